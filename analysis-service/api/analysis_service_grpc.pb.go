@@ -19,9 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AnalysisService_AnalyseTask_FullMethodName       = "/analysis.v1.AnalysisService/AnalyseTask"
-	AnalysisService_GetReport_FullMethodName         = "/analysis.v1.AnalysisService/GetReport"
-	AnalysisService_GenerateWordCloud_FullMethodName = "/analysis.v1.AnalysisService/GenerateWordCloud"
+	AnalysisService_AnalyseTask_FullMethodName          = "/analysis.v1.AnalysisService/AnalyseTask"
+	AnalysisService_GetReport_FullMethodName            = "/analysis.v1.AnalysisService/GetReport"
+	AnalysisService_GenerateWordCloud_FullMethodName    = "/analysis.v1.AnalysisService/GenerateWordCloud"
+	AnalysisService_WatchAnalysis_FullMethodName        = "/analysis.v1.AnalysisService/WatchAnalysis"
+	AnalysisService_CancelAnalysis_FullMethodName       = "/analysis.v1.AnalysisService/CancelAnalysis"
+	AnalysisService_ReanalyseTask_FullMethodName        = "/analysis.v1.AnalysisService/ReanalyseTask"
+	AnalysisService_ListReportVersions_FullMethodName   = "/analysis.v1.AnalysisService/ListReportVersions"
+	AnalysisService_ListReports_FullMethodName          = "/analysis.v1.AnalysisService/ListReports"
+	AnalysisService_PurgeTask_FullMethodName            = "/analysis.v1.AnalysisService/PurgeTask"
+	AnalysisService_ListSourceMatches_FullMethodName    = "/analysis.v1.AnalysisService/ListSourceMatches"
+	AnalysisService_StartBulkReanalysis_FullMethodName  = "/analysis.v1.AnalysisService/StartBulkReanalysis"
+	AnalysisService_GetBulkReanalysis_FullMethodName    = "/analysis.v1.AnalysisService/GetBulkReanalysis"
+	AnalysisService_CancelBulkReanalysis_FullMethodName = "/analysis.v1.AnalysisService/CancelBulkReanalysis"
 )
 
 // AnalysisServiceClient is the client API for AnalysisService service.
@@ -31,6 +41,16 @@ type AnalysisServiceClient interface {
 	AnalyseTask(ctx context.Context, in *AnalyzeTaskRequest, opts ...grpc.CallOption) (*AnalyseTaskResponse, error)
 	GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error)
 	GenerateWordCloud(ctx context.Context, in *GenerateWordCloudRequest, opts ...grpc.CallOption) (*GenerateWordCloudResponse, error)
+	WatchAnalysis(ctx context.Context, in *WatchAnalysisRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AnalysisEvent], error)
+	CancelAnalysis(ctx context.Context, in *CancelAnalysisRequest, opts ...grpc.CallOption) (*CancelAnalysisResponse, error)
+	ReanalyseTask(ctx context.Context, in *ReanalyseTaskRequest, opts ...grpc.CallOption) (*ReanalyseTaskResponse, error)
+	ListReportVersions(ctx context.Context, in *ListReportVersionsRequest, opts ...grpc.CallOption) (*ListReportVersionsResponse, error)
+	ListReports(ctx context.Context, in *ListReportsRequest, opts ...grpc.CallOption) (*ListReportsResponse, error)
+	PurgeTask(ctx context.Context, in *PurgeTaskRequest, opts ...grpc.CallOption) (*PurgeTaskResponse, error)
+	ListSourceMatches(ctx context.Context, in *ListSourceMatchesRequest, opts ...grpc.CallOption) (*ListSourceMatchesResponse, error)
+	StartBulkReanalysis(ctx context.Context, in *StartBulkReanalysisRequest, opts ...grpc.CallOption) (*StartBulkReanalysisResponse, error)
+	GetBulkReanalysis(ctx context.Context, in *GetBulkReanalysisRequest, opts ...grpc.CallOption) (*GetBulkReanalysisResponse, error)
+	CancelBulkReanalysis(ctx context.Context, in *CancelBulkReanalysisRequest, opts ...grpc.CallOption) (*CancelBulkReanalysisResponse, error)
 }
 
 type analysisServiceClient struct {
@@ -71,6 +91,115 @@ func (c *analysisServiceClient) GenerateWordCloud(ctx context.Context, in *Gener
 	return out, nil
 }
 
+func (c *analysisServiceClient) WatchAnalysis(ctx context.Context, in *WatchAnalysisRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AnalysisEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AnalysisService_ServiceDesc.Streams[0], AnalysisService_WatchAnalysis_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAnalysisRequest, AnalysisEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AnalysisService_WatchAnalysisClient = grpc.ServerStreamingClient[AnalysisEvent]
+
+func (c *analysisServiceClient) CancelAnalysis(ctx context.Context, in *CancelAnalysisRequest, opts ...grpc.CallOption) (*CancelAnalysisResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelAnalysisResponse)
+	err := c.cc.Invoke(ctx, AnalysisService_CancelAnalysis_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analysisServiceClient) ReanalyseTask(ctx context.Context, in *ReanalyseTaskRequest, opts ...grpc.CallOption) (*ReanalyseTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReanalyseTaskResponse)
+	err := c.cc.Invoke(ctx, AnalysisService_ReanalyseTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analysisServiceClient) ListReportVersions(ctx context.Context, in *ListReportVersionsRequest, opts ...grpc.CallOption) (*ListReportVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReportVersionsResponse)
+	err := c.cc.Invoke(ctx, AnalysisService_ListReportVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analysisServiceClient) ListReports(ctx context.Context, in *ListReportsRequest, opts ...grpc.CallOption) (*ListReportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReportsResponse)
+	err := c.cc.Invoke(ctx, AnalysisService_ListReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analysisServiceClient) PurgeTask(ctx context.Context, in *PurgeTaskRequest, opts ...grpc.CallOption) (*PurgeTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeTaskResponse)
+	err := c.cc.Invoke(ctx, AnalysisService_PurgeTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analysisServiceClient) ListSourceMatches(ctx context.Context, in *ListSourceMatchesRequest, opts ...grpc.CallOption) (*ListSourceMatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSourceMatchesResponse)
+	err := c.cc.Invoke(ctx, AnalysisService_ListSourceMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analysisServiceClient) StartBulkReanalysis(ctx context.Context, in *StartBulkReanalysisRequest, opts ...grpc.CallOption) (*StartBulkReanalysisResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartBulkReanalysisResponse)
+	err := c.cc.Invoke(ctx, AnalysisService_StartBulkReanalysis_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analysisServiceClient) GetBulkReanalysis(ctx context.Context, in *GetBulkReanalysisRequest, opts ...grpc.CallOption) (*GetBulkReanalysisResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBulkReanalysisResponse)
+	err := c.cc.Invoke(ctx, AnalysisService_GetBulkReanalysis_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analysisServiceClient) CancelBulkReanalysis(ctx context.Context, in *CancelBulkReanalysisRequest, opts ...grpc.CallOption) (*CancelBulkReanalysisResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelBulkReanalysisResponse)
+	err := c.cc.Invoke(ctx, AnalysisService_CancelBulkReanalysis_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AnalysisServiceServer is the server API for AnalysisService service.
 // All implementations must embed UnimplementedAnalysisServiceServer
 // for forward compatibility.
@@ -78,6 +207,16 @@ type AnalysisServiceServer interface {
 	AnalyseTask(context.Context, *AnalyzeTaskRequest) (*AnalyseTaskResponse, error)
 	GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error)
 	GenerateWordCloud(context.Context, *GenerateWordCloudRequest) (*GenerateWordCloudResponse, error)
+	WatchAnalysis(*WatchAnalysisRequest, grpc.ServerStreamingServer[AnalysisEvent]) error
+	CancelAnalysis(context.Context, *CancelAnalysisRequest) (*CancelAnalysisResponse, error)
+	ReanalyseTask(context.Context, *ReanalyseTaskRequest) (*ReanalyseTaskResponse, error)
+	ListReportVersions(context.Context, *ListReportVersionsRequest) (*ListReportVersionsResponse, error)
+	ListReports(context.Context, *ListReportsRequest) (*ListReportsResponse, error)
+	PurgeTask(context.Context, *PurgeTaskRequest) (*PurgeTaskResponse, error)
+	ListSourceMatches(context.Context, *ListSourceMatchesRequest) (*ListSourceMatchesResponse, error)
+	StartBulkReanalysis(context.Context, *StartBulkReanalysisRequest) (*StartBulkReanalysisResponse, error)
+	GetBulkReanalysis(context.Context, *GetBulkReanalysisRequest) (*GetBulkReanalysisResponse, error)
+	CancelBulkReanalysis(context.Context, *CancelBulkReanalysisRequest) (*CancelBulkReanalysisResponse, error)
 	mustEmbedUnimplementedAnalysisServiceServer()
 }
 
@@ -97,6 +236,36 @@ func (UnimplementedAnalysisServiceServer) GetReport(context.Context, *GetReportR
 func (UnimplementedAnalysisServiceServer) GenerateWordCloud(context.Context, *GenerateWordCloudRequest) (*GenerateWordCloudResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateWordCloud not implemented")
 }
+func (UnimplementedAnalysisServiceServer) WatchAnalysis(*WatchAnalysisRequest, grpc.ServerStreamingServer[AnalysisEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAnalysis not implemented")
+}
+func (UnimplementedAnalysisServiceServer) CancelAnalysis(context.Context, *CancelAnalysisRequest) (*CancelAnalysisResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAnalysis not implemented")
+}
+func (UnimplementedAnalysisServiceServer) ReanalyseTask(context.Context, *ReanalyseTaskRequest) (*ReanalyseTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReanalyseTask not implemented")
+}
+func (UnimplementedAnalysisServiceServer) ListReportVersions(context.Context, *ListReportVersionsRequest) (*ListReportVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReportVersions not implemented")
+}
+func (UnimplementedAnalysisServiceServer) ListReports(context.Context, *ListReportsRequest) (*ListReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReports not implemented")
+}
+func (UnimplementedAnalysisServiceServer) PurgeTask(context.Context, *PurgeTaskRequest) (*PurgeTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeTask not implemented")
+}
+func (UnimplementedAnalysisServiceServer) ListSourceMatches(context.Context, *ListSourceMatchesRequest) (*ListSourceMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSourceMatches not implemented")
+}
+func (UnimplementedAnalysisServiceServer) StartBulkReanalysis(context.Context, *StartBulkReanalysisRequest) (*StartBulkReanalysisResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartBulkReanalysis not implemented")
+}
+func (UnimplementedAnalysisServiceServer) GetBulkReanalysis(context.Context, *GetBulkReanalysisRequest) (*GetBulkReanalysisResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBulkReanalysis not implemented")
+}
+func (UnimplementedAnalysisServiceServer) CancelBulkReanalysis(context.Context, *CancelBulkReanalysisRequest) (*CancelBulkReanalysisResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBulkReanalysis not implemented")
+}
 func (UnimplementedAnalysisServiceServer) mustEmbedUnimplementedAnalysisServiceServer() {}
 func (UnimplementedAnalysisServiceServer) testEmbeddedByValue()                         {}
 
@@ -172,6 +341,179 @@ func _AnalysisService_GenerateWordCloud_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AnalysisService_WatchAnalysis_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAnalysisRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnalysisServiceServer).WatchAnalysis(m, &grpc.GenericServerStream[WatchAnalysisRequest, AnalysisEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AnalysisService_WatchAnalysisServer = grpc.ServerStreamingServer[AnalysisEvent]
+
+func _AnalysisService_CancelAnalysis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelAnalysisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalysisServiceServer).CancelAnalysis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalysisService_CancelAnalysis_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalysisServiceServer).CancelAnalysis(ctx, req.(*CancelAnalysisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalysisService_ReanalyseTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReanalyseTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalysisServiceServer).ReanalyseTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalysisService_ReanalyseTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalysisServiceServer).ReanalyseTask(ctx, req.(*ReanalyseTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalysisService_ListReportVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReportVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalysisServiceServer).ListReportVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalysisService_ListReportVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalysisServiceServer).ListReportVersions(ctx, req.(*ListReportVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalysisService_ListReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalysisServiceServer).ListReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalysisService_ListReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalysisServiceServer).ListReports(ctx, req.(*ListReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalysisService_PurgeTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalysisServiceServer).PurgeTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalysisService_PurgeTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalysisServiceServer).PurgeTask(ctx, req.(*PurgeTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalysisService_ListSourceMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSourceMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalysisServiceServer).ListSourceMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalysisService_ListSourceMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalysisServiceServer).ListSourceMatches(ctx, req.(*ListSourceMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalysisService_StartBulkReanalysis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartBulkReanalysisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalysisServiceServer).StartBulkReanalysis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalysisService_StartBulkReanalysis_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalysisServiceServer).StartBulkReanalysis(ctx, req.(*StartBulkReanalysisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalysisService_GetBulkReanalysis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBulkReanalysisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalysisServiceServer).GetBulkReanalysis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalysisService_GetBulkReanalysis_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalysisServiceServer).GetBulkReanalysis(ctx, req.(*GetBulkReanalysisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalysisService_CancelBulkReanalysis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBulkReanalysisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalysisServiceServer).CancelBulkReanalysis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalysisService_CancelBulkReanalysis_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalysisServiceServer).CancelBulkReanalysis(ctx, req.(*CancelBulkReanalysisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AnalysisService_ServiceDesc is the grpc.ServiceDesc for AnalysisService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -191,7 +533,49 @@ var AnalysisService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateWordCloud",
 			Handler:    _AnalysisService_GenerateWordCloud_Handler,
 		},
+		{
+			MethodName: "CancelAnalysis",
+			Handler:    _AnalysisService_CancelAnalysis_Handler,
+		},
+		{
+			MethodName: "ReanalyseTask",
+			Handler:    _AnalysisService_ReanalyseTask_Handler,
+		},
+		{
+			MethodName: "ListReportVersions",
+			Handler:    _AnalysisService_ListReportVersions_Handler,
+		},
+		{
+			MethodName: "ListReports",
+			Handler:    _AnalysisService_ListReports_Handler,
+		},
+		{
+			MethodName: "PurgeTask",
+			Handler:    _AnalysisService_PurgeTask_Handler,
+		},
+		{
+			MethodName: "ListSourceMatches",
+			Handler:    _AnalysisService_ListSourceMatches_Handler,
+		},
+		{
+			MethodName: "StartBulkReanalysis",
+			Handler:    _AnalysisService_StartBulkReanalysis_Handler,
+		},
+		{
+			MethodName: "GetBulkReanalysis",
+			Handler:    _AnalysisService_GetBulkReanalysis_Handler,
+		},
+		{
+			MethodName: "CancelBulkReanalysis",
+			Handler:    _AnalysisService_CancelBulkReanalysis_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAnalysis",
+			Handler:       _AnalysisService_WatchAnalysis_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/analysis_service.proto",
 }
@@ -2,8 +2,11 @@ package main
 
 import (
 	"analysis-service/internal/config"
+	"analysis-service/internal/infrastructure/envelope"
+	"analysis-service/internal/infrastructure/fsstore"
 	"analysis-service/internal/infrastructure/minio"
 	"analysis-service/internal/infrastructure/pgdb"
+	"analysis-service/internal/infrastructure/storing"
 	"analysis-service/internal/transport"
 	"analysis-service/internal/usecase"
 	pb "analysis-service/pkg/api"
@@ -14,6 +17,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"storing-service/pkg/identity"
 	"syscall"
 
 	"go.uber.org/zap"
@@ -41,24 +45,56 @@ func main() {
 	defer db.Close()
 	appLogger.Info("database init success")
 
-	minioClient, err := minio.NewClient(ctx, &cfg.Minio)
-	if err != nil {
-		appLogger.Fatal("failed to connect to minio", zap.Error(err))
+	var backend envelope.Backend
+	switch cfg.Storage.Backend {
+	case config.StorageBackendFS:
+		backend, err = fsstore.NewStore(&cfg.Storage)
+		if err != nil {
+			appLogger.Fatal("failed to open filesystem storage", zap.Error(err))
+		}
+		appLogger.Info("filesystem storage init success", zap.String("root", cfg.Storage.FSRoot))
+	default:
+		backend, err = minio.NewClient(ctx, &cfg.Minio)
+		if err != nil {
+			appLogger.Fatal("failed to connect to minio", zap.Error(err))
+		}
+		appLogger.Info("minio init success")
+	}
+
+	fileStorage := backend.(usecase.BlobStore)
+	if cfg.Encryption.Mode != config.EncryptionNone {
+		fileStorage, err = envelope.NewStore(backend, &cfg.Encryption)
+		if err != nil {
+			appLogger.Fatal("failed to init file decryption", zap.Error(err))
+		}
+		appLogger.Info("file decryption enabled", zap.String("mode", cfg.Encryption.Mode))
 	}
-	appLogger.Info("minio init success")
 
 	comparator := usecase.NewTextComparator()
 
 	repo := pgdb.NewAnalysisRepository(db, appLogger)
-	service := usecase.NewAnalysisService(repo, minioClient, comparator, appLogger)
-	handler := transport.NewAnalysisHandler(service, appLogger)
+	service := usecase.NewAnalysisService(repo, fileStorage, comparator, &cfg.Analysis, appLogger)
+	if err := service.RecoverReanalysisJobs(ctx); err != nil {
+		appLogger.Warn("failed to recover reanalysis jobs", zap.Error(err))
+	}
+
+	storingClient, err := storing.NewClient(ctx, cfg.Storing.URL, cfg.Identity.Secret)
+	if err != nil {
+		appLogger.Fatal("storing client init failed", zap.Error(err))
+	}
+	defer storingClient.Close()
+
+	handler := transport.NewAnalysisHandler(service, storingClient, appLogger)
 
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.App.GrpcPort))
 	if err != nil {
 		appLogger.Fatal("failed to listen", zap.Error(err))
 	}
 
-	server := grpc.NewServer()
+	verifier := identity.NewVerifier(cfg.Identity.Secret)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(verifier.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(verifier.StreamServerInterceptor))
 	pb.RegisterAnalysisServiceServer(server, handler)
 
 	go func() {
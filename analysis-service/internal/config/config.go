@@ -1,16 +1,30 @@
 package config
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"runtime"
+	"storing-service/pkg/tenant"
+	"strconv"
+	"strings"
 )
 
 var (
-	dbUserEmptyError = errors.New("DB User is Empty")
-	dbNameEmptyError = errors.New("DB Name is Empty")
+	dbUserEmptyError       = errors.New("DB User is Empty")
+	dbNameEmptyError       = errors.New("DB Name is Empty")
+	unknownBackendError    = errors.New("STORAGE_BACKEND must be minio or fs")
+	unknownEncryptionError = errors.New("ENCRYPTION_MODE must be none, client or sse-c")
+	ssecBackendError       = errors.New("ENCRYPTION_MODE=sse-c requires STORAGE_BACKEND=minio")
+	ssecTLSError           = errors.New("ENCRYPTION_MODE=sse-c requires MINIO_USE_SSL=true")
+	identitySecretError    = errors.New("IDENTITY_SECRET of at least 32 characters is required")
 )
 
+// minIdentitySecretLength - минимальная длина IDENTITY_SECRET
+const minIdentitySecretLength = 32
+
 type AppConfig struct {
 	GrpcPort string
 }
@@ -29,17 +43,69 @@ type MinioConfig struct {
 	AccessKey        string
 	SecretKey        string
 	Bucket           string
+	// TLS до MinIO, обязателен для ENCRYPTION_MODE=sse-c
+	UseSSL bool
+}
+
+const (
+	StorageBackendMinio = "minio"
+	StorageBackendFS    = "fs"
+)
+
+type StorageConfig struct {
+	// Хранилище файлов: minio (MinIO/S3) или fs (каталог, общий со storing-service)
+	Backend string
+	FSRoot  string
+}
+
+const (
+	EncryptionNone   = "none"
+	EncryptionClient = "client"
+	EncryptionSSEC   = "sse-c"
+)
+
+type EncryptionConfig struct {
+	// Режим шифрования файлов, должен совпадать с storing-service
+	Mode string
+	// Мастер-ключи storing-service по идентификатору, нужны для расшифровки файлов
+	Keys map[string][]byte
 }
 
 type LoggerConfig struct {
 	Level string
 }
 
+// IdentityConfig - общий секрет api-gateway и сервисов, которым подписывается клиент запроса
+type IdentityConfig struct {
+	Secret []byte
+}
+
+// StoringConfig - адрес storing-service, у которого проверяется владелец задачи без отчетов
+type StoringConfig struct {
+	URL string
+}
+
+type AnalysisConfig struct {
+	FetchConcurrency    int
+	CompareWorkers      int
+	PlagiarismThreshold float64
+	MaxReportSources    int
+	// Скорость массового повторного анализа по умолчанию (задач в минуту)
+	ReanalysisRatePerMinute int
+	// Пороги плагиата тенантов из TENANTS_FILE, заменяют PlagiarismThreshold для тенанта
+	TenantThresholds map[string]float64
+}
+
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	Minio    MinioConfig
-	Logger   LoggerConfig
+	App        AppConfig
+	Database   DatabaseConfig
+	Minio      MinioConfig
+	Storage    StorageConfig
+	Encryption EncryptionConfig
+	Logger     LoggerConfig
+	Identity   IdentityConfig
+	Storing    StoringConfig
+	Analysis   AnalysisConfig
 }
 
 func LoadConfig() (*Config, error) {
@@ -59,10 +125,28 @@ func LoadConfig() (*Config, error) {
 			AccessKey:        getEnv("MINIO_ACCESS_KEY", "user"),
 			SecretKey:        getEnv("MINIO_SECRET_KEY", "password"),
 			Bucket:           getEnv("MINIO_BUCKET", "tasks"),
+			UseSSL:           getEnvBool("MINIO_USE_SSL", false),
+		},
+		Storage: StorageConfig{
+			Backend: strings.ToLower(getEnv("STORAGE_BACKEND", StorageBackendMinio)),
+			FSRoot:  getEnv("STORAGE_FS_ROOT", "./data/blobs"),
+		},
+		Encryption: EncryptionConfig{
+			Mode: strings.ToLower(getEnv("ENCRYPTION_MODE", EncryptionNone)),
 		},
 		Logger: LoggerConfig{
 			Level: getEnv("LOG_LEVEL", "prod"),
 		},
+		Storing: StoringConfig{
+			URL: getEnv("STORING_SERVICE_URL", "localhost:50051"),
+		},
+		Analysis: AnalysisConfig{
+			FetchConcurrency:        getEnvInt("ANALYSIS_FETCH_CONCURRENCY", 8),
+			CompareWorkers:          getEnvInt("ANALYSIS_COMPARE_WORKERS", runtime.NumCPU()),
+			PlagiarismThreshold:     getEnvFloat("PLAGIARISM_THRESHOLD", 50.0),
+			MaxReportSources:        getEnvInt("REPORT_MAX_SOURCES", 10),
+			ReanalysisRatePerMinute: getEnvInt("REANALYSIS_RATE_PER_MINUTE", 30),
+		},
 	}
 
 	err := makeDbUrl(c)
@@ -70,9 +154,48 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	c.Identity.Secret = []byte(getEnv("IDENTITY_SECRET", ""))
+	if len(c.Identity.Secret) < minIdentitySecretLength {
+		return nil, identitySecretError
+	}
+
+	c.Analysis.TenantThresholds, err = loadTenantThresholds(getEnv("TENANTS_FILE", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	c.Encryption.Keys, err = parseMasterKeys(getEnv("ENCRYPTION_KEYS", ""))
+	if err != nil {
+		return nil, err
+	}
+	if err := validateStorage(&c.Storage, &c.Minio, &c.Encryption); err != nil {
+		return nil, err
+	}
+
 	return c, nil
 }
 
+func validateStorage(cfg *StorageConfig, minio *MinioConfig, encryption *EncryptionConfig) error {
+	if cfg.Backend != StorageBackendMinio && cfg.Backend != StorageBackendFS {
+		return unknownBackendError
+	}
+
+	switch encryption.Mode {
+	case EncryptionNone, EncryptionClient:
+	case EncryptionSSEC:
+		if cfg.Backend != StorageBackendMinio {
+			return ssecBackendError
+		}
+		// MinIO принимает ключи SSE-C только по TLS, без него ключ ушел бы по сети открытым текстом
+		if !minio.UseSSL {
+			return ssecTLSError
+		}
+	default:
+		return unknownEncryptionError
+	}
+	return nil
+}
+
 func getEnv(key, fallback string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
@@ -80,6 +203,91 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+func getEnvInt(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return fallback
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+// parseMasterKeys разбирает список вида "2024-01:base64,2024-07:base64" в формате storing-service
+func parseMasterKeys(value string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid ENCRYPTION_KEYS entry, expected id:base64")
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("encryption key %s must be 32 bytes in base64", id)
+		}
+		keys[id] = key
+	}
+	return keys, nil
+}
+
+// tenantSettings - настройки тенанта в TENANTS_FILE, общем со storing-service;
+// analysis-service читает только порог плагиата
+type tenantSettings struct {
+	PlagiarismThreshold *float64 `json:"plagiarism_threshold"`
+}
+
+// loadTenantThresholds читает пороги плагиата тенантов из JSON-файла вида {"law": {"plagiarism_threshold": 30}}
+func loadTenantThresholds(path string) (map[string]float64, error) {
+	thresholds := make(map[string]float64)
+	if path == "" {
+		return thresholds, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TENANTS_FILE: %w", err)
+	}
+	var tenants map[string]tenantSettings
+	if err := json.Unmarshal(data, &tenants); err != nil {
+		return nil, fmt.Errorf("invalid TENANTS_FILE: %w", err)
+	}
+
+	for id, settings := range tenants {
+		if !tenant.Valid(id) {
+			return nil, fmt.Errorf("TENANTS_FILE has invalid tenant id %q", id)
+		}
+		if settings.PlagiarismThreshold == nil {
+			continue
+		}
+		if threshold := *settings.PlagiarismThreshold; threshold < 0 || threshold > 100 {
+			return nil, fmt.Errorf("tenant %s has invalid plagiarism_threshold %v", id, threshold)
+		}
+		thresholds[id] = *settings.PlagiarismThreshold
+	}
+	return thresholds, nil
+}
+
 func makeDbUrl(cfg *Config) error {
 	if cfg.Database.URL == "" {
 		if cfg.Database.User == "" {
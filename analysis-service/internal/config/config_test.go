@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateStorage(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend string
+		useSSL  bool
+		mode    string
+		wantErr error
+	}{
+		{name: "minio without encryption", backend: StorageBackendMinio, mode: EncryptionNone},
+		{name: "client encryption on fs", backend: StorageBackendFS, mode: EncryptionClient},
+		{name: "sse-c over tls", backend: StorageBackendMinio, useSSL: true, mode: EncryptionSSEC},
+		{name: "sse-c without tls", backend: StorageBackendMinio, mode: EncryptionSSEC, wantErr: ssecTLSError},
+		{name: "sse-c on fs", backend: StorageBackendFS, useSSL: true, mode: EncryptionSSEC, wantErr: ssecBackendError},
+		{name: "unknown backend", backend: "s3", mode: EncryptionNone, wantErr: unknownBackendError},
+		{name: "unknown mode", backend: StorageBackendMinio, mode: "rot13", wantErr: unknownEncryptionError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStorage(
+				&StorageConfig{Backend: tt.backend},
+				&MinioConfig{UseSSL: tt.useSSL},
+				&EncryptionConfig{Mode: tt.mode})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateStorage() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfigRejectsSSECWithoutTLS(t *testing.T) {
+	t.Setenv("IDENTITY_SECRET", "0123456789abcdef0123456789abcdef")
+	t.Setenv("STORAGE_BACKEND", StorageBackendMinio)
+	t.Setenv("ENCRYPTION_MODE", EncryptionSSEC)
+	t.Setenv("MINIO_USE_SSL", "false")
+
+	if _, err := LoadConfig(); !errors.Is(err, ssecTLSError) {
+		t.Fatalf("LoadConfig() = %v, want %v", err, ssecTLSError)
+	}
+
+	t.Setenv("MINIO_USE_SSL", "true")
+	if _, err := LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig() with MINIO_USE_SSL=true = %v", err)
+	}
+}
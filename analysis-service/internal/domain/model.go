@@ -6,8 +6,159 @@ import (
 )
 
 type Report struct {
+	Id                   uuid.UUID
 	TaskId               uuid.UUID
+	Version              int
+	ObjectKey            string
+	AssignmentId         string
+	Owner                ReportOwner
+	AlgorithmVersion     string
+	Policy               ReportPolicy
 	IsPlagiarism         bool
 	PlagiarismPercentage float64
-	CreatedAt            time.Time
+	// Задача с побайтово совпадающим файлом, uuid.Nil - отчет построен сравнением
+	DuplicateOf uuid.UUID
+	// Задачи, исключенные из сравнения (предыдущие версии посылки того же студента)
+	ExcludedTaskIds  []uuid.UUID
+	CorpusSnapshotAt time.Time
+	CreatedAt        time.Time
+	Sources          []Match
+}
+
+// ReportOwner - автор и курс проверенной работы, по ним проверяется доступ к отчету.
+// У отчетов, созданных до появления владельца, UploadedBy равен uuid.Nil.
+type ReportOwner struct {
+	UploadedBy uuid.UUID
+	CourseId   string
+}
+
+type ReportPolicy struct {
+	Threshold float64 `json:"threshold"`
+}
+
+type Match struct {
+	TaskId     uuid.UUID
+	ObjectKey  string
+	Percentage float64
+	// Фрагменты проверяемого файла, найденные в файле-источнике
+	Spans []MatchedSpan
+	// Задание задачи-источника по ее последнему отчету, заполняется при чтении отчета
+	AssignmentId string
+}
+
+// MatchedSpan - фрагмент проверяемого файла: байты [Start, End) исходного файла
+type MatchedSpan struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// SourceMatch - совпадение, в котором задача найдена источником в отчете другой задачи
+type SourceMatch struct {
+	SourceTaskId uuid.UUID
+	Percentage   float64
+	IsPlagiarism bool
+	MatchedAt    time.Time
+}
+
+// ReportFilter задает отбор последних версий отчетов. Пустые поля не ограничивают выборку.
+type ReportFilter struct {
+	AssignmentId  string
+	IsPlagiarism  *bool
+	MinPercentage *float64
+	MaxPercentage *float64
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	// Ограничение доступа клиента, nil - без ограничений
+	Scope *AccessScope
+}
+
+// AccessScope - отчеты, доступные клиенту: работы автора UploadedBy и работы курсов CourseIds
+type AccessScope struct {
+	UploadedBy uuid.UUID
+	CourseIds  []string
+}
+
+// ReportCursor - позиция keyset-пагинации по (created_at, id)
+type ReportCursor struct {
+	CreatedAt time.Time
+	Id        uuid.UUID
+}
+
+type AnalysisStage string
+
+const (
+	StageExtracting AnalysisStage = "extracting"
+	StageIndexing   AnalysisStage = "indexing"
+	StageComparing  AnalysisStage = "comparing"
+	StageDone       AnalysisStage = "done"
+	StageFailed     AnalysisStage = "failed"
+	StageCancelled  AnalysisStage = "cancelled"
+)
+
+type AnalysisEvent struct {
+	TaskId               uuid.UUID
+	Stage                AnalysisStage
+	Compared             int
+	Total                int
+	IsPlagiarism         bool
+	PlagiarismPercentage float64
+	Message              string
+	Timestamp            time.Time
+}
+
+func (e AnalysisEvent) IsFinal() bool {
+	return e.Stage == StageDone || e.Stage == StageFailed || e.Stage == StageCancelled
+}
+
+type ReanalysisJobStatus string
+
+const (
+	JobPending   ReanalysisJobStatus = "pending"
+	JobRunning   ReanalysisJobStatus = "running"
+	JobCompleted ReanalysisJobStatus = "completed"
+	JobFailed    ReanalysisJobStatus = "failed"
+	JobCancelled ReanalysisJobStatus = "cancelled"
+)
+
+// ReanalysisScope задает отбор задач для массового повторного анализа.
+// Пустые поля не ограничивают выборку.
+type ReanalysisScope struct {
+	AssignmentId string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+}
+
+type ReanalysisJob struct {
+	Id                  uuid.UUID
+	Scope               ReanalysisScope
+	RatePerMinute       int
+	Status              ReanalysisJobStatus
+	Total               int
+	Processed           int
+	Failed              int
+	Skipped             int
+	FlippedToPlagiarism int
+	FlippedToClean      int
+	Error               string
+	CreatedAt           time.Time
+	StartedAt           *time.Time
+	FinishedAt          *time.Time
+}
+
+// PurgeResult - итог удаления данных анализа удаленной задачи
+type PurgeResult struct {
+	ReportsDeleted int
+	// Удаленные строки источников и сброшенные ссылки duplicate_of в отчетах других задач
+	ReferencesRemoved int
+}
+
+// ReanalysisTarget - задача, попавшая в массовый повторный анализ, с вердиктом последней версии отчета
+type ReanalysisTarget struct {
+	TaskId       uuid.UUID
+	ObjectKey    string
+	AssignmentId string
+	Owner        ReportOwner
+	IsPlagiarism bool
+	// Исключения из сравнения наследуются от последней версии отчета
+	ExcludedTaskIds []uuid.UUID
 }
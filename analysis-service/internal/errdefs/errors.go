@@ -3,8 +3,10 @@ package errdefs
 import "errors"
 
 var (
-	ErrNotFound        = errors.New("not found")
-	ErrInvalidArgument = errors.New("invalid argument")
-	ErrAlreadyExists   = errors.New("already exists")
-	ErrUnavailable     = errors.New("unavailable")
+	ErrNotFound         = errors.New("not found")
+	ErrInvalidArgument  = errors.New("invalid argument")
+	ErrAlreadyExists    = errors.New("already exists")
+	ErrUnavailable      = errors.New("unavailable")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrUnauthenticated  = errors.New("unauthenticated")
 )
@@ -1,17 +1,72 @@
 package dto
 
 import (
+	"analysis-service/internal/domain"
 	"github.com/google/uuid"
 	"time"
 )
 
 type CreateReportDTO struct {
+	Id                   uuid.UUID
 	TaskId               uuid.UUID
+	Version              int
+	ObjectKey            string
+	AssignmentId         string
+	Owner                domain.ReportOwner
+	AlgorithmVersion     string
+	Policy               domain.ReportPolicy
 	IsPlagiarism         bool
 	PlagiarismPercentage float64
+	DuplicateOf          uuid.UUID
+	ExcludedTaskIds      []uuid.UUID
+	CorpusSnapshotAt     time.Time
 	CreatedAt            time.Time
+	Sources              []domain.Match
 }
 
 type GetReportsDTO struct {
 	TaskId uuid.UUID
+	// 0 - последняя версия
+	Version int
+}
+
+type ListReportVersionsDTO struct {
+	TaskId uuid.UUID
+}
+
+type ListReportsDTO struct {
+	Filter domain.ReportFilter
+	// nil - первая страница
+	After *domain.ReportCursor
+	Limit int
+}
+
+type PurgeTaskDTO struct {
+	TaskId uuid.UUID
+	// Обезличить ссылки на задачу в отчетах других задач вместо их удаления
+	AnonymiseReferences bool
+}
+
+type ListSourceAssignmentsDTO struct {
+	TaskIds []uuid.UUID
+}
+
+type ListSourceMatchesDTO struct {
+	TaskIds []uuid.UUID
+}
+
+type CreateReanalysisJobDTO struct {
+	Id            uuid.UUID
+	Scope         domain.ReanalysisScope
+	RatePerMinute int
+	Status        domain.ReanalysisJobStatus
+	CreatedAt     time.Time
+}
+
+type GetReanalysisJobDTO struct {
+	Id uuid.UUID
+}
+
+type ListReanalysisTargetsDTO struct {
+	Scope domain.ReanalysisScope
 }
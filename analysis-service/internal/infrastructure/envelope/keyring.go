@@ -0,0 +1,113 @@
+package envelope
+
+import (
+	"analysis-service/internal/errdefs"
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Формат заголовка совпадает с envelope storing-service
+const (
+	// magic - начало заголовка зашифрованного объекта
+	magic = "APE1"
+	// noncePrefixSize - случайная часть nonce частей объекта, остальные 4 байта - номер части
+	noncePrefixSize = 8
+)
+
+// errNotEncrypted - объект записан до включения шифрования и хранится открытым
+var errNotEncrypted = errors.New("object is not encrypted")
+
+// Keyring расшифровывает ключи объектов мастер-ключами storing-service
+type Keyring struct {
+	keys map[string][]byte
+}
+
+func NewKeyring(keys map[string][]byte) *Keyring {
+	return &Keyring{keys: keys}
+}
+
+// header - заголовок объекта: идентификатор мастер-ключа, зашифрованный им ключ объекта
+// и случайная часть nonce
+type header struct {
+	keyId       string
+	wrappedKey  []byte
+	noncePrefix []byte
+}
+
+// unwrap расшифровывает ключ объекта мастер-ключом из заголовка
+func (k *Keyring) unwrap(h *header) ([]byte, error) {
+	master, ok := k.keys[h.keyId]
+	if !ok {
+		return nil, fmt.Errorf("%w: master key %s is not configured", errdefs.ErrUnavailable, h.keyId)
+	}
+
+	aead, err := newGCM(master)
+	if err != nil {
+		return nil, err
+	}
+	if len(h.wrappedKey) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: invalid wrapped data key", errdefs.ErrUnavailable)
+	}
+
+	nonce, sealed := h.wrappedKey[:aead.NonceSize()], h.wrappedKey[aead.NonceSize():]
+	dataKey, err := aead.Open(nil, nonce, sealed, []byte(h.keyId))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to unwrap data key with master key %s", errdefs.ErrUnavailable, h.keyId)
+	}
+	return dataKey, nil
+}
+
+// readHeader читает заголовок из начала объекта. Для открытого объекта возвращает errNotEncrypted,
+// ничего не прочитав из r.
+func readHeader(r *bufio.Reader) (*header, error) {
+	prefix, err := r.Peek(len(magic))
+	if err != nil || string(prefix) != magic {
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		return nil, errNotEncrypted
+	}
+	r.Discard(len(magic))
+
+	keyIdLen, err := r.ReadByte()
+	if err != nil {
+		return nil, corrupted(err)
+	}
+	keyId := make([]byte, keyIdLen)
+	if _, err := io.ReadFull(r, keyId); err != nil {
+		return nil, corrupted(err)
+	}
+
+	var wrappedLen uint16
+	if err := binary.Read(r, binary.BigEndian, &wrappedLen); err != nil {
+		return nil, corrupted(err)
+	}
+	wrappedKey := make([]byte, wrappedLen)
+	if _, err := io.ReadFull(r, wrappedKey); err != nil {
+		return nil, corrupted(err)
+	}
+
+	noncePrefix := make([]byte, noncePrefixSize)
+	if _, err := io.ReadFull(r, noncePrefix); err != nil {
+		return nil, corrupted(err)
+	}
+
+	return &header{keyId: string(keyId), wrappedKey: wrappedKey, noncePrefix: noncePrefix}, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func corrupted(err error) error {
+	return fmt.Errorf("%w: encrypted object is corrupted: %v", errdefs.ErrUnavailable, err)
+}
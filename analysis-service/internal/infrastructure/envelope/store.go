@@ -0,0 +1,130 @@
+package envelope
+
+import (
+	"analysis-service/internal/config"
+	"analysis-service/internal/errdefs"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// keysPrefix - префикс объектов с заголовками ключей в режиме SSE-C
+const keysPrefix = ".keys/"
+
+// Backend - хранилище, из которого читаются зашифрованные файлы
+type Backend interface {
+	Get(ctx context.Context, objectKey string) (io.ReadCloser, error)
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// SSECBackend - хранилище, расшифровывающее объекты ключом из запроса (MinIO SSE-C)
+type SSECBackend interface {
+	Backend
+	GetSSEC(ctx context.Context, objectKey string, key []byte) (io.ReadCloser, error)
+}
+
+// Store расшифровывает файлы, зашифрованные storing-service. Файлы, записанные
+// до включения шифрования, читаются как есть.
+type Store struct {
+	backend Backend
+	ssec    SSECBackend
+	keyring *Keyring
+}
+
+func NewStore(backend Backend, cfg *config.EncryptionConfig) (*Store, error) {
+	s := &Store{
+		backend: backend,
+		keyring: NewKeyring(cfg.Keys),
+	}
+
+	if cfg.Mode == config.EncryptionSSEC {
+		ssec, ok := backend.(SSECBackend)
+		if !ok {
+			return nil, fmt.Errorf("storage backend does not support SSE-C")
+		}
+		s.ssec = ssec
+	}
+	return s, nil
+}
+
+func (s *Store) Get(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	if s.ssec != nil {
+		h, err := s.keyHeader(ctx, objectKey)
+		if errors.Is(err, errNotEncrypted) {
+			return s.backend.Get(ctx, objectKey)
+		}
+		if err != nil {
+			return nil, err
+		}
+		dataKey, err := s.keyring.unwrap(h)
+		if err != nil {
+			return nil, err
+		}
+		return s.ssec.GetSSEC(ctx, objectKey, dataKey)
+	}
+
+	obj, err := s.backend.Get(ctx, objectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	r := bufio.NewReaderSize(obj, chunkSize)
+	h, err := readHeader(r)
+	if errors.Is(err, errNotEncrypted) {
+		return &bufferedReadCloser{Reader: r, Closer: obj}, nil
+	}
+	if err != nil {
+		obj.Close()
+		return nil, err
+	}
+
+	dataKey, err := s.keyring.unwrap(h)
+	if err != nil {
+		obj.Close()
+		return nil, err
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		obj.Close()
+		return nil, err
+	}
+	return newDecryptReader(r, aead, h.noncePrefix, obj), nil
+}
+
+// List возвращает ключи файлов без служебных объектов с заголовками ключей
+func (s *Store) List(ctx context.Context, prefix string) ([]string, error) {
+	keys, err := s.backend.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := keys[:0]
+	for _, key := range keys {
+		if !strings.HasPrefix(key, keysPrefix) {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered, nil
+}
+
+// keyHeader читает заголовок ключа объекта SSE-C
+func (s *Store) keyHeader(ctx context.Context, objectKey string) (*header, error) {
+	obj, err := s.backend.Get(ctx, keysPrefix+objectKey)
+	if errors.Is(err, errdefs.ErrNotFound) {
+		return nil, errNotEncrypted
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+
+	return readHeader(bufio.NewReader(obj))
+}
+
+type bufferedReadCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,98 @@
+package envelope
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// chunkSize - размер части открытого текста. Каждая часть зашифрована AES-GCM отдельно,
+// последняя помечена в дополнительных данных.
+const chunkSize = 64 << 10
+
+// decryptReader расшифровывает поток частей, записанный storing-service
+type decryptReader struct {
+	src         *bufio.Reader
+	aead        cipher.AEAD
+	noncePrefix []byte
+	counter     uint32
+	buf         []byte
+	result      []byte
+	out         []byte
+	done        bool
+	closer      io.Closer
+}
+
+func newDecryptReader(src *bufio.Reader, aead cipher.AEAD, noncePrefix []byte, closer io.Closer) *decryptReader {
+	return &decryptReader{
+		src:         src,
+		aead:        aead,
+		noncePrefix: noncePrefix,
+		buf:         make([]byte, chunkSize+aead.Overhead()),
+		closer:      closer,
+	}
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.fill(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *decryptReader) fill() error {
+	n, err := io.ReadFull(r.src, r.buf)
+	final := false
+	switch {
+	case errors.Is(err, io.EOF):
+		// Поток закончился без последней части
+		return corrupted(io.ErrUnexpectedEOF)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		final = true
+	case err != nil:
+		return err
+	default:
+		if _, err := r.src.Peek(1); errors.Is(err, io.EOF) {
+			final = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	r.result, err = r.aead.Open(r.result[:0], chunkNonce(r.noncePrefix, r.counter), r.buf[:n], chunkAAD(final))
+	if err != nil {
+		return corrupted(err)
+	}
+	r.out = r.result
+	r.counter++
+	r.done = final
+	return nil
+}
+
+func (r *decryptReader) Close() error {
+	return r.closer.Close()
+}
+
+func chunkNonce(prefix []byte, counter uint32) []byte {
+	nonce := make([]byte, noncePrefixSize+4)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[noncePrefixSize:], counter)
+	return nonce
+}
+
+func chunkAAD(final bool) []byte {
+	if final {
+		return []byte{1}
+	}
+	return []byte{0}
+}
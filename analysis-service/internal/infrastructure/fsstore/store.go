@@ -0,0 +1,78 @@
+package fsstore
+
+import (
+	"analysis-service/internal/config"
+	"analysis-service/internal/errdefs"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// tempPrefix - префикс незавершенных загрузок storing-service, такие файлы не анализируются
+const tempPrefix = ".upload-"
+
+// Store читает файлы задач из каталога, в который их записывает storing-service
+type Store struct {
+	root string
+}
+
+func NewStore(cfg *config.StorageConfig) (*Store, error) {
+	// Каталог может быть еще не создан storing-service
+	if err := os.MkdirAll(cfg.FSRoot, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage root %s: %w", cfg.FSRoot, err)
+	}
+	return &Store{root: cfg.FSRoot}, nil
+}
+
+func (s *Store) Get(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	if !fs.ValidPath(objectKey) || objectKey == "." || strings.Contains(objectKey, `\`) {
+		return nil, fmt.Errorf("%w: invalid object key %q", errdefs.ErrInvalidArgument, objectKey)
+	}
+
+	file, err := os.Open(filepath.Join(s.root, filepath.FromSlash(objectKey)))
+	if err != nil {
+		return nil, handleFSError(err)
+	}
+	return file, nil
+}
+
+// List возвращает ключи всех файлов каталога с заданным префиксом
+func (s *Store) List(ctx context.Context, prefix string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(s.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), tempPrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			files = append(files, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, handleFSError(err)
+	}
+	return files, nil
+}
+
+func handleFSError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %v", errdefs.ErrNotFound, err)
+	}
+	return fmt.Errorf("%w: %v", errdefs.ErrUnavailable, err)
+}
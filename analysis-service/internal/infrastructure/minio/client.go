@@ -10,6 +10,7 @@ import (
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
 )
 
 type Client struct {
@@ -29,7 +30,7 @@ func NewClient(ctx context.Context, cfg *config.MinioConfig) (*Client, error) {
 	for i := 0; i < maxRetries; i++ {
 		minioClient, err = minio.New(endpoint, &minio.Options{
 			Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
-			Secure: false,
+			Secure: cfg.UseSSL,
 		})
 		if err == nil {
 			break
@@ -71,25 +72,42 @@ func NewClient(ctx context.Context, cfg *config.MinioConfig) (*Client, error) {
 	return nil, fmt.Errorf("failed to check/create bucket after %d retries: %w", maxRetries, err)
 }
 
-func (c *Client) GetFile(ctx context.Context, objectKey string) ([]byte, error) {
+// Get открывает объект на чтение. Наличие объекта проверяется сразу, а не при первом чтении.
+func (c *Client) Get(ctx context.Context, objectKey string) (io.ReadCloser, error) {
 	obj, err := c.client.GetObject(ctx, c.bucket, objectKey, minio.GetObjectOptions{})
 	if err != nil {
 		return nil, handleMinioError(err)
 	}
-	defer obj.Close()
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		return nil, handleMinioError(err)
+	}
+	return obj, nil
+}
 
-	data, err := io.ReadAll(obj)
+// GetSSEC открывает объект, зашифрованный MinIO ключом key (SSE-C)
+func (c *Client) GetSSEC(ctx context.Context, objectKey string, key []byte) (io.ReadCloser, error) {
+	sse, err := encrypt.NewSSEC(key)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := c.client.GetObject(ctx, c.bucket, objectKey, minio.GetObjectOptions{ServerSideEncryption: sse})
 	if err != nil {
 		return nil, handleMinioError(err)
 	}
-
-	return data, nil
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		return nil, handleMinioError(err)
+	}
+	return obj, nil
 }
 
-func (c *Client) GetAllKeys(ctx context.Context) ([]string, error) {
+// List возвращает ключи всех объектов bucket с заданным префиксом
+func (c *Client) List(ctx context.Context, prefix string) ([]string, error) {
 	var files []string
 
 	objectCh := c.client.ListObjects(ctx, c.bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
 		Recursive: true,
 	})
 
@@ -4,20 +4,133 @@ import (
 	"analysis-service/internal/domain"
 	"analysis-service/internal/infrastructure/dto"
 	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
+	"storing-service/pkg/tenant"
+	"time"
 )
 
+// Все запросы, кроме failInterruptedReanalysisJobsQuery, ограничены тенантом из контекста
 const (
 	createReportQuery = `
-INSERT INTO reports (task_id, is_plagiarism, plagiarism_percentage, created_at)
-VALUES ($1, $2, $3, $4)
-RETURNING task_id`
+INSERT INTO reports (id, task_id, version, object_key, assignment_id, uploaded_by, course_id, algorithm_version, policy, is_plagiarism, plagiarism_percentage, duplicate_of, excluded_task_ids, corpus_snapshot_at, created_at, tenant_id)
+SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+FROM reports
+WHERE task_id = $2 AND tenant_id = $15
+RETURNING version`
+
+	createReportSourceQuery = `
+INSERT INTO report_sources (report_id, position, source_task_id, object_key, similarity, matched_spans)
+VALUES ($1, $2, $3, $4, $5, $6)`
 
 	getReportQuery = `
-SELECT task_id, is_plagiarism, plagiarism_percentage, created_at
+SELECT id, task_id, version, object_key, assignment_id, uploaded_by, course_id, algorithm_version, policy, is_plagiarism, plagiarism_percentage, duplicate_of, excluded_task_ids, COALESCE(corpus_snapshot_at, created_at), created_at
 FROM reports
-WHERE task_id = $1`
+WHERE task_id = $1 AND tenant_id = $3 AND ($2 = 0 OR version = $2)
+ORDER BY version DESC
+LIMIT 1`
+
+	getReportSourcesQuery = `
+SELECT source_task_id, object_key, similarity, matched_spans
+FROM report_sources
+WHERE report_id = $1
+ORDER BY position`
+
+	listReportVersionsQuery = `
+SELECT id, task_id, version, object_key, assignment_id, uploaded_by, course_id, algorithm_version, policy, is_plagiarism, plagiarism_percentage, duplicate_of, excluded_task_ids, COALESCE(corpus_snapshot_at, created_at), created_at
+FROM reports
+WHERE task_id = $1 AND tenant_id = $2
+ORDER BY version DESC`
+
+	// Берется только последняя версия отчета каждой задачи
+	listReportsQuery = `
+SELECT id, task_id, version, object_key, assignment_id, uploaded_by, course_id, algorithm_version, policy, is_plagiarism, plagiarism_percentage, duplicate_of, excluded_task_ids, COALESCE(corpus_snapshot_at, created_at), created_at
+FROM reports r
+WHERE tenant_id = $13
+  AND NOT EXISTS (SELECT 1 FROM reports n WHERE n.tenant_id = r.tenant_id AND n.task_id = r.task_id AND n.version > r.version)
+  AND ($1 = '' OR assignment_id = $1)
+  AND ($2::boolean IS NULL OR is_plagiarism = $2)
+  AND ($3::float IS NULL OR plagiarism_percentage >= $3)
+  AND ($4::float IS NULL OR plagiarism_percentage <= $4)
+  AND ($5::timestamp IS NULL OR created_at >= $5)
+  AND ($6::timestamp IS NULL OR created_at < $6)
+  AND ($7::timestamp IS NULL OR (created_at, id) < ($7, $8))
+  AND (NOT $10 OR uploaded_by = $11 OR course_id = ANY($12))
+ORDER BY created_at DESC, id DESC
+LIMIT $9`
+
+	// report_sources удаляемых отчетов удаляются каскадно
+	deleteTaskReportsQuery = `
+DELETE FROM reports
+WHERE task_id = $1 AND tenant_id = $2`
+
+	deleteSourceReferencesQuery = `
+DELETE FROM report_sources
+WHERE source_task_id = $1 AND report_id IN (SELECT id FROM reports WHERE tenant_id = $2)`
+
+	// Процент схожести сохраняется, но по строке нельзя определить удаленную задачу
+	anonymiseSourceReferencesQuery = `
+UPDATE report_sources
+SET source_task_id = NULL, object_key = ''
+WHERE source_task_id = $1 AND report_id IN (SELECT id FROM reports WHERE tenant_id = $2)`
+
+	listSourceMatchesQuery = `
+SELECT s.source_task_id, s.similarity, r.is_plagiarism, r.created_at
+FROM report_sources s
+JOIN reports r ON r.id = s.report_id
+WHERE s.source_task_id = ANY($1) AND r.tenant_id = $2
+ORDER BY r.created_at, s.report_id, s.position`
+
+	listSourceAssignmentsQuery = `
+SELECT DISTINCT ON (task_id) task_id, assignment_id
+FROM reports
+WHERE task_id = ANY($1) AND tenant_id = $2
+ORDER BY task_id, version DESC`
+
+	clearDuplicateOfQuery = `
+UPDATE reports
+SET duplicate_of = NULL
+WHERE duplicate_of = $1 AND tenant_id = $2`
+
+	createReanalysisJobQuery = `
+INSERT INTO reanalysis_jobs (id, assignment_id, created_from, created_to, rate_per_minute, status, created_at, tenant_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	updateReanalysisJobQuery = `
+UPDATE reanalysis_jobs
+SET status = $2, total = $3, processed = $4, failed = $5, skipped = $6,
+    flipped_to_plagiarism = $7, flipped_to_clean = $8, error = $9, started_at = $10, finished_at = $11
+WHERE id = $1 AND tenant_id = $12`
+
+	getReanalysisJobQuery = `
+SELECT id, assignment_id, created_from, created_to, rate_per_minute, status, total, processed, failed, skipped,
+       flipped_to_plagiarism, flipped_to_clean, error, created_at, started_at, finished_at
+FROM reanalysis_jobs
+WHERE id = $1 AND tenant_id = $2`
+
+	// Выполняется при запуске сервиса для заданий всех тенантов
+	failInterruptedReanalysisJobsQuery = `
+UPDATE reanalysis_jobs
+SET status = 'failed', error = 'interrupted by service restart', finished_at = now()
+WHERE status IN ('pending', 'running')`
+
+	// Дата задачи - время ее первого анализа, вердикт берется из последней версии отчета
+	listReanalysisTargetsQuery = `
+SELECT task_id, object_key, assignment_id, uploaded_by, course_id, is_plagiarism, excluded_task_ids
+FROM (
+    SELECT DISTINCT ON (task_id) task_id, object_key, assignment_id, uploaded_by, course_id, is_plagiarism, excluded_task_ids,
+           MIN(created_at) OVER (PARTITION BY task_id) AS first_analysed_at
+    FROM reports
+    WHERE tenant_id = $4
+    ORDER BY task_id, version DESC
+) latest
+WHERE ($1 = '' OR assignment_id = $1)
+  AND ($2::timestamp IS NULL OR first_analysed_at >= $2)
+  AND ($3::timestamp IS NULL OR first_analysed_at < $3)
+ORDER BY first_analysed_at, task_id`
 )
 
 type AnalysisRepository struct {
@@ -36,13 +149,37 @@ func (r *AnalysisRepository) CreateReport(ctx context.Context, dto *dto.CreateRe
 	r.logger.Debug("executing create report query",
 		zap.String("task_id", dto.TaskId.String()),
 		zap.Bool("is_plagiarism", dto.IsPlagiarism),
-		zap.Float64("plagiarism_percentage", dto.PlagiarismPercentage))
+		zap.Float64("plagiarism_percentage", dto.PlagiarismPercentage),
+		zap.Int("sources", len(dto.Sources)))
 
-	err := r.db.QueryRow(ctx, createReportQuery,
+	policy, err := json.Marshal(dto.Policy)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.logger.Error("failed to begin transaction", zap.Error(err))
+		return handleDBError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, createReportQuery,
+		dto.Id,
 		dto.TaskId,
+		dto.ObjectKey,
+		dto.AssignmentId,
+		nullableUUID(dto.Owner.UploadedBy),
+		dto.Owner.CourseId,
+		dto.AlgorithmVersion,
+		policy,
 		dto.IsPlagiarism,
 		dto.PlagiarismPercentage,
-		dto.CreatedAt).Scan(&dto.TaskId)
+		nullableUUID(dto.DuplicateOf),
+		excludedTaskIds(dto.ExcludedTaskIds),
+		dto.CorpusSnapshotAt,
+		dto.CreatedAt,
+		tenant.FromContext(ctx)).Scan(&dto.Version)
 
 	if err != nil {
 		r.logger.Error("create report query failed",
@@ -51,27 +188,478 @@ func (r *AnalysisRepository) CreateReport(ctx context.Context, dto *dto.CreateRe
 		return handleDBError(err)
 	}
 
-	r.logger.Debug("report created in database", zap.String("task_id", dto.TaskId.String()))
+	batch := &pgx.Batch{}
+	for i, source := range dto.Sources {
+		spans, err := json.Marshal(matchedSpans(source.Spans))
+		if err != nil {
+			return err
+		}
+		batch.Queue(createReportSourceQuery,
+			dto.Id,
+			i,
+			nullableUUID(source.TaskId),
+			source.ObjectKey,
+			source.Percentage,
+			spans)
+	}
+	if batch.Len() > 0 {
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			r.logger.Error("create report sources query failed",
+				zap.String("task_id", dto.TaskId.String()),
+				zap.Error(err))
+			return handleDBError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.logger.Error("failed to commit report",
+			zap.String("task_id", dto.TaskId.String()),
+			zap.Error(err))
+		return handleDBError(err)
+	}
+
+	r.logger.Debug("report created in database",
+		zap.String("task_id", dto.TaskId.String()),
+		zap.Int("version", dto.Version))
 	return nil
 }
 
 func (r *AnalysisRepository) GetReport(ctx context.Context, dto *dto.GetReportsDTO) (*domain.Report, error) {
-	r.logger.Debug("executing get report query", zap.String("task_id", dto.TaskId.String()))
+	r.logger.Debug("executing get report query",
+		zap.String("task_id", dto.TaskId.String()),
+		zap.Int("version", dto.Version))
+
+	report, err := scanReport(r.db.QueryRow(ctx, getReportQuery, dto.TaskId, dto.Version, tenant.FromContext(ctx)))
+	if err != nil {
+		r.logger.Error("get report query failed",
+			zap.String("task_id", dto.TaskId.String()),
+			zap.Error(err))
+		return nil, handleDBError(err)
+	}
+
+	rows, err := r.db.Query(ctx, getReportSourcesQuery, report.Id)
+	if err != nil {
+		r.logger.Error("get report sources query failed",
+			zap.String("report_id", report.Id.String()),
+			zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var source domain.Match
+		var taskId uuid.NullUUID
+		var spans []byte
+		if err := rows.Scan(&taskId, &source.ObjectKey, &source.Percentage, &spans); err != nil {
+			return nil, handleDBError(err)
+		}
+		source.TaskId = taskId.UUID
+		if err := json.Unmarshal(spans, &source.Spans); err != nil {
+			return nil, err
+		}
+		report.Sources = append(report.Sources, source)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	r.logger.Debug("report retrieved from database",
+		zap.String("task_id", dto.TaskId.String()),
+		zap.Int("version", report.Version))
+	return report, nil
+}
+
+func (r *AnalysisRepository) ListReportVersions(ctx context.Context, dto *dto.ListReportVersionsDTO) ([]*domain.Report, error) {
+	r.logger.Debug("executing list report versions query", zap.String("task_id", dto.TaskId.String()))
+
+	rows, err := r.db.Query(ctx, listReportVersionsQuery, dto.TaskId, tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("list report versions query failed",
+			zap.String("task_id", dto.TaskId.String()),
+			zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	var reports []*domain.Report
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, handleDBError(err)
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	r.logger.Debug("report versions retrieved from database",
+		zap.String("task_id", dto.TaskId.String()),
+		zap.Int("count", len(reports)))
+	return reports, nil
+}
+
+func (r *AnalysisRepository) ListReports(ctx context.Context, dto *dto.ListReportsDTO) ([]*domain.Report, error) {
+	r.logger.Debug("executing list reports query",
+		zap.String("assignment_id", dto.Filter.AssignmentId),
+		zap.Int("limit", dto.Limit))
+
+	var afterCreatedAt *time.Time
+	var afterId uuid.UUID
+	if dto.After != nil {
+		afterCreatedAt = &dto.After.CreatedAt
+		afterId = dto.After.Id
+	}
+
+	scope := dto.Filter.Scope
+	if scope == nil {
+		scope = &domain.AccessScope{}
+	}
+
+	rows, err := r.db.Query(ctx, listReportsQuery,
+		dto.Filter.AssignmentId,
+		dto.Filter.IsPlagiarism,
+		dto.Filter.MinPercentage,
+		dto.Filter.MaxPercentage,
+		dto.Filter.CreatedFrom,
+		dto.Filter.CreatedTo,
+		afterCreatedAt,
+		afterId,
+		dto.Limit,
+		dto.Filter.Scope != nil,
+		nullableUUID(scope.UploadedBy),
+		nonNilStrings(scope.CourseIds),
+		tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("list reports query failed", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	var reports []*domain.Report
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, handleDBError(err)
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	r.logger.Debug("reports retrieved from database", zap.Int("count", len(reports)))
+	return reports, nil
+}
+
+// PurgeTask удаляет все версии отчета задачи и ссылки на нее из отчетов других задач
+func (r *AnalysisRepository) PurgeTask(ctx context.Context, dto *dto.PurgeTaskDTO) (*domain.PurgeResult, error) {
+	r.logger.Debug("executing purge task queries", zap.String("task_id", dto.TaskId.String()))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.logger.Error("failed to begin transaction", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	tenantId := tenant.FromContext(ctx)
+	reports, err := tx.Exec(ctx, deleteTaskReportsQuery, dto.TaskId, tenantId)
+	if err != nil {
+		r.logger.Error("delete task reports query failed",
+			zap.String("task_id", dto.TaskId.String()),
+			zap.Error(err))
+		return nil, handleDBError(err)
+	}
+
+	sourcesQuery := deleteSourceReferencesQuery
+	if dto.AnonymiseReferences {
+		sourcesQuery = anonymiseSourceReferencesQuery
+	}
+
+	sources, err := tx.Exec(ctx, sourcesQuery, dto.TaskId, tenantId)
+	if err != nil {
+		r.logger.Error("delete source references query failed",
+			zap.String("task_id", dto.TaskId.String()),
+			zap.Error(err))
+		return nil, handleDBError(err)
+	}
 
+	duplicates, err := tx.Exec(ctx, clearDuplicateOfQuery, dto.TaskId, tenantId)
+	if err != nil {
+		r.logger.Error("clear duplicate_of query failed",
+			zap.String("task_id", dto.TaskId.String()),
+			zap.Error(err))
+		return nil, handleDBError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.logger.Error("failed to commit task purge",
+			zap.String("task_id", dto.TaskId.String()),
+			zap.Error(err))
+		return nil, handleDBError(err)
+	}
+
+	result := &domain.PurgeResult{
+		ReportsDeleted:    int(reports.RowsAffected()),
+		ReferencesRemoved: int(sources.RowsAffected() + duplicates.RowsAffected()),
+	}
+
+	r.logger.Debug("task purged from database",
+		zap.String("task_id", dto.TaskId.String()),
+		zap.Int("reports_deleted", result.ReportsDeleted),
+		zap.Int("references_removed", result.ReferencesRemoved))
+	return result, nil
+}
+
+func (r *AnalysisRepository) ListSourceMatches(ctx context.Context, dto *dto.ListSourceMatchesDTO) ([]domain.SourceMatch, error) {
+	r.logger.Debug("executing list source matches query", zap.Int("task_ids", len(dto.TaskIds)))
+
+	rows, err := r.db.Query(ctx, listSourceMatchesQuery, dto.TaskIds, tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("list source matches query failed", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	var matches []domain.SourceMatch
+	for rows.Next() {
+		var match domain.SourceMatch
+		if err := rows.Scan(&match.SourceTaskId, &match.Percentage, &match.IsPlagiarism, &match.MatchedAt); err != nil {
+			return nil, handleDBError(err)
+		}
+		matches = append(matches, match)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	r.logger.Debug("source matches retrieved from database", zap.Int("count", len(matches)))
+	return matches, nil
+}
+
+// ListSourceAssignments возвращает задания последних версий отчетов задач; задачи без отчетов
+// и без задания в результат не попадают
+func (r *AnalysisRepository) ListSourceAssignments(ctx context.Context, dto *dto.ListSourceAssignmentsDTO) (map[uuid.UUID]string, error) {
+	r.logger.Debug("executing list source assignments query", zap.Int("task_ids", len(dto.TaskIds)))
+
+	rows, err := r.db.Query(ctx, listSourceAssignmentsQuery, dto.TaskIds, tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("list source assignments query failed", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	assignments := make(map[uuid.UUID]string, len(dto.TaskIds))
+	for rows.Next() {
+		var taskId uuid.UUID
+		var assignmentId string
+		if err := rows.Scan(&taskId, &assignmentId); err != nil {
+			return nil, handleDBError(err)
+		}
+		if assignmentId != "" {
+			assignments[taskId] = assignmentId
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	return assignments, nil
+}
+
+func (r *AnalysisRepository) CreateReanalysisJob(ctx context.Context, dto *dto.CreateReanalysisJobDTO) error {
+	r.logger.Debug("executing create reanalysis job query",
+		zap.String("job_id", dto.Id.String()),
+		zap.String("assignment_id", dto.Scope.AssignmentId))
+
+	_, err := r.db.Exec(ctx, createReanalysisJobQuery,
+		dto.Id,
+		dto.Scope.AssignmentId,
+		dto.Scope.CreatedFrom,
+		dto.Scope.CreatedTo,
+		dto.RatePerMinute,
+		dto.Status,
+		dto.CreatedAt,
+		tenant.FromContext(ctx))
+
+	if err != nil {
+		r.logger.Error("create reanalysis job query failed",
+			zap.String("job_id", dto.Id.String()),
+			zap.Error(err))
+		return handleDBError(err)
+	}
+
+	r.logger.Debug("reanalysis job created in database", zap.String("job_id", dto.Id.String()))
+	return nil
+}
+
+func (r *AnalysisRepository) UpdateReanalysisJob(ctx context.Context, job *domain.ReanalysisJob) error {
+	r.logger.Debug("executing update reanalysis job query",
+		zap.String("job_id", job.Id.String()),
+		zap.String("status", string(job.Status)))
+
+	tag, err := r.db.Exec(ctx, updateReanalysisJobQuery,
+		job.Id,
+		job.Status,
+		job.Total,
+		job.Processed,
+		job.Failed,
+		job.Skipped,
+		job.FlippedToPlagiarism,
+		job.FlippedToClean,
+		job.Error,
+		job.StartedAt,
+		job.FinishedAt,
+		tenant.FromContext(ctx))
+
+	if err != nil {
+		r.logger.Error("update reanalysis job query failed",
+			zap.String("job_id", job.Id.String()),
+			zap.Error(err))
+		return handleDBError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return handleDBError(pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r *AnalysisRepository) GetReanalysisJob(ctx context.Context, dto *dto.GetReanalysisJobDTO) (*domain.ReanalysisJob, error) {
+	r.logger.Debug("executing get reanalysis job query", zap.String("job_id", dto.Id.String()))
+
+	job := &domain.ReanalysisJob{}
+	err := r.db.QueryRow(ctx, getReanalysisJobQuery, dto.Id, tenant.FromContext(ctx)).Scan(
+		&job.Id,
+		&job.Scope.AssignmentId,
+		&job.Scope.CreatedFrom,
+		&job.Scope.CreatedTo,
+		&job.RatePerMinute,
+		&job.Status,
+		&job.Total,
+		&job.Processed,
+		&job.Failed,
+		&job.Skipped,
+		&job.FlippedToPlagiarism,
+		&job.FlippedToClean,
+		&job.Error,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.FinishedAt)
+
+	if err != nil {
+		r.logger.Error("get reanalysis job query failed",
+			zap.String("job_id", dto.Id.String()),
+			zap.Error(err))
+		return nil, handleDBError(err)
+	}
+
+	return job, nil
+}
+
+func (r *AnalysisRepository) FailInterruptedReanalysisJobs(ctx context.Context) (int64, error) {
+	r.logger.Debug("executing fail interrupted reanalysis jobs query")
+
+	tag, err := r.db.Exec(ctx, failInterruptedReanalysisJobsQuery)
+	if err != nil {
+		r.logger.Error("fail interrupted reanalysis jobs query failed", zap.Error(err))
+		return 0, handleDBError(err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *AnalysisRepository) ListReanalysisTargets(ctx context.Context, dto *dto.ListReanalysisTargetsDTO) ([]domain.ReanalysisTarget, error) {
+	r.logger.Debug("executing list reanalysis targets query",
+		zap.String("assignment_id", dto.Scope.AssignmentId))
+
+	rows, err := r.db.Query(ctx, listReanalysisTargetsQuery,
+		dto.Scope.AssignmentId,
+		dto.Scope.CreatedFrom,
+		dto.Scope.CreatedTo,
+		tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("list reanalysis targets query failed", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	var targets []domain.ReanalysisTarget
+	for rows.Next() {
+		var target domain.ReanalysisTarget
+		var uploadedBy uuid.NullUUID
+		if err := rows.Scan(&target.TaskId, &target.ObjectKey, &target.AssignmentId, &uploadedBy, &target.Owner.CourseId, &target.IsPlagiarism, &target.ExcludedTaskIds); err != nil {
+			return nil, handleDBError(err)
+		}
+		target.Owner.UploadedBy = uploadedBy.UUID
+		targets = append(targets, target)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	r.logger.Debug("reanalysis targets retrieved from database", zap.Int("count", len(targets)))
+	return targets, nil
+}
+
+func scanReport(row pgx.Row) (*domain.Report, error) {
 	report := &domain.Report{}
-	err := r.db.QueryRow(ctx, getReportQuery, dto.TaskId).Scan(
+	var policy []byte
+	var duplicateOf, uploadedBy uuid.NullUUID
+	err := row.Scan(
+		&report.Id,
 		&report.TaskId,
+		&report.Version,
+		&report.ObjectKey,
+		&report.AssignmentId,
+		&uploadedBy,
+		&report.Owner.CourseId,
+		&report.AlgorithmVersion,
+		&policy,
 		&report.IsPlagiarism,
 		&report.PlagiarismPercentage,
+		&duplicateOf,
+		&report.ExcludedTaskIds,
+		&report.CorpusSnapshotAt,
 		&report.CreatedAt)
-
 	if err != nil {
-		r.logger.Error("get report query failed",
-			zap.String("task_id", dto.TaskId.String()),
-			zap.Error(err))
-		return nil, handleDBError(err)
+		return nil, err
 	}
 
-	r.logger.Debug("report retrieved from database", zap.String("task_id", dto.TaskId.String()))
+	if err := json.Unmarshal(policy, &report.Policy); err != nil {
+		return nil, err
+	}
+	report.DuplicateOf = duplicateOf.UUID
+	report.Owner.UploadedBy = uploadedBy.UUID
+
 	return report, nil
 }
+
+// excludedTaskIds заменяет nil пустым массивом: колонка excluded_task_ids не допускает NULL
+func excludedTaskIds(ids []uuid.UUID) []uuid.UUID {
+	if ids == nil {
+		return []uuid.UUID{}
+	}
+	return ids
+}
+
+// matchedSpans заменяет nil пустым массивом, чтобы в jsonb сохранялся [], а не null
+func matchedSpans(spans []domain.MatchedSpan) []domain.MatchedSpan {
+	if spans == nil {
+		return []domain.MatchedSpan{}
+	}
+	return spans
+}
+
+func nullableUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
+
+// nonNilStrings заменяет nil пустым массивом, чтобы параметр text[] не передавался как NULL
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,61 @@
+package storing
+
+import (
+	"analysis-service/internal/domain"
+	"analysis-service/internal/errdefs"
+	"context"
+	"fmt"
+	"storing-service/pkg/identity"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	storingpb "storing-service/pkg/api"
+)
+
+type Client struct {
+	conn   *grpc.ClientConn
+	client storingpb.StoringServiceClient
+}
+
+func NewClient(ctx context.Context, endpoint string, identitySecret []byte) (*Client, error) {
+	// Вызовы подписываются внутренним клиентом в тенанте контекста: задача другого тенанта
+	// в storing-service не находится
+	conn, err := grpc.NewClient(endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(identity.SystemClientInterceptor(identitySecret, "analysis-service")))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		conn:   conn,
+		client: storingpb.NewStoringServiceClient(conn),
+	}, nil
+}
+
+// GetTaskOwner возвращает автора и курс задачи тенанта контекста
+func (c *Client) GetTaskOwner(ctx context.Context, taskId uuid.UUID) (domain.ReportOwner, error) {
+	resp, err := c.client.GetTask(ctx, &storingpb.GetTaskRequest{FileId: taskId.String()})
+	if status.Code(err) == codes.NotFound {
+		return domain.ReportOwner{}, fmt.Errorf("task %s: %w", taskId, errdefs.ErrNotFound)
+	}
+	if err != nil {
+		return domain.ReportOwner{}, fmt.Errorf("failed to get task %s from storing-service: %v: %w", taskId, err, errdefs.ErrUnavailable)
+	}
+
+	uploadedBy, err := uuid.Parse(resp.UploadedBy)
+	if err != nil {
+		return domain.ReportOwner{}, fmt.Errorf("task %s has invalid uploaded_by %q", taskId, resp.UploadedBy)
+	}
+	return domain.ReportOwner{UploadedBy: uploadedBy, CourseId: resp.CourseId}, nil
+}
+
+func (c *Client) Close() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
@@ -37,16 +37,16 @@ func (c *Client) GenerateWordCloud(ctx context.Context, fileContent []byte) (str
 		zap.Int("total_words", len(words)))
 
 	text := strings.Join(words, " ")
-	
+
 	config := map[string]interface{}{
-		"type":    "wordCloud",
-		"data":    wordFreq,
+		"type": "wordCloud",
+		"data": wordFreq,
 		"options": map[string]interface{}{
-			"width":   800,
-			"height":  600,
-			"colors":  []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b"},
+			"width":      800,
+			"height":     600,
+			"colors":     []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b"},
 			"fontFamily": "Arial",
-			"scale":   "sqrt",
+			"scale":      "sqrt",
 		},
 	}
 
@@ -150,4 +150,3 @@ func (c *Client) GenerateWordCloudImage(ctx context.Context, fileContent []byte)
 
 	return imageData, nil
 }
-
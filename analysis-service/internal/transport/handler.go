@@ -7,26 +7,44 @@ import (
 	"context"
 	"errors"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"go.uber.org/zap"
+	"time"
 )
 
 type AnalysisService interface {
-	AnalyseTask(ctx context.Context, taskId uuid.UUID, objectKey string) (bool, error)
-	GetReport(ctx context.Context, taskId uuid.UUID) (*domain.Report, error)
+	AnalyseTask(ctx context.Context, taskId uuid.UUID, objectKey, assignmentId string, owner domain.ReportOwner, duplicateOf *domain.Match, excluded []uuid.UUID) (bool, error)
+	GetReport(ctx context.Context, taskId uuid.UUID, version int) (*domain.Report, error)
+	ListReportVersions(ctx context.Context, taskId uuid.UUID) ([]*domain.Report, error)
+	ListReports(ctx context.Context, filter domain.ReportFilter, cursor string, limit int) ([]*domain.Report, string, error)
 	GenerateWordCloud(ctx context.Context, fileContent []byte) (string, error)
+	WatchAnalysis(ctx context.Context, taskId uuid.UUID) (<-chan domain.AnalysisEvent, error)
+	CancelAnalysis(ctx context.Context, taskId uuid.UUID) error
+	PurgeTask(ctx context.Context, taskId uuid.UUID, anonymiseReferences bool) (*domain.PurgeResult, error)
+	ListSourceMatches(ctx context.Context, taskIds []uuid.UUID) ([]domain.SourceMatch, error)
+	ReanalyseTask(ctx context.Context, taskId uuid.UUID, objectKey string, owner domain.ReportOwner, excluded []uuid.UUID) error
+	StartBulkReanalysis(ctx context.Context, scope domain.ReanalysisScope, ratePerMinute int) (*domain.ReanalysisJob, error)
+	GetBulkReanalysis(ctx context.Context, jobId uuid.UUID) (*domain.ReanalysisJob, error)
+	CancelBulkReanalysis(ctx context.Context, jobId uuid.UUID) error
+}
+
+// TaskOwners возвращает автора и курс задачи тенанта контекста по данным storing-service
+type TaskOwners interface {
+	GetTaskOwner(ctx context.Context, taskId uuid.UUID) (domain.ReportOwner, error)
 }
 
 type AnalysisHandler struct {
 	pb.UnimplementedAnalysisServiceServer
 	svc    AnalysisService
+	tasks  TaskOwners
 	logger *zap.Logger
 }
 
-func NewAnalysisHandler(svc AnalysisService, logger *zap.Logger) *AnalysisHandler {
+func NewAnalysisHandler(svc AnalysisService, tasks TaskOwners, logger *zap.Logger) *AnalysisHandler {
 	return &AnalysisHandler{
 		svc:    svc,
+		tasks:  tasks,
 		logger: logger,
 	}
 }
@@ -34,7 +52,9 @@ func NewAnalysisHandler(svc AnalysisService, logger *zap.Logger) *AnalysisHandle
 func (h *AnalysisHandler) AnalyseTask(ctx context.Context, request *pb.AnalyzeTaskRequest) (*pb.AnalyseTaskResponse, error) {
 	h.logger.Info("analyse task gRPC request",
 		zap.String("task_id", request.TaskId),
-		zap.String("object_key", request.ObjectKey))
+		zap.String("object_key", request.ObjectKey),
+		zap.String("assignment_id", request.AssignmentId),
+		zap.String("duplicate_of", request.DuplicateOf))
 
 	taskId, err := uuid.Parse(request.TaskId)
 	if err != nil {
@@ -44,7 +64,49 @@ func (h *AnalysisHandler) AnalyseTask(ctx context.Context, request *pb.AnalyzeTa
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	status, err := h.svc.AnalyseTask(ctx, taskId, request.ObjectKey)
+	var duplicateOf *domain.Match
+	if request.DuplicateOf != "" {
+		originalId, err := uuid.Parse(request.DuplicateOf)
+		if err != nil {
+			h.logger.Warn("invalid duplicate_of UUID",
+				zap.String("duplicate_of", request.DuplicateOf),
+				zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		duplicateOf = &domain.Match{
+			TaskId:    originalId,
+			ObjectKey: request.DuplicateOfObjectKey,
+		}
+	}
+
+	excluded := make([]uuid.UUID, 0, len(request.ExcludeTaskIds))
+	for _, id := range request.ExcludeTaskIds {
+		excludedId, err := uuid.Parse(id)
+		if err != nil {
+			h.logger.Warn("invalid exclude_task_ids UUID",
+				zap.String("task_id", id),
+				zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		excluded = append(excluded, excludedId)
+	}
+
+	owner, err := parseOwner(request.UploadedBy, request.CourseId)
+	if err != nil {
+		h.logger.Warn("invalid uploaded_by UUID",
+			zap.String("uploaded_by", request.UploadedBy),
+			zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.authorizeTeacherTask(ctx, taskId, request.CourseId); err != nil {
+		h.logger.Warn("analyse task denied",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	status, err := h.svc.AnalyseTask(ctx, taskId, request.ObjectKey, request.AssignmentId, owner, duplicateOf, excluded)
 	if err != nil {
 		h.logger.Error("analyse task failed",
 			zap.String("task_id", request.TaskId),
@@ -62,7 +124,9 @@ func (h *AnalysisHandler) AnalyseTask(ctx context.Context, request *pb.AnalyzeTa
 }
 
 func (h *AnalysisHandler) GetReport(ctx context.Context, request *pb.GetReportRequest) (*pb.GetReportResponse, error) {
-	h.logger.Info("get report gRPC request", zap.String("task_id", request.TaskId))
+	h.logger.Info("get report gRPC request",
+		zap.String("task_id", request.TaskId),
+		zap.Int32("version", request.Version))
 
 	taskId, err := uuid.Parse(request.TaskId)
 	if err != nil {
@@ -72,30 +136,222 @@ func (h *AnalysisHandler) GetReport(ctx context.Context, request *pb.GetReportRe
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	report, err := h.svc.GetReport(ctx, taskId)
+	report, err := h.svc.GetReport(ctx, taskId, int(request.Version))
 	if err != nil {
 		h.logger.Error("get report failed",
 			zap.String("task_id", request.TaskId),
 			zap.Error(err))
 		return nil, mapError(err)
 	}
+	if err := checkReportAccess(ctx, report.Owner); err != nil {
+		h.logger.Warn("get report denied",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
 
 	h.logger.Info("get report success",
 		zap.String("task_id", request.TaskId),
 		zap.Bool("is_plagiarism", report.IsPlagiarism),
-		zap.Float64("plagiarism_percentage", report.PlagiarismPercentage))
+		zap.Float64("plagiarism_percentage", report.PlagiarismPercentage),
+		zap.Int("version", report.Version))
+
+	showSources := canSeeSources(ctx)
+	sources := make([]*pb.ReportSource, 0, len(report.Sources))
+	for _, source := range report.Sources {
+		sources = append(sources, toReportSource(source, showSources))
+	}
 
 	return &pb.GetReportResponse{
 		TaskId:               report.TaskId.String(),
 		IsPlagiarism:         report.IsPlagiarism,
 		PlagiarismPercentage: float32(report.PlagiarismPercentage),
+		ReportId:             report.Id.String(),
+		Version:              int32(report.Version),
+		AlgorithmVersion:     report.AlgorithmVersion,
+		Policy:               toReportPolicy(report.Policy),
+		CorpusSnapshotAt:     report.CorpusSnapshotAt.Format(time.RFC3339),
+		CreatedAt:            report.CreatedAt.Format(time.RFC3339),
+		Sources:              sources,
+		AssignmentId:         report.AssignmentId,
+		DuplicateOf:          visibleDuplicateOf(report.DuplicateOf, showSources),
+		ExactDuplicate:       report.DuplicateOf != uuid.Nil,
+	}, nil
+}
+
+func (h *AnalysisHandler) ListReportVersions(ctx context.Context, request *pb.ListReportVersionsRequest) (*pb.ListReportVersionsResponse, error) {
+	h.logger.Info("list report versions gRPC request", zap.String("task_id", request.TaskId))
+
+	taskId, err := uuid.Parse(request.TaskId)
+	if err != nil {
+		h.logger.Warn("invalid task_id UUID",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	reports, err := h.svc.ListReportVersions(ctx, taskId)
+	if err != nil {
+		h.logger.Error("list report versions failed",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+	// Версии упорядочены по убыванию, доступ проверяется по владельцу последней
+	if len(reports) > 0 {
+		if err := checkReportAccess(ctx, reports[0].Owner); err != nil {
+			h.logger.Warn("list report versions denied",
+				zap.String("task_id", request.TaskId),
+				zap.Error(err))
+			return nil, mapError(err)
+		}
+	}
+
+	showSources := canSeeSources(ctx)
+	versions := make([]*pb.ReportVersion, 0, len(reports))
+	for _, report := range reports {
+		versions = append(versions, &pb.ReportVersion{
+			ReportId:             report.Id.String(),
+			Version:              int32(report.Version),
+			AlgorithmVersion:     report.AlgorithmVersion,
+			Policy:               toReportPolicy(report.Policy),
+			IsPlagiarism:         report.IsPlagiarism,
+			PlagiarismPercentage: float32(report.PlagiarismPercentage),
+			CorpusSnapshotAt:     report.CorpusSnapshotAt.Format(time.RFC3339),
+			CreatedAt:            report.CreatedAt.Format(time.RFC3339),
+			DuplicateOf:          visibleDuplicateOf(report.DuplicateOf, showSources),
+		})
+	}
+
+	h.logger.Info("list report versions success",
+		zap.String("task_id", request.TaskId),
+		zap.Int("count", len(versions)))
+
+	return &pb.ListReportVersionsResponse{
+		TaskId:   request.TaskId,
+		Versions: versions,
+	}, nil
+}
+
+func (h *AnalysisHandler) ListReports(ctx context.Context, request *pb.ListReportsRequest) (*pb.ListReportsResponse, error) {
+	h.logger.Info("list reports gRPC request",
+		zap.String("assignment_id", request.AssignmentId),
+		zap.Int32("limit", request.Limit))
+
+	createdFrom, err := parseOptionalTime(request.CreatedFrom)
+	if err != nil {
+		h.logger.Warn("invalid created_from", zap.String("created_from", request.CreatedFrom), zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	createdTo, err := parseOptionalTime(request.CreatedTo)
+	if err != nil {
+		h.logger.Warn("invalid created_to", zap.String("created_to", request.CreatedTo), zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	scope, err := accessScope(ctx)
+	if err != nil {
+		h.logger.Warn("list reports denied", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	filter := domain.ReportFilter{
+		AssignmentId:  request.AssignmentId,
+		IsPlagiarism:  request.IsPlagiarism,
+		MinPercentage: optionalFloat64(request.MinPercentage),
+		MaxPercentage: optionalFloat64(request.MaxPercentage),
+		CreatedFrom:   createdFrom,
+		CreatedTo:     createdTo,
+		Scope:         scope,
+	}
+
+	reports, nextCursor, err := h.svc.ListReports(ctx, filter, request.Cursor, int(request.Limit))
+	if err != nil {
+		h.logger.Error("list reports failed", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	showSources := canSeeSources(ctx)
+	summaries := make([]*pb.ReportSummary, 0, len(reports))
+	for _, report := range reports {
+		summaries = append(summaries, &pb.ReportSummary{
+			TaskId:               report.TaskId.String(),
+			ReportId:             report.Id.String(),
+			Version:              int32(report.Version),
+			AssignmentId:         report.AssignmentId,
+			IsPlagiarism:         report.IsPlagiarism,
+			PlagiarismPercentage: float32(report.PlagiarismPercentage),
+			DuplicateOf:          visibleDuplicateOf(report.DuplicateOf, showSources),
+			CreatedAt:            report.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	h.logger.Info("list reports success", zap.Int("count", len(summaries)))
+
+	return &pb.ListReportsResponse{
+		Reports:    summaries,
+		NextCursor: nextCursor,
 	}, nil
 }
 
+func toReportPolicy(policy domain.ReportPolicy) *pb.ReportPolicy {
+	return &pb.ReportPolicy{
+		Threshold: float32(policy.Threshold),
+	}
+}
+
+func formatOptionalUUID(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
+
+// toReportSource переводит источник отчета; без showSources источник обезличивается
+func toReportSource(source domain.Match, showSources bool) *pb.ReportSource {
+	if !showSources {
+		return &pb.ReportSource{
+			Similarity:   float32(source.Percentage),
+			AssignmentId: source.AssignmentId,
+			MatchedSpans: toMatchedSpans(source.Spans),
+		}
+	}
+	return &pb.ReportSource{
+		TaskId:       formatOptionalUUID(source.TaskId),
+		ObjectKey:    source.ObjectKey,
+		Similarity:   float32(source.Percentage),
+		AssignmentId: source.AssignmentId,
+		MatchedSpans: toMatchedSpans(source.Spans),
+	}
+}
+
+// toMatchedSpans переводит фрагменты проверяемого файла; они ссылаются только на его собственный текст
+func toMatchedSpans(spans []domain.MatchedSpan) []*pb.MatchedSpan {
+	result := make([]*pb.MatchedSpan, 0, len(spans))
+	for _, span := range spans {
+		result = append(result, &pb.MatchedSpan{Start: int64(span.Start), End: int64(span.End)})
+	}
+	return result
+}
+
+// visibleDuplicateOf скрывает задачу-оригинал дубликата от клиентов, которым источники не видны
+func visibleDuplicateOf(duplicateOf uuid.UUID, showSources bool) string {
+	if !showSources {
+		return ""
+	}
+	return formatOptionalUUID(duplicateOf)
+}
+
 func (h *AnalysisHandler) GenerateWordCloud(ctx context.Context, request *pb.GenerateWordCloudRequest) (*pb.GenerateWordCloudResponse, error) {
 	h.logger.Info("generate word cloud gRPC request",
 		zap.Int("content_size", len(request.FileContent)))
 
+	if _, err := principalFromContext(ctx); err != nil {
+		h.logger.Warn("generate word cloud denied", zap.Error(err))
+		return nil, mapError(err)
+	}
+
 	imageURL, err := h.svc.GenerateWordCloud(ctx, request.FileContent)
 	if err != nil {
 		h.logger.Error("generate word cloud failed", zap.Error(err))
@@ -110,6 +366,411 @@ func (h *AnalysisHandler) GenerateWordCloud(ctx context.Context, request *pb.Gen
 	}, nil
 }
 
+func (h *AnalysisHandler) WatchAnalysis(request *pb.WatchAnalysisRequest, stream pb.AnalysisService_WatchAnalysisServer) error {
+	h.logger.Info("watch analysis gRPC request", zap.String("task_id", request.TaskId))
+
+	taskId, err := uuid.Parse(request.TaskId)
+	if err != nil {
+		h.logger.Warn("invalid task_id UUID",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.authorizeWatch(stream.Context(), taskId); err != nil {
+		h.logger.Warn("watch analysis denied",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return mapError(err)
+	}
+
+	events, err := h.svc.WatchAnalysis(stream.Context(), taskId)
+	if err != nil {
+		h.logger.Error("watch analysis failed",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return mapError(err)
+	}
+
+	for event := range events {
+		if err := stream.Send(toAnalysisEvent(event)); err != nil {
+			h.logger.Warn("failed to send analysis event",
+				zap.String("task_id", request.TaskId),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	h.logger.Info("watch analysis finished", zap.String("task_id", request.TaskId))
+	return nil
+}
+
+func (h *AnalysisHandler) CancelAnalysis(ctx context.Context, request *pb.CancelAnalysisRequest) (*pb.CancelAnalysisResponse, error) {
+	h.logger.Info("cancel analysis gRPC request", zap.String("task_id", request.TaskId))
+
+	taskId, err := uuid.Parse(request.TaskId)
+	if err != nil {
+		h.logger.Warn("invalid task_id UUID",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.authorizeTeacherTask(ctx, taskId, ""); err != nil {
+		h.logger.Warn("cancel analysis denied",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	if err := h.svc.CancelAnalysis(ctx, taskId); err != nil {
+		h.logger.Error("cancel analysis failed",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	h.logger.Info("cancel analysis success", zap.String("task_id", request.TaskId))
+
+	return &pb.CancelAnalysisResponse{
+		Status: true,
+	}, nil
+}
+
+func (h *AnalysisHandler) PurgeTask(ctx context.Context, request *pb.PurgeTaskRequest) (*pb.PurgeTaskResponse, error) {
+	h.logger.Info("purge task gRPC request",
+		zap.String("task_id", request.TaskId),
+		zap.Bool("anonymise_references", request.AnonymiseReferences))
+
+	taskId, err := uuid.Parse(request.TaskId)
+	if err != nil {
+		h.logger.Warn("invalid task_id UUID",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := checkAdmin(ctx); err != nil {
+		h.logger.Warn("purge task denied", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	result, err := h.svc.PurgeTask(ctx, taskId, request.AnonymiseReferences)
+	if err != nil {
+		h.logger.Error("purge task failed",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	h.logger.Info("purge task success",
+		zap.String("task_id", request.TaskId),
+		zap.Int("reports_deleted", result.ReportsDeleted))
+
+	return &pb.PurgeTaskResponse{
+		ReportsDeleted:    int32(result.ReportsDeleted),
+		ReferencesRemoved: int32(result.ReferencesRemoved),
+	}, nil
+}
+
+func (h *AnalysisHandler) ListSourceMatches(ctx context.Context, request *pb.ListSourceMatchesRequest) (*pb.ListSourceMatchesResponse, error) {
+	h.logger.Info("list source matches gRPC request", zap.Int("task_ids", len(request.TaskIds)))
+
+	taskIds := make([]uuid.UUID, 0, len(request.TaskIds))
+	for _, value := range request.TaskIds {
+		taskId, err := uuid.Parse(value)
+		if err != nil {
+			h.logger.Warn("invalid task_id UUID",
+				zap.String("task_id", value),
+				zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		taskIds = append(taskIds, taskId)
+	}
+
+	taskIds, err := h.accessibleTaskIds(ctx, taskIds)
+	if err != nil {
+		h.logger.Error("failed to check access to tasks", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	matches, err := h.svc.ListSourceMatches(ctx, taskIds)
+	if err != nil {
+		h.logger.Error("list source matches failed", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	resp := &pb.ListSourceMatchesResponse{
+		Matches: make([]*pb.SourceMatch, 0, len(matches)),
+	}
+	for _, match := range matches {
+		resp.Matches = append(resp.Matches, &pb.SourceMatch{
+			SourceTaskId: match.SourceTaskId.String(),
+			Similarity:   float32(match.Percentage),
+			IsPlagiarism: match.IsPlagiarism,
+			MatchedAt:    match.MatchedAt.Format(time.RFC3339),
+		})
+	}
+
+	h.logger.Info("list source matches success", zap.Int("count", len(matches)))
+	return resp, nil
+}
+
+func (h *AnalysisHandler) ReanalyseTask(ctx context.Context, request *pb.ReanalyseTaskRequest) (*pb.ReanalyseTaskResponse, error) {
+	h.logger.Info("reanalyse task gRPC request",
+		zap.String("task_id", request.TaskId),
+		zap.String("object_key", request.ObjectKey))
+
+	taskId, err := uuid.Parse(request.TaskId)
+	if err != nil {
+		h.logger.Warn("invalid task_id UUID",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var excluded []uuid.UUID
+	for _, id := range request.ExcludeTaskIds {
+		excludedId, err := uuid.Parse(id)
+		if err != nil {
+			h.logger.Warn("invalid exclude_task_ids UUID",
+				zap.String("task_id", id),
+				zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		excluded = append(excluded, excludedId)
+	}
+
+	owner, err := parseOwner(request.UploadedBy, request.CourseId)
+	if err != nil {
+		h.logger.Warn("invalid uploaded_by UUID",
+			zap.String("uploaded_by", request.UploadedBy),
+			zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.authorizeTeacherTask(ctx, taskId, request.CourseId); err != nil {
+		h.logger.Warn("reanalyse task denied",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	if err := h.svc.ReanalyseTask(ctx, taskId, request.ObjectKey, owner, excluded); err != nil {
+		h.logger.Error("reanalyse task failed",
+			zap.String("task_id", request.TaskId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	h.logger.Info("reanalyse task started", zap.String("task_id", request.TaskId))
+
+	return &pb.ReanalyseTaskResponse{
+		Status: true,
+	}, nil
+}
+
+func (h *AnalysisHandler) StartBulkReanalysis(ctx context.Context, request *pb.StartBulkReanalysisRequest) (*pb.StartBulkReanalysisResponse, error) {
+	h.logger.Info("start bulk reanalysis gRPC request",
+		zap.String("assignment_id", request.AssignmentId),
+		zap.String("created_from", request.CreatedFrom),
+		zap.String("created_to", request.CreatedTo),
+		zap.Int32("rate_per_minute", request.RatePerMinute))
+
+	if err := checkAdmin(ctx); err != nil {
+		h.logger.Warn("start bulk reanalysis denied", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	createdFrom, err := parseOptionalTime(request.CreatedFrom)
+	if err != nil {
+		h.logger.Warn("invalid created_from", zap.String("created_from", request.CreatedFrom), zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	createdTo, err := parseOptionalTime(request.CreatedTo)
+	if err != nil {
+		h.logger.Warn("invalid created_to", zap.String("created_to", request.CreatedTo), zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	scope := domain.ReanalysisScope{
+		AssignmentId: request.AssignmentId,
+		CreatedFrom:  createdFrom,
+		CreatedTo:    createdTo,
+	}
+
+	job, err := h.svc.StartBulkReanalysis(ctx, scope, int(request.RatePerMinute))
+	if err != nil {
+		h.logger.Error("start bulk reanalysis failed", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	h.logger.Info("start bulk reanalysis success", zap.String("job_id", job.Id.String()))
+
+	return &pb.StartBulkReanalysisResponse{
+		Job: toBulkReanalysisJob(job),
+	}, nil
+}
+
+func (h *AnalysisHandler) GetBulkReanalysis(ctx context.Context, request *pb.GetBulkReanalysisRequest) (*pb.GetBulkReanalysisResponse, error) {
+	h.logger.Info("get bulk reanalysis gRPC request", zap.String("job_id", request.JobId))
+
+	jobId, err := uuid.Parse(request.JobId)
+	if err != nil {
+		h.logger.Warn("invalid job_id UUID",
+			zap.String("job_id", request.JobId),
+			zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := checkAdmin(ctx); err != nil {
+		h.logger.Warn("get bulk reanalysis denied", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	job, err := h.svc.GetBulkReanalysis(ctx, jobId)
+	if err != nil {
+		h.logger.Error("get bulk reanalysis failed",
+			zap.String("job_id", request.JobId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	h.logger.Info("get bulk reanalysis success",
+		zap.String("job_id", request.JobId),
+		zap.String("status", string(job.Status)))
+
+	return &pb.GetBulkReanalysisResponse{
+		Job: toBulkReanalysisJob(job),
+	}, nil
+}
+
+func (h *AnalysisHandler) CancelBulkReanalysis(ctx context.Context, request *pb.CancelBulkReanalysisRequest) (*pb.CancelBulkReanalysisResponse, error) {
+	h.logger.Info("cancel bulk reanalysis gRPC request", zap.String("job_id", request.JobId))
+
+	jobId, err := uuid.Parse(request.JobId)
+	if err != nil {
+		h.logger.Warn("invalid job_id UUID",
+			zap.String("job_id", request.JobId),
+			zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := checkAdmin(ctx); err != nil {
+		h.logger.Warn("cancel bulk reanalysis denied", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	if err := h.svc.CancelBulkReanalysis(ctx, jobId); err != nil {
+		h.logger.Error("cancel bulk reanalysis failed",
+			zap.String("job_id", request.JobId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	h.logger.Info("cancel bulk reanalysis success", zap.String("job_id", request.JobId))
+
+	return &pb.CancelBulkReanalysisResponse{
+		Status: true,
+	}, nil
+}
+
+func toBulkReanalysisJob(job *domain.ReanalysisJob) *pb.BulkReanalysisJob {
+	return &pb.BulkReanalysisJob{
+		JobId:               job.Id.String(),
+		AssignmentId:        job.Scope.AssignmentId,
+		CreatedFrom:         formatOptionalTime(job.Scope.CreatedFrom),
+		CreatedTo:           formatOptionalTime(job.Scope.CreatedTo),
+		RatePerMinute:       int32(job.RatePerMinute),
+		Status:              string(job.Status),
+		Total:               int32(job.Total),
+		Processed:           int32(job.Processed),
+		Failed:              int32(job.Failed),
+		Skipped:             int32(job.Skipped),
+		FlippedToPlagiarism: int32(job.FlippedToPlagiarism),
+		FlippedToClean:      int32(job.FlippedToClean),
+		Error:               job.Error,
+		CreatedAt:           job.CreatedAt.Format(time.RFC3339),
+		StartedAt:           formatOptionalTime(job.StartedAt),
+		FinishedAt:          formatOptionalTime(job.FinishedAt),
+	}
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	// Время в БД хранится без часового пояса в локальном времени сервиса
+	t = t.Local()
+	return &t, nil
+}
+
+// parseOwner разбирает автора и курс работы; пустой uploaded_by - автор неизвестен
+func parseOwner(uploadedBy, courseId string) (domain.ReportOwner, error) {
+	owner := domain.ReportOwner{CourseId: courseId}
+	if uploadedBy == "" {
+		return owner, nil
+	}
+	id, err := uuid.Parse(uploadedBy)
+	if err != nil {
+		return domain.ReportOwner{}, err
+	}
+	owner.UploadedBy = id
+	return owner, nil
+}
+
+func optionalFloat64(v *float32) *float64 {
+	if v == nil {
+		return nil
+	}
+	f := float64(*v)
+	return &f
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func toAnalysisEvent(event domain.AnalysisEvent) *pb.AnalysisEvent {
+	return &pb.AnalysisEvent{
+		TaskId:               event.TaskId.String(),
+		Stage:                toAnalysisStage(event.Stage),
+		Compared:             int32(event.Compared),
+		Total:                int32(event.Total),
+		IsPlagiarism:         event.IsPlagiarism,
+		PlagiarismPercentage: float32(event.PlagiarismPercentage),
+		Message:              event.Message,
+		Timestamp:            event.Timestamp.Format(time.RFC3339),
+	}
+}
+
+func toAnalysisStage(stage domain.AnalysisStage) pb.AnalysisStage {
+	switch stage {
+	case domain.StageExtracting:
+		return pb.AnalysisStage_ANALYSIS_STAGE_EXTRACTING
+	case domain.StageIndexing:
+		return pb.AnalysisStage_ANALYSIS_STAGE_INDEXING
+	case domain.StageComparing:
+		return pb.AnalysisStage_ANALYSIS_STAGE_COMPARING
+	case domain.StageDone:
+		return pb.AnalysisStage_ANALYSIS_STAGE_DONE
+	case domain.StageFailed:
+		return pb.AnalysisStage_ANALYSIS_STAGE_FAILED
+	case domain.StageCancelled:
+		return pb.AnalysisStage_ANALYSIS_STAGE_CANCELLED
+	default:
+		return pb.AnalysisStage_ANALYSIS_STAGE_UNSPECIFIED
+	}
+}
+
 func mapError(err error) error {
 	switch {
 	case err == nil:
@@ -122,6 +783,14 @@ func mapError(err error) error {
 		return status.Error(codes.AlreadyExists, err.Error())
 	case errors.Is(err, errdefs.ErrUnavailable):
 		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, errdefs.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, errdefs.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
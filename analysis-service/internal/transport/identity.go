@@ -0,0 +1,209 @@
+package transport
+
+import (
+	"analysis-service/internal/domain"
+	"analysis-service/internal/errdefs"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"storing-service/pkg/identity"
+
+	"github.com/google/uuid"
+)
+
+// principal - клиент запроса, подпись которого проверил identity.Verifier
+type principal struct {
+	subject string
+	kind    string
+	role    string
+	courses []string
+}
+
+// principalFromContext возвращает клиента запроса. Вызов без подписанного клиента отклоняется:
+// доступ без ограничений есть только у внутренних вызовов storing-service с видом system.
+func principalFromContext(ctx context.Context) (*principal, error) {
+	id, ok := identity.FromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("request has no signed identity: %w", errdefs.ErrUnauthenticated)
+	}
+	return &principal{
+		subject: id.Subject,
+		kind:    id.Kind,
+		role:    id.Role,
+		courses: id.Courses,
+	}, nil
+}
+
+func (p *principal) isAdmin() bool {
+	return p.role == identity.RoleAdmin || p.kind == identity.KindSystem
+}
+
+func (p *principal) isTeacher() bool {
+	return p.role == identity.RoleTeacher || p.isAdmin()
+}
+
+func (p *principal) teaches(courseId string) bool {
+	return courseId != "" && p.isTeacher() && slices.Contains(p.courses, courseId)
+}
+
+// canAccess: своя работа, работа курса преподавателя или любая работа для администратора.
+// Отчеты без владельца (созданные до его появления) доступны только администратору.
+func (p *principal) canAccess(owner domain.ReportOwner) bool {
+	if p.isAdmin() {
+		return true
+	}
+	if p.kind == identity.KindUser && owner.UploadedBy != uuid.Nil && p.subject == owner.UploadedBy.String() {
+		return true
+	}
+	return p.teaches(owner.CourseId)
+}
+
+// checkReportAccess проверяет доступ клиента к отчету по его владельцу
+func checkReportAccess(ctx context.Context, owner domain.ReportOwner) error {
+	p, err := principalFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if p.canAccess(owner) {
+		return nil
+	}
+	return fmt.Errorf("%s %s has no access to the report: %w", p.role, p.subject, errdefs.ErrPermissionDenied)
+}
+
+// checkCourseAccess проверяет, что клиент ведет курс или он администратор
+func checkCourseAccess(ctx context.Context, courseId string) error {
+	p, err := principalFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if p.isAdmin() || p.teaches(courseId) {
+		return nil
+	}
+	return fmt.Errorf("%s %s has no access to course %q: %w", p.role, p.subject, courseId, errdefs.ErrPermissionDenied)
+}
+
+// checkAdmin проверяет, что клиент - администратор
+func checkAdmin(ctx context.Context) error {
+	p, err := principalFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if p.isAdmin() {
+		return nil
+	}
+	return fmt.Errorf("%s %s is not an admin: %w", p.role, p.subject, errdefs.ErrPermissionDenied)
+}
+
+// canSeeSources сообщает, что клиенту видны работы-источники отчетов. Студенту источники
+// обезличиваются: он видит сходство и задание источника, но не задачу, ее файл и автора.
+// Без клиента источники скрываются.
+func canSeeSources(ctx context.Context) bool {
+	p, err := principalFromContext(ctx)
+	return err == nil && p.isTeacher()
+}
+
+// accessScope возвращает ограничение выборки отчетов клиентом; nil - без ограничений
+func accessScope(ctx context.Context) (*domain.AccessScope, error) {
+	p, err := principalFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p.isAdmin() {
+		return nil, nil
+	}
+
+	scope := &domain.AccessScope{CourseIds: []string{}}
+	if p.kind == identity.KindUser {
+		// subject не UUID - у пользователя не может быть своих работ
+		scope.UploadedBy, _ = uuid.Parse(p.subject)
+	}
+	if p.isTeacher() {
+		scope.CourseIds = p.courses
+	}
+	return scope, nil
+}
+
+// latestOwner возвращает владельца последней версии отчета задачи; found = false, если отчетов нет
+func (h *AnalysisHandler) latestOwner(ctx context.Context, taskId uuid.UUID) (domain.ReportOwner, bool, error) {
+	report, err := h.svc.GetReport(ctx, taskId, 0)
+	if errors.Is(err, errdefs.ErrNotFound) {
+		return domain.ReportOwner{}, false, nil
+	}
+	if err != nil {
+		return domain.ReportOwner{}, false, err
+	}
+	return report.Owner, true, nil
+}
+
+// taskOwner возвращает владельца задачи по последней версии отчета. У первого анализа задачи
+// отчета еще нет: владелец берется из storing-service, задача другого тенанта там не находится.
+func (h *AnalysisHandler) taskOwner(ctx context.Context, taskId uuid.UUID) (domain.ReportOwner, error) {
+	owner, found, err := h.latestOwner(ctx, taskId)
+	if err != nil || found {
+		return owner, err
+	}
+	return h.tasks.GetTaskOwner(ctx, taskId)
+}
+
+// authorizeWatch проверяет доступ к ходу анализа по владельцу задачи
+func (h *AnalysisHandler) authorizeWatch(ctx context.Context, taskId uuid.UUID) error {
+	if _, err := principalFromContext(ctx); err != nil {
+		return err
+	}
+
+	owner, err := h.taskOwner(ctx, taskId)
+	if err != nil {
+		return err
+	}
+	return checkReportAccess(ctx, owner)
+}
+
+// authorizeTeacherTask проверяет, что клиент может управлять анализом задачи: администратор или
+// преподаватель курса задачи. Курс берется из отчета или storing-service, а не из запроса: переданный
+// курс courseId должен с ним совпадать.
+func (h *AnalysisHandler) authorizeTeacherTask(ctx context.Context, taskId uuid.UUID, courseId string) error {
+	p, err := principalFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if p.isAdmin() {
+		return nil
+	}
+	if !p.isTeacher() {
+		return fmt.Errorf("%s %s cannot manage analysis: %w", p.role, p.subject, errdefs.ErrPermissionDenied)
+	}
+
+	owner, err := h.taskOwner(ctx, taskId)
+	if err != nil {
+		return err
+	}
+	if courseId != "" && courseId != owner.CourseId {
+		return fmt.Errorf("task %s does not belong to course %q: %w", taskId, courseId, errdefs.ErrPermissionDenied)
+	}
+	return checkCourseAccess(ctx, owner.CourseId)
+}
+
+// accessibleTaskIds оставляет задачи, к последним отчетам которых у клиента есть доступ.
+// Задачи без отчетов отбрасываются: их владелец analysis-service неизвестен.
+func (h *AnalysisHandler) accessibleTaskIds(ctx context.Context, taskIds []uuid.UUID) ([]uuid.UUID, error) {
+	p, err := principalFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p.isAdmin() {
+		return taskIds, nil
+	}
+
+	result := make([]uuid.UUID, 0, len(taskIds))
+	for _, taskId := range taskIds {
+		owner, found, err := h.latestOwner(ctx, taskId)
+		if err != nil {
+			return nil, err
+		}
+		if found && p.canAccess(owner) {
+			result = append(result, taskId)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,226 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"analysis-service/internal/domain"
+	"analysis-service/internal/errdefs"
+	pb "analysis-service/pkg/api"
+	"storing-service/pkg/identity"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordingService отдает один отчет и запоминает вызовы, которые меняют данные или раскрывают
+// отчеты; остальные методы не реализованы
+type recordingService struct {
+	AnalysisService
+	report *domain.Report
+	calls  []string
+}
+
+func (s *recordingService) GetReport(ctx context.Context, taskId uuid.UUID, version int) (*domain.Report, error) {
+	return s.report, nil
+}
+
+func (s *recordingService) AnalyseTask(ctx context.Context, taskId uuid.UUID, objectKey, assignmentId string, owner domain.ReportOwner, duplicateOf *domain.Match, excluded []uuid.UUID) (bool, error) {
+	s.calls = append(s.calls, "AnalyseTask")
+	return false, nil
+}
+
+func (s *recordingService) ListReports(ctx context.Context, filter domain.ReportFilter, cursor string, limit int) ([]*domain.Report, string, error) {
+	s.calls = append(s.calls, "ListReports")
+	return []*domain.Report{s.report}, "", nil
+}
+
+func (s *recordingService) ListSourceMatches(ctx context.Context, taskIds []uuid.UUID) ([]domain.SourceMatch, error) {
+	s.calls = append(s.calls, "ListSourceMatches")
+	return nil, nil
+}
+
+func (s *recordingService) PurgeTask(ctx context.Context, taskId uuid.UUID, anonymiseReferences bool) (*domain.PurgeResult, error) {
+	s.calls = append(s.calls, "PurgeTask")
+	return &domain.PurgeResult{}, nil
+}
+
+func (s *recordingService) GenerateWordCloud(ctx context.Context, fileContent []byte) (string, error) {
+	s.calls = append(s.calls, "GenerateWordCloud")
+	return "", nil
+}
+
+// taskOwners - задачи storing-service тенанта запроса
+type taskOwners map[uuid.UUID]domain.ReportOwner
+
+func (t taskOwners) GetTaskOwner(ctx context.Context, taskId uuid.UUID) (domain.ReportOwner, error) {
+	owner, ok := t[taskId]
+	if !ok {
+		return domain.ReportOwner{}, fmt.Errorf("task %s: %w", taskId, errdefs.ErrNotFound)
+	}
+	return owner, nil
+}
+
+// unanalysedService - задачи без отчетов
+type unanalysedService struct {
+	AnalysisService
+}
+
+func (unanalysedService) GetReport(ctx context.Context, taskId uuid.UUID, version int) (*domain.Report, error) {
+	return nil, fmt.Errorf("report for task %s: %w", taskId, errdefs.ErrNotFound)
+}
+
+func newTestHandler() (*AnalysisHandler, *recordingService) {
+	svc := &recordingService{report: &domain.Report{
+		Id:      uuid.New(),
+		TaskId:  uuid.New(),
+		Version: 1,
+		Owner:   domain.ReportOwner{UploadedBy: uuid.New(), CourseId: "cs101"},
+		Sources: []domain.Match{{TaskId: uuid.New(), ObjectKey: "cs101/a.txt", Percentage: 80}},
+	}}
+	return NewAnalysisHandler(svc, taskOwners{}, zap.NewNop()), svc
+}
+
+func TestHandlerRejectsCallsWithoutIdentity(t *testing.T) {
+	h, svc := newTestHandler()
+	ctx := context.Background()
+	taskId := svc.report.TaskId.String()
+
+	calls := map[string]func() error{
+		"AnalyseTask": func() error {
+			_, err := h.AnalyseTask(ctx, &pb.AnalyzeTaskRequest{TaskId: taskId, CourseId: "cs101"})
+			return err
+		},
+		"GetReport": func() error {
+			_, err := h.GetReport(ctx, &pb.GetReportRequest{TaskId: taskId})
+			return err
+		},
+		"ListReports": func() error {
+			_, err := h.ListReports(ctx, &pb.ListReportsRequest{})
+			return err
+		},
+		"ListSourceMatches": func() error {
+			_, err := h.ListSourceMatches(ctx, &pb.ListSourceMatchesRequest{TaskIds: []string{taskId}})
+			return err
+		},
+		"PurgeTask": func() error {
+			_, err := h.PurgeTask(ctx, &pb.PurgeTaskRequest{TaskId: taskId})
+			return err
+		},
+		"GenerateWordCloud": func() error {
+			_, err := h.GenerateWordCloud(ctx, &pb.GenerateWordCloudRequest{FileContent: []byte("text")})
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if err := call(); status.Code(err) != codes.Unauthenticated {
+				t.Fatalf("err = %v, want Unauthenticated", err)
+			}
+		})
+	}
+	if len(svc.calls) != 0 {
+		t.Fatalf("service was called without identity: %v", svc.calls)
+	}
+}
+
+func TestCanSeeSourcesRequiresIdentity(t *testing.T) {
+	if canSeeSources(context.Background()) {
+		t.Fatal("sources visible without identity")
+	}
+
+	student := identity.WithIdentity(context.Background(), identity.Identity{
+		Subject: uuid.NewString(), Kind: identity.KindUser, Role: identity.RoleStudent, Tenant: "default",
+	})
+	if canSeeSources(student) {
+		t.Fatal("sources visible to a student")
+	}
+
+	system := identity.WithIdentity(context.Background(), identity.Identity{
+		Subject: "storing-service", Kind: identity.KindSystem, Role: identity.RoleAdmin, Tenant: "default",
+	})
+	if !canSeeSources(system) {
+		t.Fatal("sources hidden from storing-service")
+	}
+}
+
+func TestHandlerChecksSignedIdentity(t *testing.T) {
+	h, svc := newTestHandler()
+	taskId := svc.report.TaskId.String()
+
+	student := identity.WithIdentity(context.Background(), identity.Identity{
+		Subject: uuid.NewString(), Kind: identity.KindUser, Role: identity.RoleStudent, Tenant: "default",
+	})
+	if _, err := h.GetReport(student, &pb.GetReportRequest{TaskId: taskId}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("other student: err = %v, want PermissionDenied", err)
+	}
+	if _, err := h.PurgeTask(student, &pb.PurgeTaskRequest{TaskId: taskId}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("student purge: err = %v, want PermissionDenied", err)
+	}
+
+	owner := identity.WithIdentity(context.Background(), identity.Identity{
+		Subject: svc.report.Owner.UploadedBy.String(), Kind: identity.KindUser, Role: identity.RoleStudent, Tenant: "default",
+	})
+	report, err := h.GetReport(owner, &pb.GetReportRequest{TaskId: taskId})
+	if err != nil {
+		t.Fatalf("owner: %v", err)
+	}
+	if len(report.Sources) != 1 || report.Sources[0].TaskId != "" {
+		t.Fatalf("owner sees source task: %+v", report.Sources)
+	}
+}
+
+func TestAuthorizeWatchChecksTaskOwnerWithoutReport(t *testing.T) {
+	ownTask, otherTask := uuid.New(), uuid.New()
+	author := uuid.New()
+	h := NewAnalysisHandler(unanalysedService{}, taskOwners{
+		ownTask: {UploadedBy: author, CourseId: "cs101"},
+	}, zap.NewNop())
+
+	student := identity.WithIdentity(context.Background(), identity.Identity{
+		Subject: author.String(), Kind: identity.KindUser, Role: identity.RoleStudent, Tenant: "uni",
+	})
+	if err := h.authorizeWatch(student, ownTask); err != nil {
+		t.Fatalf("author: %v", err)
+	}
+	// Задача другого тенанта в storing-service не находится
+	if err := h.authorizeWatch(student, otherTask); status.Code(mapError(err)) != codes.NotFound {
+		t.Fatalf("unknown task: err = %v, want NotFound", err)
+	}
+
+	stranger := identity.WithIdentity(context.Background(), identity.Identity{
+		Subject: uuid.NewString(), Kind: identity.KindUser, Role: identity.RoleStudent, Tenant: "uni",
+	})
+	if err := h.authorizeWatch(stranger, ownTask); status.Code(mapError(err)) != codes.PermissionDenied {
+		t.Fatalf("other student: err = %v, want PermissionDenied", err)
+	}
+}
+
+func TestAuthorizeTeacherTaskUsesTaskCourse(t *testing.T) {
+	taskId := uuid.New()
+	h := NewAnalysisHandler(unanalysedService{}, taskOwners{
+		taskId: {UploadedBy: uuid.New(), CourseId: "cs202"},
+	}, zap.NewNop())
+
+	teacher := func(courses ...string) context.Context {
+		return identity.WithIdentity(context.Background(), identity.Identity{
+			Subject: uuid.NewString(), Kind: identity.KindUser, Role: identity.RoleTeacher, Tenant: "uni", Courses: courses,
+		})
+	}
+
+	// Курс из запроса не заменяет курс задачи
+	for _, courseId := range []string{"", "cs101"} {
+		if err := h.authorizeTeacherTask(teacher("cs101"), taskId, courseId); status.Code(mapError(err)) != codes.PermissionDenied {
+			t.Fatalf("teacher of another course with course %q: err = %v, want PermissionDenied", courseId, err)
+		}
+	}
+	if err := h.authorizeTeacherTask(teacher("cs202"), taskId, ""); err != nil {
+		t.Fatalf("teacher of the task course: %v", err)
+	}
+	if err := h.authorizeTeacherTask(teacher("cs202"), uuid.New(), "cs202"); status.Code(mapError(err)) != codes.NotFound {
+		t.Fatalf("unknown task: err = %v, want NotFound", err)
+	}
+}
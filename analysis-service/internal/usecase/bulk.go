@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"analysis-service/internal/domain"
+	"analysis-service/internal/errdefs"
+	"analysis-service/internal/infrastructure/dto"
+	"context"
+	"errors"
+	"fmt"
+	"storing-service/pkg/tenant"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// StartBulkReanalysis создает задание массового повторного анализа задач тенанта запроса и запускает его в фоне.
+// Задачи обрабатываются последовательно не быстрее ratePerMinute в минуту (0 - значение из конфигурации),
+// прогресс и сводка изменений вердиктов сохраняются в БД после каждой задачи.
+func (s *AnalysisService) StartBulkReanalysis(ctx context.Context, scope domain.ReanalysisScope, ratePerMinute int) (*domain.ReanalysisJob, error) {
+	s.logger.Info("starting bulk reanalysis",
+		zap.String("assignment_id", scope.AssignmentId),
+		zap.Int("rate_per_minute", ratePerMinute))
+
+	if ratePerMinute < 0 {
+		s.logger.Warn("invalid reanalysis rate", zap.Int("rate_per_minute", ratePerMinute))
+		return nil, fmt.Errorf("invalid rate per minute %d: %w", ratePerMinute, errdefs.ErrInvalidArgument)
+	}
+	if ratePerMinute == 0 {
+		ratePerMinute = max(s.cfg.ReanalysisRatePerMinute, 1)
+	}
+
+	if scope.CreatedFrom != nil && scope.CreatedTo != nil && !scope.CreatedFrom.Before(*scope.CreatedTo) {
+		s.logger.Warn("invalid reanalysis date range",
+			zap.Time("created_from", *scope.CreatedFrom),
+			zap.Time("created_to", *scope.CreatedTo))
+		return nil, fmt.Errorf("created_from must be before created_to: %w", errdefs.ErrInvalidArgument)
+	}
+
+	jobId, err := uuid.NewV7()
+	if err != nil {
+		s.logger.Error("failed to generate job UUID", zap.Error(err))
+		return nil, err
+	}
+
+	job := &domain.ReanalysisJob{
+		Id:            jobId,
+		Scope:         scope,
+		RatePerMinute: ratePerMinute,
+		Status:        domain.JobPending,
+		CreatedAt:     time.Now(),
+	}
+
+	err = s.repo.CreateReanalysisJob(ctx, &dto.CreateReanalysisJobDTO{
+		Id:            job.Id,
+		Scope:         job.Scope,
+		RatePerMinute: job.RatePerMinute,
+		Status:        job.Status,
+		CreatedAt:     job.CreatedAt,
+	})
+	if err != nil {
+		s.logger.Error("failed to create reanalysis job",
+			zap.String("job_id", jobId.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	jobCtx, finish, err := s.jobs.start(tenant.Detach(ctx), jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer finish()
+		s.runBulkReanalysis(jobCtx, *job)
+	}()
+
+	s.logger.Info("bulk reanalysis job created", zap.String("job_id", jobId.String()))
+	return job, nil
+}
+
+func (s *AnalysisService) GetBulkReanalysis(ctx context.Context, jobId uuid.UUID) (*domain.ReanalysisJob, error) {
+	s.logger.Info("getting bulk reanalysis job", zap.String("job_id", jobId.String()))
+
+	job, err := s.repo.GetReanalysisJob(ctx, &dto.GetReanalysisJobDTO{Id: jobId})
+	if err != nil {
+		s.logger.Error("failed to get reanalysis job",
+			zap.String("job_id", jobId.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (s *AnalysisService) CancelBulkReanalysis(ctx context.Context, jobId uuid.UUID) error {
+	s.logger.Info("cancelling bulk reanalysis", zap.String("job_id", jobId.String()))
+
+	// Задание другого тенанта не находится
+	if _, err := s.repo.GetReanalysisJob(ctx, &dto.GetReanalysisJobDTO{Id: jobId}); err != nil {
+		s.logger.Warn("reanalysis job not found",
+			zap.String("job_id", jobId.String()),
+			zap.Error(err))
+		return err
+	}
+
+	if !s.jobs.cancel(ctx, jobId) {
+		s.logger.Warn("no running reanalysis job to cancel", zap.String("job_id", jobId.String()))
+		return fmt.Errorf("no running reanalysis job %s: %w", jobId, errdefs.ErrNotFound)
+	}
+
+	return nil
+}
+
+// RecoverReanalysisJobs помечает задания всех тенантов, прерванные перестартом сервиса, как завершившиеся с ошибкой
+func (s *AnalysisService) RecoverReanalysisJobs(ctx context.Context) error {
+	count, err := s.repo.FailInterruptedReanalysisJobs(ctx)
+	if err != nil {
+		s.logger.Error("failed to recover reanalysis jobs", zap.Error(err))
+		return err
+	}
+
+	if count > 0 {
+		s.logger.Warn("interrupted reanalysis jobs marked as failed", zap.Int64("count", count))
+	}
+	return nil
+}
+
+func (s *AnalysisService) runBulkReanalysis(ctx context.Context, job domain.ReanalysisJob) {
+	// Состояние задания сохраняется и после отмены
+	saveCtx := context.WithoutCancel(ctx)
+	save := func() {
+		if err := s.repo.UpdateReanalysisJob(saveCtx, &job); err != nil {
+			s.logger.Error("failed to save reanalysis job progress",
+				zap.String("job_id", job.Id.String()),
+				zap.Error(err))
+		}
+	}
+	finish := func(status domain.ReanalysisJobStatus) {
+		finishedAt := time.Now()
+		job.Status = status
+		job.FinishedAt = &finishedAt
+		save()
+
+		s.logger.Info("bulk reanalysis finished",
+			zap.String("job_id", job.Id.String()),
+			zap.String("status", string(status)),
+			zap.Int("processed", job.Processed),
+			zap.Int("failed", job.Failed),
+			zap.Int("skipped", job.Skipped),
+			zap.Int("flipped_to_plagiarism", job.FlippedToPlagiarism),
+			zap.Int("flipped_to_clean", job.FlippedToClean))
+	}
+
+	startedAt := time.Now()
+	job.Status = domain.JobRunning
+	job.StartedAt = &startedAt
+
+	targets, err := s.repo.ListReanalysisTargets(ctx, &dto.ListReanalysisTargetsDTO{Scope: job.Scope})
+	if err != nil {
+		job.Error = err.Error()
+		finish(domain.JobFailed)
+		return
+	}
+
+	job.Total = len(targets)
+	save()
+
+	s.logger.Info("bulk reanalysis running",
+		zap.String("job_id", job.Id.String()),
+		zap.Int("total", job.Total))
+
+	throttle := time.NewTicker(time.Minute / time.Duration(job.RatePerMinute))
+	defer throttle.Stop()
+
+	for i, target := range targets {
+		if i > 0 {
+			select {
+			case <-throttle.C:
+			case <-ctx.Done():
+				finish(domain.JobCancelled)
+				return
+			}
+		}
+
+		report, err := s.reanalyseTarget(ctx, target)
+		switch {
+		case err == nil:
+			job.Processed++
+			if report.IsPlagiarism && !target.IsPlagiarism {
+				job.FlippedToPlagiarism++
+			}
+			if !report.IsPlagiarism && target.IsPlagiarism {
+				job.FlippedToClean++
+			}
+		case errors.Is(err, errdefs.ErrAlreadyExists):
+			job.Skipped++
+		case ctx.Err() != nil:
+			finish(domain.JobCancelled)
+			return
+		default:
+			job.Failed++
+		}
+		save()
+	}
+
+	finish(domain.JobCompleted)
+}
+
+func (s *AnalysisService) reanalyseTarget(ctx context.Context, target domain.ReanalysisTarget) (*dto.CreateReportDTO, error) {
+	runCtx, finish, err := s.runs.start(ctx, target.TaskId)
+	if err != nil {
+		s.logger.Debug("task is already being analysed, skipping",
+			zap.String("task_id", target.TaskId.String()))
+		return nil, err
+	}
+	defer finish()
+
+	return s.analyse(runCtx, target.TaskId, target.ObjectKey, target.AssignmentId, target.Owner, nil, target.ExcludedTaskIds)
+}
@@ -1,9 +1,23 @@
 package usecase
 
 import (
+	"analysis-service/internal/domain"
 	"context"
 	"strings"
 	"unicode"
+	"unicode/utf8"
+)
+
+// Версия алгоритма сохраняется в каждом отчете. Ее нужно менять при любом изменении
+// нормализации текста или метрики схожести, чтобы старые отчеты оставались воспроизводимыми.
+const textComparatorVersion = "ngram3-jaccard-v1"
+
+const (
+	ngramSize = 3
+	// Минимальная длина фрагмента совпадения в байтах нормализованного текста
+	minSpanLength = 16
+	// Фрагментов одного источника в отчете не больше maxSpansPerSource
+	maxSpansPerSource = 100
 )
 
 type TextComparator struct{}
@@ -12,26 +26,70 @@ func NewTextComparator() *TextComparator {
 	return &TextComparator{}
 }
 
-func (c *TextComparator) CompareFiles(ctx context.Context, file1, file2 []byte) (float64, error) {
-	text1 := string(file1)
-	text2 := string(file2)
+func (c *TextComparator) AlgorithmVersion() string {
+	return textComparatorVersion
+}
 
-	text1 = normalizeText(text1)
-	text2 = normalizeText(text2)
+// CompareFiles возвращает схожесть файлов и фрагменты file1, n-граммы которых есть в file2.
+// Фрагменты не влияют на процент схожести.
+func (c *TextComparator) CompareFiles(ctx context.Context, file1, file2 []byte) (float64, []domain.MatchedSpan, error) {
+	text1, offsets := normalizeTextWithOffsets(string(file1))
+	text2 := normalizeText(string(file2))
 
 	similarity := calculateSimilarity(text1, text2)
+	spans := matchedSpans(text1, offsets, createNGrams(text2, ngramSize))
 
-	return similarity, nil
+	return similarity, spans, nil
 }
 
 func normalizeText(text string) string {
+	normalized, _ := normalizeTextWithOffsets(text)
+	return normalized
+}
+
+// normalizeTextWithOffsets нормализует текст и для каждого байта результата запоминает границы
+// руны исходного текста, из которой он получен: offsets[2*i] - начало, offsets[2*i+1] - конец
+func normalizeTextWithOffsets(text string) (string, []int) {
 	var builder strings.Builder
-	for _, r := range text {
+	var offsets []int
+	for i, r := range text {
 		if unicode.IsLetter(r) || unicode.IsDigit(r) {
-			builder.WriteRune(unicode.ToLower(r))
+			n, _ := builder.WriteRune(unicode.ToLower(r))
+			for range n {
+				offsets = append(offsets, i, i+utf8.RuneLen(r))
+			}
+		}
+	}
+	return builder.String(), offsets
+}
+
+// matchedSpans склеивает совпавшие n-граммы нормализованного текста в непрерывные фрагменты
+// и переводит их в байтовые границы исходного файла. Фрагменты короче minSpanLength байт
+// нормализованного текста (случайные совпадения коротких слов) отбрасываются.
+func matchedSpans(text string, offsets []int, ngrams map[string]bool) []domain.MatchedSpan {
+	var spans []domain.MatchedSpan
+	start, end := -1, -1
+	flush := func() {
+		if start >= 0 && end-start >= minSpanLength && len(spans) < maxSpansPerSource {
+			spans = append(spans, domain.MatchedSpan{Start: offsets[2*start], End: offsets[2*(end-1)+1]})
+		}
+		start, end = -1, -1
+	}
+
+	for i := 0; i <= len(text)-ngramSize; i++ {
+		if !ngrams[text[i:i+ngramSize]] {
+			continue
+		}
+		if start >= 0 && i > end {
+			flush()
+		}
+		if start < 0 {
+			start = i
 		}
+		end = i + ngramSize
 	}
-	return builder.String()
+	flush()
+	return spans
 }
 
 func calculateSimilarity(text1, text2 string) float64 {
@@ -42,9 +100,8 @@ func calculateSimilarity(text1, text2 string) float64 {
 		return 0.0
 	}
 
-	n := 3
-	ngrams1 := createNGrams(text1, n)
-	ngrams2 := createNGrams(text2, n)
+	ngrams1 := createNGrams(text1, ngramSize)
+	ngrams2 := createNGrams(text2, ngramSize)
 
 	intersection := 0
 	for ngram := range ngrams1 {
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"analysis-service/internal/domain"
+	"context"
+	"testing"
+)
+
+func TestTextComparatorReportsMatchedSpans(t *testing.T) {
+	target := "Введение. The quick brown fox jumps over the lazy dog! Остальное написано самостоятельно."
+	source := "something else entirely; THE QUICK BROWN FOX JUMPS OVER THE LAZY DOG; and more"
+
+	_, spans, err := NewTextComparator().CompareFiles(context.Background(), []byte(target), []byte(source))
+	if err != nil {
+		t.Fatalf("CompareFiles: %v", err)
+	}
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1: %+v", len(spans), spans)
+	}
+	if got := target[spans[0].Start:spans[0].End]; got != "The quick brown fox jumps over the lazy dog" {
+		t.Errorf("span text = %q", got)
+	}
+}
+
+func TestTextComparatorSpansKeepSimilarity(t *testing.T) {
+	target := []byte("Совпадающий абзац текста, который встречается в обоих файлах целиком.")
+	source := []byte("Совпадающий абзац текста, который встречается в обоих файлах целиком.")
+
+	similarity, spans, err := NewTextComparator().CompareFiles(context.Background(), target, source)
+	if err != nil {
+		t.Fatalf("CompareFiles: %v", err)
+	}
+	if similarity != 100 {
+		t.Errorf("similarity = %v, want 100", similarity)
+	}
+	want := []domain.MatchedSpan{{Start: 0, End: len(target) - 1}}
+	if len(spans) != 1 || spans[0] != want[0] {
+		t.Errorf("spans = %+v, want %+v", spans, want)
+	}
+}
+
+func TestTextComparatorIgnoresShortMatches(t *testing.T) {
+	target := []byte("the cat sat on a mat")
+	source := []byte("the dog ate my homework")
+
+	_, spans, err := NewTextComparator().CompareFiles(context.Background(), target, source)
+	if err != nil {
+		t.Fatalf("CompareFiles: %v", err)
+	}
+	if len(spans) != 0 {
+		t.Errorf("spans = %+v, want none", spans)
+	}
+}
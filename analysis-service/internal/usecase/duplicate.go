@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"analysis-service/internal/domain"
+	"analysis-service/internal/infrastructure/dto"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// exactDuplicateAlgorithm - версия алгоритма в отчетах, построенных по совпадению SHA-256 содержимого
+const exactDuplicateAlgorithm = "sha256-exact"
+
+// reportExactDuplicate сохраняет отчет о 100% совпадении с исходной задачей без сравнения с корпусом
+func (s *AnalysisService) reportExactDuplicate(ctx context.Context, taskId uuid.UUID, objectKey, assignmentId string, owner domain.ReportOwner, original domain.Match, excluded []uuid.UUID) (*dto.CreateReportDTO, error) {
+	s.logger.Info("exact duplicate detected, skipping comparison",
+		zap.String("task_id", taskId.String()),
+		zap.String("duplicate_of", original.TaskId.String()))
+
+	reportId, err := uuid.NewV7()
+	if err != nil {
+		s.logger.Error("failed to generate report UUID", zap.Error(err))
+		return nil, err
+	}
+
+	original.Percentage = 100
+	now := time.Now()
+	dto := &dto.CreateReportDTO{
+		Id:                   reportId,
+		TaskId:               taskId,
+		ObjectKey:            objectKey,
+		AssignmentId:         assignmentId,
+		Owner:                owner,
+		AlgorithmVersion:     exactDuplicateAlgorithm,
+		Policy:               domain.ReportPolicy{Threshold: s.plagiarismThreshold(ctx)},
+		IsPlagiarism:         true,
+		PlagiarismPercentage: original.Percentage,
+		DuplicateOf:          original.TaskId,
+		ExcludedTaskIds:      excluded,
+		CorpusSnapshotAt:     now,
+		CreatedAt:            now,
+		Sources:              []domain.Match{original},
+	}
+
+	s.logger.Debug("saving duplicate report to database", zap.String("task_id", taskId.String()))
+	if err := s.repo.CreateReport(ctx, dto); err != nil {
+		s.logger.Error("failed to create report in database",
+			zap.String("task_id", taskId.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return dto, nil
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"analysis-service/internal/domain"
+	"analysis-service/internal/errdefs"
+	"analysis-service/internal/infrastructure/dto"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// ListReports возвращает страницу последних версий отчетов, от новых к старым, и курсор следующей страницы.
+// Пустой курсор в ответе означает, что страница последняя.
+func (s *AnalysisService) ListReports(ctx context.Context, filter domain.ReportFilter, cursor string, limit int) ([]*domain.Report, string, error) {
+	s.logger.Info("listing reports",
+		zap.String("assignment_id", filter.AssignmentId),
+		zap.Int("limit", limit))
+
+	if limit < 0 || limit > maxListLimit {
+		s.logger.Warn("invalid list limit", zap.Int("limit", limit))
+		return nil, "", fmt.Errorf("limit must be between 1 and %d: %w", maxListLimit, errdefs.ErrInvalidArgument)
+	}
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+
+	for _, p := range []*float64{filter.MinPercentage, filter.MaxPercentage} {
+		if p != nil && (*p < 0 || *p > 100) {
+			s.logger.Warn("invalid percentage filter", zap.Float64("percentage", *p))
+			return nil, "", fmt.Errorf("percentage must be between 0 and 100: %w", errdefs.ErrInvalidArgument)
+		}
+	}
+	if filter.MinPercentage != nil && filter.MaxPercentage != nil && *filter.MinPercentage > *filter.MaxPercentage {
+		s.logger.Warn("invalid percentage range",
+			zap.Float64("min_percentage", *filter.MinPercentage),
+			zap.Float64("max_percentage", *filter.MaxPercentage))
+		return nil, "", fmt.Errorf("min_percentage must not exceed max_percentage: %w", errdefs.ErrInvalidArgument)
+	}
+
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		s.logger.Warn("invalid created_at range",
+			zap.Time("created_from", *filter.CreatedFrom),
+			zap.Time("created_to", *filter.CreatedTo))
+		return nil, "", fmt.Errorf("created_from must be before created_to: %w", errdefs.ErrInvalidArgument)
+	}
+
+	after, err := decodeReportCursor(cursor)
+	if err != nil {
+		s.logger.Warn("invalid list cursor", zap.String("cursor", cursor), zap.Error(err))
+		return nil, "", err
+	}
+
+	// Лишняя запись показывает, есть ли следующая страница
+	reports, err := s.repo.ListReports(ctx, &dto.ListReportsDTO{
+		Filter: filter,
+		After:  after,
+		Limit:  limit + 1,
+	})
+	if err != nil {
+		s.logger.Error("failed to list reports", zap.Error(err))
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(reports) > limit {
+		reports = reports[:limit]
+		last := reports[limit-1]
+		nextCursor = encodeReportCursor(domain.ReportCursor{CreatedAt: last.CreatedAt, Id: last.Id})
+	}
+
+	s.logger.Info("reports listed",
+		zap.Int("count", len(reports)),
+		zap.Bool("has_more", nextCursor != ""))
+	return reports, nextCursor, nil
+}
+
+// encodeReportCursor кодирует позицию в непрозрачную для клиента строку
+func encodeReportCursor(c domain.ReportCursor) string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixMicro(), 10) + ":" + c.Id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeReportCursor(cursor string) (*domain.ReportCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", errdefs.ErrInvalidArgument)
+	}
+
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, fmt.Errorf("malformed cursor: %w", errdefs.ErrInvalidArgument)
+	}
+
+	usec, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", errdefs.ErrInvalidArgument)
+	}
+
+	reportId, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", errdefs.ErrInvalidArgument)
+	}
+
+	// created_at хранится без часового пояса, pgx читает его как UTC
+	return &domain.ReportCursor{CreatedAt: time.UnixMicro(usec).UTC(), Id: reportId}, nil
+}
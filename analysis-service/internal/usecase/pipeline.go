@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"analysis-service/internal/domain"
+	"context"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+type fetchedFile struct {
+	index   int
+	key     string
+	content []byte
+}
+
+type comparisonResult struct {
+	index      int
+	key        string
+	percentage float64
+	spans      []domain.MatchedSpan
+	ok         bool
+}
+
+// compareWithCandidates загружает файлы-кандидаты параллельно (не более FetchConcurrency
+// одновременных запросов к MinIO) и сравнивает их с целевым файлом на CompareWorkers воркерах.
+// Результат не зависит от порядка завершения горутин: совпадения отсортированы
+// по убыванию процента схожести, а при равенстве - по ключу объекта.
+// onProgress вызывается после обработки каждого кандидата.
+func (s *AnalysisService) compareWithCandidates(ctx context.Context, targetFile []byte, keys []string, onProgress func(compared, total int)) ([]domain.Match, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	fetchConcurrency := max(s.cfg.FetchConcurrency, 1)
+	compareWorkers := max(s.cfg.CompareWorkers, 1)
+
+	s.logger.Debug("starting comparison pipeline",
+		zap.Int("candidates", len(keys)),
+		zap.Int("fetch_concurrency", fetchConcurrency),
+		zap.Int("compare_workers", compareWorkers))
+
+	fetched := make(chan fetchedFile, compareWorkers)
+	results := make(chan comparisonResult, compareWorkers)
+
+	go func() {
+		defer close(fetched)
+
+		sem := make(chan struct{}, fetchConcurrency)
+		var wg sync.WaitGroup
+		defer wg.Wait()
+
+		for i, key := range keys {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			wg.Add(1)
+			go func(index int, key string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				content, err := s.readFile(ctx, key)
+				if err != nil {
+					if ctx.Err() == nil {
+						s.logger.Warn("failed to get file for comparison",
+							zap.String("key", key),
+							zap.Error(err))
+					}
+					select {
+					case results <- comparisonResult{index: index, key: key}:
+					case <-ctx.Done():
+					}
+					return
+				}
+
+				select {
+				case fetched <- fetchedFile{index: index, key: key, content: content}:
+				case <-ctx.Done():
+				}
+			}(i, key)
+		}
+	}()
+
+	var workers sync.WaitGroup
+	for w := 0; w < compareWorkers; w++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for file := range fetched {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				percentage, spans, err := s.comparator.CompareFiles(ctx, targetFile, file.content)
+				if err != nil {
+					s.logger.Warn("failed to compare files",
+						zap.String("key", file.key),
+						zap.Error(err))
+				}
+
+				select {
+				case results <- comparisonResult{index: file.index, key: file.key, percentage: percentage, spans: spans, ok: err == nil}:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+
+	defer workers.Wait()
+
+	collected := make([]comparisonResult, len(keys))
+	for received := 0; received < len(keys); received++ {
+		select {
+		case res := <-results:
+			collected[res.index] = res
+			if onProgress != nil {
+				onProgress(received+1, len(keys))
+			}
+			if res.ok {
+				s.logger.Debug("comparison result",
+					zap.String("key", res.key),
+					zap.Float64("similarity_percentage", res.percentage))
+			}
+		case <-ctx.Done():
+			s.logger.Warn("comparison pipeline cancelled",
+				zap.Int("compared", received),
+				zap.Int("total", len(keys)),
+				zap.Error(ctx.Err()))
+			return nil, ctx.Err()
+		}
+	}
+
+	matches := make([]domain.Match, 0, len(collected))
+	for _, res := range collected {
+		if res.ok {
+			matches = append(matches, domain.Match{
+				TaskId:     taskIdFromKey(res.key),
+				ObjectKey:  res.key,
+				Percentage: res.percentage,
+				Spans:      res.spans,
+			})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Percentage != matches[j].Percentage {
+			return matches[i].Percentage > matches[j].Percentage
+		}
+		return matches[i].ObjectKey < matches[j].ObjectKey
+	})
+
+	return matches, nil
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"analysis-service/internal/config"
+	"analysis-service/internal/domain"
+	"analysis-service/internal/errdefs"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// memoryBlobs - хранилище в памяти, считающее одновременные чтения
+type memoryBlobs struct {
+	files   map[string][]byte
+	delay   time.Duration
+	active  atomic.Int32
+	maxSeen atomic.Int32
+}
+
+func (b *memoryBlobs) Get(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	n := b.active.Add(1)
+	defer b.active.Add(-1)
+	for {
+		seen := b.maxSeen.Load()
+		if n <= seen || b.maxSeen.CompareAndSwap(seen, n) {
+			break
+		}
+	}
+
+	select {
+	case <-time.After(b.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	content, ok := b.files[objectKey]
+	if !ok {
+		return nil, fmt.Errorf("object %s: %w", objectKey, errdefs.ErrNotFound)
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (b *memoryBlobs) List(ctx context.Context, prefix string) ([]string, error) {
+	return nil, nil
+}
+
+// percentComparator возвращает схожесть, записанную в самом файле-кандидате
+type percentComparator struct{}
+
+func (percentComparator) CompareFiles(ctx context.Context, file1, file2 []byte) (float64, []domain.MatchedSpan, error) {
+	percentage, err := strconv.ParseFloat(string(file2), 64)
+	return percentage, nil, err
+}
+
+func (percentComparator) AlgorithmVersion() string {
+	return "test"
+}
+
+func newPipelineService(blobs BlobStore, fetchConcurrency, compareWorkers int) *AnalysisService {
+	cfg := &config.AnalysisConfig{FetchConcurrency: fetchConcurrency, CompareWorkers: compareWorkers}
+	return NewAnalysisService(nil, blobs, percentComparator{}, cfg, zap.NewNop())
+}
+
+func TestCompareWithCandidatesOrdersMatches(t *testing.T) {
+	blobs := &memoryBlobs{files: map[string][]byte{
+		"b.txt": []byte("40"),
+		"a.txt": []byte("40"),
+		"c.txt": []byte("90"),
+		"d.txt": []byte("not a number"),
+	}}
+	svc := newPipelineService(blobs, 4, 2)
+
+	var progress []int
+	var mu sync.Mutex
+	matches, err := svc.compareWithCandidates(context.Background(), []byte("target"),
+		[]string{"a.txt", "b.txt", "c.txt", "d.txt", "missing.txt"},
+		func(compared, total int) {
+			mu.Lock()
+			defer mu.Unlock()
+			progress = append(progress, compared)
+			if total != 5 {
+				t.Errorf("total = %d, want 5", total)
+			}
+		})
+	if err != nil {
+		t.Fatalf("compareWithCandidates: %v", err)
+	}
+
+	want := []string{"c.txt", "a.txt", "b.txt"}
+	if len(matches) != len(want) {
+		t.Fatalf("got %d matches, want %d: %+v", len(matches), len(want), matches)
+	}
+	for i, key := range want {
+		if matches[i].ObjectKey != key {
+			t.Errorf("matches[%d] = %s, want %s", i, matches[i].ObjectKey, key)
+		}
+	}
+	if len(progress) != 5 || progress[len(progress)-1] != 5 {
+		t.Errorf("progress = %v, want 5 calls ending with 5", progress)
+	}
+}
+
+func TestCompareWithCandidatesLimitsFetchConcurrency(t *testing.T) {
+	files := make(map[string][]byte)
+	var keys []string
+	for i := 0; i < 20; i++ {
+		key := fmt.Sprintf("%02d.txt", i)
+		files[key] = []byte("10")
+		keys = append(keys, key)
+	}
+	blobs := &memoryBlobs{files: files, delay: 5 * time.Millisecond}
+	svc := newPipelineService(blobs, 3, 2)
+
+	matches, err := svc.compareWithCandidates(context.Background(), []byte("target"), keys, func(int, int) {})
+	if err != nil {
+		t.Fatalf("compareWithCandidates: %v", err)
+	}
+	if len(matches) != len(keys) {
+		t.Fatalf("got %d matches, want %d", len(matches), len(keys))
+	}
+	if got := blobs.maxSeen.Load(); got > 3 {
+		t.Errorf("max concurrent fetches = %d, want at most 3", got)
+	}
+}
+
+func TestCompareWithCandidatesCancelled(t *testing.T) {
+	blobs := &memoryBlobs{files: map[string][]byte{"a.txt": []byte("10")}, delay: time.Second}
+	svc := newPipelineService(blobs, 1, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := svc.compareWithCandidates(ctx, []byte("target"), []string{"a.txt"}, func(int, int) {})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"analysis-service/internal/domain"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const subscriberBufferSize = 16
+
+// finalEventTTL - сколько хранится событие FAILED или CANCELLED: подписчик, пришедший сразу после
+// завершения анализа, еще видит его итог
+const finalEventTTL = time.Minute
+
+// scopedId - задача или задание тенанта: ход и отмена анализа не видны из других тенантов
+type scopedId struct {
+	tenant string
+	id     uuid.UUID
+}
+
+// ProgressTracker хранит последнее событие по каждому выполняющемуся анализу
+// и рассылает новые события подписчикам WatchAnalysis того же тенанта.
+type ProgressTracker struct {
+	mu          sync.Mutex
+	subscribers map[scopedId]map[chan domain.AnalysisEvent]struct{}
+	last        map[scopedId]domain.AnalysisEvent
+	finalTTL    time.Duration
+}
+
+func NewProgressTracker() *ProgressTracker {
+	return &ProgressTracker{
+		subscribers: make(map[scopedId]map[chan domain.AnalysisEvent]struct{}),
+		last:        make(map[scopedId]domain.AnalysisEvent),
+		finalTTL:    finalEventTTL,
+	}
+}
+
+func (t *ProgressTracker) Publish(tenantId string, event domain.AnalysisEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := scopedId{tenant: tenantId, id: event.TaskId}
+
+	// После успешного завершения актуальное состояние хранится в БД отчетов, остальные итоговые
+	// события удаляются через finalTTL, если задачу за это время не начали анализировать снова
+	switch {
+	case event.Stage == domain.StageDone:
+		delete(t.last, key)
+	case event.IsFinal():
+		t.last[key] = event
+		time.AfterFunc(t.finalTTL, func() { t.expire(key, event) })
+	default:
+		t.last[key] = event
+	}
+
+	for ch := range t.subscribers[key] {
+		// Медленный подписчик теряет самые старые события, но не блокирует анализ
+		select {
+		case ch <- event:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
+
+// expire удаляет итоговое событие, если оно все еще последнее
+func (t *ProgressTracker) expire(key scopedId, event domain.AnalysisEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if last, ok := t.last[key]; ok && last == event {
+		delete(t.last, key)
+	}
+}
+
+// Forget удаляет сохраненное состояние анализа задачи
+func (t *ProgressTracker) Forget(tenantId string, taskId uuid.UUID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.last, scopedId{tenant: tenantId, id: taskId})
+}
+
+func (t *ProgressTracker) Subscribe(tenantId string, taskId uuid.UUID) (<-chan domain.AnalysisEvent, *domain.AnalysisEvent, func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := scopedId{tenant: tenantId, id: taskId}
+
+	ch := make(chan domain.AnalysisEvent, subscriberBufferSize)
+	if t.subscribers[key] == nil {
+		t.subscribers[key] = make(map[chan domain.AnalysisEvent]struct{})
+	}
+	t.subscribers[key][ch] = struct{}{}
+
+	var last *domain.AnalysisEvent
+	if event, ok := t.last[key]; ok {
+		last = &event
+	}
+
+	unsubscribe := func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		delete(t.subscribers[key], ch)
+		if len(t.subscribers[key]) == 0 {
+			delete(t.subscribers, key)
+		}
+	}
+
+	return ch, last, unsubscribe
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"analysis-service/internal/domain"
+	"analysis-service/internal/errdefs"
+	"context"
+	"errors"
+	"storing-service/pkg/tenant"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestProgressTrackerSeparatesTenants(t *testing.T) {
+	tracker := NewProgressTracker()
+	taskId := uuid.New()
+
+	updates, last, unsubscribe := tracker.Subscribe("other", taskId)
+	defer unsubscribe()
+
+	tracker.Publish("uni", domain.AnalysisEvent{TaskId: taskId, Stage: domain.StageComparing})
+	if last != nil {
+		t.Fatalf("last = %+v, want none", last)
+	}
+	select {
+	case event := <-updates:
+		t.Fatalf("event of another tenant delivered: %+v", event)
+	default:
+	}
+
+	_, last, unsubscribeOwn := tracker.Subscribe("uni", taskId)
+	defer unsubscribeOwn()
+	if last == nil || last.Stage != domain.StageComparing {
+		t.Fatalf("last = %+v, want comparing", last)
+	}
+}
+
+func TestRunRegistrySeparatesTenants(t *testing.T) {
+	runs := newRunRegistry()
+	taskId := uuid.New()
+	uni := tenant.WithTenant(context.Background(), "uni")
+	other := tenant.WithTenant(context.Background(), "other")
+
+	ctx, finish, err := runs.start(uni, taskId)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer finish()
+
+	if runs.cancel(other, taskId) {
+		t.Fatal("analysis cancelled from another tenant")
+	}
+	if _, _, err := runs.start(uni, taskId); !errors.Is(err, errdefs.ErrAlreadyExists) {
+		t.Fatalf("second start: err = %v, want ErrAlreadyExists", err)
+	}
+	if !runs.cancel(uni, taskId) || ctx.Err() == nil {
+		t.Fatal("analysis not cancelled in its tenant")
+	}
+}
+
+func TestProgressTrackerExpiresFinalEvents(t *testing.T) {
+	tracker := NewProgressTracker()
+	tracker.finalTTL = 10 * time.Millisecond
+	failed, cancelled, restarted := uuid.New(), uuid.New(), uuid.New()
+
+	tracker.Publish("uni", domain.AnalysisEvent{TaskId: failed, Stage: domain.StageFailed, Message: "boom"})
+	tracker.Publish("uni", domain.AnalysisEvent{TaskId: cancelled, Stage: domain.StageCancelled})
+	tracker.Publish("uni", domain.AnalysisEvent{TaskId: restarted, Stage: domain.StageFailed})
+	tracker.Publish("uni", domain.AnalysisEvent{TaskId: restarted, Stage: domain.StageExtracting})
+
+	// Подписчик сразу после завершения видит итог
+	_, last, unsubscribe := tracker.Subscribe("uni", failed)
+	unsubscribe()
+	if last == nil || last.Stage != domain.StageFailed {
+		t.Fatalf("last = %+v, want failed", last)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		tracker.mu.Lock()
+		_, failedKept := tracker.last[scopedId{tenant: "uni", id: failed}]
+		_, cancelledKept := tracker.last[scopedId{tenant: "uni", id: cancelled}]
+		restartedEvent, restartedKept := tracker.last[scopedId{tenant: "uni", id: restarted}]
+		tracker.mu.Unlock()
+
+		if !failedKept && !cancelledKept {
+			if !restartedKept || restartedEvent.Stage != domain.StageExtracting {
+				t.Fatalf("event of a restarted analysis expired: %+v", restartedEvent)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("final events were not expired")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"analysis-service/internal/domain"
+	"analysis-service/internal/infrastructure/dto"
+	"context"
+	"storing-service/pkg/tenant"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// PurgeTask удаляет отчеты задачи, удаленной в storing-service, и ссылки на нее в отчетах
+// других задач (при anonymiseReferences ссылки обезличиваются). Выполняющийся анализ задачи
+// отменяется. Вызов идемпотентен: storing-service повторяет доставку события до успешного ответа.
+func (s *AnalysisService) PurgeTask(ctx context.Context, taskId uuid.UUID, anonymiseReferences bool) (*domain.PurgeResult, error) {
+	s.logger.Info("purging task",
+		zap.String("task_id", taskId.String()),
+		zap.Bool("anonymise_references", anonymiseReferences))
+
+	if s.runs.cancel(ctx, taskId) {
+		s.logger.Info("running analysis cancelled for purged task", zap.String("task_id", taskId.String()))
+	}
+
+	result, err := s.repo.PurgeTask(ctx, &dto.PurgeTaskDTO{
+		TaskId:              taskId,
+		AnonymiseReferences: anonymiseReferences,
+	})
+	if err != nil {
+		s.logger.Error("failed to purge task",
+			zap.String("task_id", taskId.String()),
+			zap.Error(err))
+		return nil, err
+	}
+	s.progress.Forget(tenant.FromContext(ctx), taskId)
+
+	s.logger.Info("task purged",
+		zap.String("task_id", taskId.String()),
+		zap.Int("reports_deleted", result.ReportsDeleted),
+		zap.Int("references_removed", result.ReferencesRemoved))
+	return result, nil
+}
+
+// ListSourceMatches возвращает совпадения, в которых задачи найдены источниками
+// во всех версиях отчетов других задач
+func (s *AnalysisService) ListSourceMatches(ctx context.Context, taskIds []uuid.UUID) ([]domain.SourceMatch, error) {
+	s.logger.Info("listing source matches", zap.Int("task_ids", len(taskIds)))
+
+	if len(taskIds) == 0 {
+		return nil, nil
+	}
+
+	matches, err := s.repo.ListSourceMatches(ctx, &dto.ListSourceMatchesDTO{TaskIds: taskIds})
+	if err != nil {
+		s.logger.Error("failed to list source matches", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("source matches retrieved", zap.Int("count", len(matches)))
+	return matches, nil
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"analysis-service/internal/errdefs"
+	"context"
+	"fmt"
+	"storing-service/pkg/tenant"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// runRegistry хранит функции отмены выполняющихся анализов (или заданий) в тенанте контекста.
+// Для одной задачи одновременно может выполняться только один анализ.
+type runRegistry struct {
+	mu   sync.Mutex
+	runs map[scopedId]context.CancelFunc
+}
+
+func newRunRegistry() *runRegistry {
+	return &runRegistry{
+		runs: make(map[scopedId]context.CancelFunc),
+	}
+}
+
+func (r *runRegistry) start(ctx context.Context, taskId uuid.UUID) (context.Context, func(), error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := scopedId{tenant: tenant.FromContext(ctx), id: taskId}
+	if _, ok := r.runs[key]; ok {
+		return nil, nil, fmt.Errorf("analysis for task %s is already running: %w", taskId, errdefs.ErrAlreadyExists)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	r.runs[key] = cancel
+
+	finish := func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		cancel()
+		delete(r.runs, key)
+	}
+
+	return ctx, finish, nil
+}
+
+func (r *runRegistry) cancel(ctx context.Context, taskId uuid.UUID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cancel, ok := r.runs[scopedId{tenant: tenant.FromContext(ctx), id: taskId}]
+	if ok {
+		cancel()
+	}
+	return ok
+}
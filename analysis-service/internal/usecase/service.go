@@ -1,109 +1,275 @@
 package usecase
 
 import (
+	"analysis-service/internal/config"
 	"analysis-service/internal/domain"
+	"analysis-service/internal/errdefs"
 	"analysis-service/internal/infrastructure/dto"
-	"analysis-service/internal/infrastructure/minio"
 	"analysis-service/internal/infrastructure/wordcloud"
 	"context"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"io"
+	"path"
+	"slices"
+	"storing-service/pkg/tenant"
+	"strings"
 	"time"
 )
 
 type AnalysisRepository interface {
 	CreateReport(ctx context.Context, dto *dto.CreateReportDTO) error
 	GetReport(ctx context.Context, dto *dto.GetReportsDTO) (*domain.Report, error)
+	ListReportVersions(ctx context.Context, dto *dto.ListReportVersionsDTO) ([]*domain.Report, error)
+	ListReports(ctx context.Context, dto *dto.ListReportsDTO) ([]*domain.Report, error)
+	PurgeTask(ctx context.Context, dto *dto.PurgeTaskDTO) (*domain.PurgeResult, error)
+	ListSourceMatches(ctx context.Context, dto *dto.ListSourceMatchesDTO) ([]domain.SourceMatch, error)
+	ListSourceAssignments(ctx context.Context, dto *dto.ListSourceAssignmentsDTO) (map[uuid.UUID]string, error)
+	CreateReanalysisJob(ctx context.Context, dto *dto.CreateReanalysisJobDTO) error
+	UpdateReanalysisJob(ctx context.Context, job *domain.ReanalysisJob) error
+	GetReanalysisJob(ctx context.Context, dto *dto.GetReanalysisJobDTO) (*domain.ReanalysisJob, error)
+	FailInterruptedReanalysisJobs(ctx context.Context) (int64, error)
+	ListReanalysisTargets(ctx context.Context, dto *dto.ListReanalysisTargetsDTO) ([]domain.ReanalysisTarget, error)
 }
 
 type FileComparator interface {
-	CompareFiles(ctx context.Context, file1, file2 []byte) (float64, error)
+	// CompareFiles возвращает процент схожести и фрагменты file1, совпавшие с file2
+	CompareFiles(ctx context.Context, file1, file2 []byte) (float64, []domain.MatchedSpan, error)
+	AlgorithmVersion() string
+}
+
+// BlobStore - хранилище файлов задач: MinIO/S3 или каталог, общий со storing-service.
+// Анализ файлы только читает.
+type BlobStore interface {
+	Get(ctx context.Context, objectKey string) (io.ReadCloser, error)
+	List(ctx context.Context, prefix string) ([]string, error)
 }
 
 type AnalysisService struct {
-	repo        AnalysisRepository
-	minioClient *minio.Client
-	comparator  FileComparator
-	logger      *zap.Logger
+	repo       AnalysisRepository
+	blobs      BlobStore
+	comparator FileComparator
+	cfg        *config.AnalysisConfig
+	progress   *ProgressTracker
+	runs       *runRegistry
+	jobs       *runRegistry
+	logger     *zap.Logger
 }
 
-func NewAnalysisService(repo AnalysisRepository, client *minio.Client, comparator FileComparator, logger *zap.Logger) *AnalysisService {
+func NewAnalysisService(repo AnalysisRepository, blobs BlobStore, comparator FileComparator, cfg *config.AnalysisConfig, logger *zap.Logger) *AnalysisService {
 	return &AnalysisService{
-		repo:        repo,
-		minioClient: client,
-		comparator:  comparator,
-		logger:      logger,
+		repo:       repo,
+		blobs:      blobs,
+		comparator: comparator,
+		cfg:        cfg,
+		progress:   NewProgressTracker(),
+		runs:       newRunRegistry(),
+		jobs:       newRunRegistry(),
+		logger:     logger,
 	}
 }
 
-func (s *AnalysisService) AnalyseTask(ctx context.Context, taskId uuid.UUID, objectKey string) (bool, error) {
+// AnalyseTask анализирует задачу. Если storing-service уже нашел задачу с побайтово совпадающим файлом
+// (duplicateOf), отчет сохраняется сразу, без сравнения с корпусом. Задачи excluded в сравнении не участвуют.
+func (s *AnalysisService) AnalyseTask(ctx context.Context, taskId uuid.UUID, objectKey, assignmentId string, owner domain.ReportOwner, duplicateOf *domain.Match, excluded []uuid.UUID) (bool, error) {
 	s.logger.Info("starting task analysis",
 		zap.String("task_id", taskId.String()),
-		zap.String("object_key", objectKey))
+		zap.String("object_key", objectKey),
+		zap.String("assignment_id", assignmentId))
 
-	s.logger.Debug("fetching all file keys from MinIO")
-	allKeys, err := s.minioClient.GetAllKeys(ctx)
+	if err := s.checkObjectKey(ctx, objectKey); err != nil {
+		return false, err
+	}
+	if duplicateOf != nil {
+		if err := s.checkObjectKey(ctx, duplicateOf.ObjectKey); err != nil {
+			return false, err
+		}
+	}
+
+	ctx, finish, err := s.runs.start(ctx, taskId)
 	if err != nil {
-		s.logger.Error("failed to get all keys from MinIO",
+		s.logger.Warn("analysis already running",
 			zap.String("task_id", taskId.String()),
 			zap.Error(err))
 		return false, err
 	}
+	defer finish()
 
-	s.logger.Debug("filtering keys",
-		zap.Int("total_keys", len(allKeys)),
-		zap.String("target_key", objectKey))
-	otherKeys := filterKeys(allKeys, objectKey)
-	s.logger.Debug("keys filtered",
-		zap.Int("other_keys_count", len(otherKeys)))
+	if _, err := s.analyse(ctx, taskId, objectKey, assignmentId, owner, duplicateOf, excluded); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReanalyseTask запускает повторный анализ в фоне. Результат сохраняется новой версией отчета,
+// предыдущие версии не изменяются. Если objectKey не передан, используется ключ из последнего отчета,
+// задание (assignment) наследуется от последнего отчета. Исключенные из сравнения задачи excluded
+// (предыдущие версии посылки по данным storing-service) заменяют сохраненные в последнем отчете,
+// nil - исключаются те же задачи, что и раньше.
+// Владелец наследуется, если не передан: так отчеты, созданные до появления владельца, его получают.
+func (s *AnalysisService) ReanalyseTask(ctx context.Context, taskId uuid.UUID, objectKey string, owner domain.ReportOwner, excluded []uuid.UUID) error {
+	s.logger.Info("starting task reanalysis",
+		zap.String("task_id", taskId.String()),
+		zap.String("object_key", objectKey))
 
-	s.logger.Debug("fetching target file from MinIO", zap.String("object_key", objectKey))
-	targetFile, err := s.minioClient.GetFile(ctx, objectKey)
+	assignmentId := ""
+	report, err := s.repo.GetReport(ctx, &dto.GetReportsDTO{TaskId: taskId})
+	switch {
+	case err == nil:
+		assignmentId = report.AssignmentId
+		if excluded == nil {
+			excluded = report.ExcludedTaskIds
+		}
+		if owner.UploadedBy == uuid.Nil {
+			owner = report.Owner
+		}
+	case errors.Is(err, errdefs.ErrNotFound) && objectKey != "":
+		s.logger.Debug("no previous report, reanalysing as new task", zap.String("task_id", taskId.String()))
+	default:
+		s.logger.Error("failed to get latest report for reanalysis",
+			zap.String("task_id", taskId.String()),
+			zap.Error(err))
+		return err
+	}
+
+	if objectKey == "" {
+		s.logger.Debug("object key not provided, using latest report", zap.String("task_id", taskId.String()))
+		if report.ObjectKey == "" {
+			s.logger.Warn("latest report has no object key", zap.String("task_id", taskId.String()))
+			return fmt.Errorf("object key is unknown for task %s: %w", taskId, errdefs.ErrInvalidArgument)
+		}
+		objectKey = report.ObjectKey
+	}
+	if err := s.checkObjectKey(ctx, objectKey); err != nil {
+		return err
+	}
+
+	runCtx, finish, err := s.runs.start(tenant.Detach(ctx), taskId)
 	if err != nil {
-		s.logger.Error("failed to get target file from MinIO",
+		s.logger.Warn("analysis already running",
+			zap.String("task_id", taskId.String()),
+			zap.Error(err))
+		return err
+	}
+
+	go func() {
+		defer finish()
+		_, _ = s.analyse(runCtx, taskId, objectKey, assignmentId, owner, nil, excluded)
+	}()
+
+	return nil
+}
+
+func (s *AnalysisService) CancelAnalysis(ctx context.Context, taskId uuid.UUID) error {
+	s.logger.Info("cancelling analysis", zap.String("task_id", taskId.String()))
+
+	if !s.runs.cancel(ctx, taskId) {
+		s.logger.Warn("no running analysis to cancel", zap.String("task_id", taskId.String()))
+		return fmt.Errorf("no running analysis for task %s: %w", taskId, errdefs.ErrNotFound)
+	}
+
+	s.logger.Info("analysis cancelled", zap.String("task_id", taskId.String()))
+	return nil
+}
+
+func (s *AnalysisService) analyse(ctx context.Context, taskId uuid.UUID, objectKey, assignmentId string, owner domain.ReportOwner, duplicateOf *domain.Match, excluded []uuid.UUID) (*dto.CreateReportDTO, error) {
+	var report *dto.CreateReportDTO
+	var err error
+	if duplicateOf != nil {
+		report, err = s.reportExactDuplicate(ctx, taskId, objectKey, assignmentId, owner, *duplicateOf, excluded)
+	} else {
+		report, err = s.runAnalysis(ctx, taskId, objectKey, assignmentId, owner, excluded)
+	}
+	if err != nil {
+		stage := domain.StageFailed
+		if errors.Is(err, context.Canceled) {
+			stage = domain.StageCancelled
+		}
+		s.publishProgress(ctx, domain.AnalysisEvent{
+			TaskId:  taskId,
+			Stage:   stage,
+			Message: err.Error(),
+		})
+		return nil, err
+	}
+
+	s.publishProgress(ctx, domain.AnalysisEvent{
+		TaskId:               taskId,
+		Stage:                domain.StageDone,
+		IsPlagiarism:         report.IsPlagiarism,
+		PlagiarismPercentage: report.PlagiarismPercentage,
+	})
+
+	s.logger.Info("report saved successfully",
+		zap.String("task_id", taskId.String()),
+		zap.Int("version", report.Version))
+	return report, nil
+}
+
+func (s *AnalysisService) runAnalysis(ctx context.Context, taskId uuid.UUID, objectKey, assignmentId string, owner domain.ReportOwner, excluded []uuid.UUID) (*dto.CreateReportDTO, error) {
+	s.publishProgress(ctx, domain.AnalysisEvent{TaskId: taskId, Stage: domain.StageExtracting})
+
+	s.logger.Debug("fetching target file from storage", zap.String("object_key", objectKey))
+	targetFile, err := s.readFile(ctx, objectKey)
+	if err != nil {
+		s.logger.Error("failed to get target file from storage",
 			zap.String("object_key", objectKey),
 			zap.Error(err))
-		return false, err
+		return nil, err
 	}
 	s.logger.Debug("target file fetched",
 		zap.String("object_key", objectKey),
 		zap.Int("file_size", len(targetFile)))
 
-	maxPlagiarism := 0.0
-	s.logger.Debug("comparing with other files", zap.Int("files_to_compare", len(otherKeys)))
-	for i, key := range otherKeys {
-		s.logger.Debug("comparing with file",
-			zap.Int("index", i+1),
-			zap.Int("total", len(otherKeys)),
-			zap.String("other_key", key))
-
-		otherFile, err := s.minioClient.GetFile(ctx, key)
-		if err != nil {
-			s.logger.Warn("failed to get file for comparison",
-				zap.String("key", key),
-				zap.Error(err))
-			continue
-		}
+	s.publishProgress(ctx, domain.AnalysisEvent{TaskId: taskId, Stage: domain.StageIndexing})
 
-		percentage, err := s.comparator.CompareFiles(ctx, targetFile, otherFile)
-		if err != nil {
-			s.logger.Warn("failed to compare files",
-				zap.String("key", key),
-				zap.Error(err))
-			continue
-		}
+	prefix := corpusPrefix(objectKey)
+	s.logger.Debug("fetching corpus file keys from storage", zap.String("prefix", prefix))
+	corpusSnapshotAt := time.Now()
+	allKeys, err := s.blobs.List(ctx, prefix)
+	if err != nil {
+		s.logger.Error("failed to get corpus keys from storage",
+			zap.String("task_id", taskId.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Debug("filtering keys",
+		zap.Int("total_keys", len(allKeys)),
+		zap.String("target_key", objectKey),
+		zap.Int("excluded_tasks", len(excluded)))
+	otherKeys := filterKeys(allKeys, objectKey, excluded)
+	s.logger.Debug("keys filtered",
+		zap.Int("other_keys_count", len(otherKeys)))
 
-		s.logger.Debug("comparison result",
-			zap.String("key", key),
-			zap.Float64("similarity_percentage", percentage))
+	s.publishProgress(ctx, domain.AnalysisEvent{TaskId: taskId, Stage: domain.StageComparing, Total: len(otherKeys)})
 
-		if percentage > maxPlagiarism {
-			maxPlagiarism = percentage
-		}
+	s.logger.Debug("comparing with other files", zap.Int("files_to_compare", len(otherKeys)))
+	matches, err := s.compareWithCandidates(ctx, targetFile, otherKeys, func(compared, total int) {
+		s.publishProgress(ctx, domain.AnalysisEvent{
+			TaskId:   taskId,
+			Stage:    domain.StageComparing,
+			Compared: compared,
+			Total:    total,
+		})
+	})
+	if err != nil {
+		s.logger.Error("failed to compare with other files",
+			zap.String("task_id", taskId.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	maxPlagiarism := 0.0
+	if len(matches) > 0 {
+		maxPlagiarism = matches[0].Percentage
 	}
 
 	isPlagiarism := false
-	const plagiarismThreshold = 50.0
+	plagiarismThreshold := s.plagiarismThreshold(ctx)
 	if maxPlagiarism >= plagiarismThreshold {
 		isPlagiarism = true
 	}
@@ -112,13 +278,34 @@ func (s *AnalysisService) AnalyseTask(ctx context.Context, taskId uuid.UUID, obj
 		zap.String("task_id", taskId.String()),
 		zap.Float64("max_plagiarism", maxPlagiarism),
 		zap.Bool("is_plagiarism", isPlagiarism),
-		zap.Float64("threshold", plagiarismThreshold))
+		zap.Float64("threshold", plagiarismThreshold),
+		zap.Int("compared_files", len(matches)))
+
+	reportId, err := uuid.NewV7()
+	if err != nil {
+		s.logger.Error("failed to generate report UUID", zap.Error(err))
+		return nil, err
+	}
+
+	sources := matches
+	if len(sources) > s.cfg.MaxReportSources {
+		sources = sources[:s.cfg.MaxReportSources]
+	}
 
 	dto := &dto.CreateReportDTO{
+		Id:                   reportId,
 		TaskId:               taskId,
+		ObjectKey:            objectKey,
+		AssignmentId:         assignmentId,
+		Owner:                owner,
+		AlgorithmVersion:     s.comparator.AlgorithmVersion(),
+		Policy:               domain.ReportPolicy{Threshold: plagiarismThreshold},
 		IsPlagiarism:         isPlagiarism,
 		PlagiarismPercentage: maxPlagiarism,
+		ExcludedTaskIds:      excluded,
+		CorpusSnapshotAt:     corpusSnapshotAt,
 		CreatedAt:            time.Now(),
+		Sources:              sources,
 	}
 
 	s.logger.Debug("saving report to database", zap.String("task_id", taskId.String()))
@@ -127,18 +314,26 @@ func (s *AnalysisService) AnalyseTask(ctx context.Context, taskId uuid.UUID, obj
 		s.logger.Error("failed to create report in database",
 			zap.String("task_id", taskId.String()),
 			zap.Error(err))
-		return false, err
+		return nil, err
 	}
 
-	s.logger.Info("report saved successfully", zap.String("task_id", taskId.String()))
-	return true, nil
+	return dto, nil
 }
 
-func (s *AnalysisService) GetReport(ctx context.Context, taskId uuid.UUID) (*domain.Report, error) {
-	s.logger.Info("getting report", zap.String("task_id", taskId.String()))
+// GetReport возвращает указанную версию отчета, version = 0 - последнюю версию
+func (s *AnalysisService) GetReport(ctx context.Context, taskId uuid.UUID, version int) (*domain.Report, error) {
+	s.logger.Info("getting report",
+		zap.String("task_id", taskId.String()),
+		zap.Int("version", version))
+
+	if version < 0 {
+		s.logger.Warn("invalid report version", zap.Int("version", version))
+		return nil, fmt.Errorf("invalid report version %d: %w", version, errdefs.ErrInvalidArgument)
+	}
 
 	dto := &dto.GetReportsDTO{
-		TaskId: taskId,
+		TaskId:  taskId,
+		Version: version,
 	}
 
 	s.logger.Debug("fetching report from database", zap.String("task_id", taskId.String()))
@@ -150,6 +345,13 @@ func (s *AnalysisService) GetReport(ctx context.Context, taskId uuid.UUID) (*dom
 		return nil, err
 	}
 
+	if err := s.resolveSourceAssignments(ctx, report); err != nil {
+		s.logger.Error("failed to resolve source assignments",
+			zap.String("task_id", taskId.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
 	s.logger.Info("report retrieved",
 		zap.String("task_id", taskId.String()),
 		zap.Bool("is_plagiarism", report.IsPlagiarism),
@@ -158,6 +360,123 @@ func (s *AnalysisService) GetReport(ctx context.Context, taskId uuid.UUID) (*dom
 	return report, nil
 }
 
+// resolveSourceAssignments заполняет задания источников отчета: по ним студенту показывается,
+// из того же задания источник или нет, без раскрытия самой работы
+func (s *AnalysisService) resolveSourceAssignments(ctx context.Context, report *domain.Report) error {
+	taskIds := make([]uuid.UUID, 0, len(report.Sources))
+	for _, source := range report.Sources {
+		if source.TaskId != uuid.Nil {
+			taskIds = append(taskIds, source.TaskId)
+		}
+	}
+	if len(taskIds) == 0 {
+		return nil
+	}
+
+	assignments, err := s.repo.ListSourceAssignments(ctx, &dto.ListSourceAssignmentsDTO{TaskIds: taskIds})
+	if err != nil {
+		return err
+	}
+	for i := range report.Sources {
+		report.Sources[i].AssignmentId = assignments[report.Sources[i].TaskId]
+	}
+	return nil
+}
+
+// WatchAnalysis возвращает канал событий анализа задачи. Первым приходит текущее состояние
+// (если анализ уже идет или завершен), канал закрывается после финального события
+// или отмены контекста.
+func (s *AnalysisService) WatchAnalysis(ctx context.Context, taskId uuid.UUID) (<-chan domain.AnalysisEvent, error) {
+	s.logger.Info("watching analysis", zap.String("task_id", taskId.String()))
+
+	updates, last, unsubscribe := s.progress.Subscribe(tenant.FromContext(ctx), taskId)
+
+	if last == nil {
+		report, err := s.repo.GetReport(ctx, &dto.GetReportsDTO{TaskId: taskId})
+		switch {
+		case err == nil:
+			last = &domain.AnalysisEvent{
+				TaskId:               taskId,
+				Stage:                domain.StageDone,
+				IsPlagiarism:         report.IsPlagiarism,
+				PlagiarismPercentage: report.PlagiarismPercentage,
+				Timestamp:            report.CreatedAt,
+			}
+		case errors.Is(err, errdefs.ErrNotFound):
+			s.logger.Debug("analysis not started yet, waiting for events",
+				zap.String("task_id", taskId.String()))
+		default:
+			unsubscribe()
+			s.logger.Error("failed to get report for watch",
+				zap.String("task_id", taskId.String()),
+				zap.Error(err))
+			return nil, err
+		}
+	}
+
+	events := make(chan domain.AnalysisEvent)
+	go func() {
+		defer close(events)
+		defer unsubscribe()
+
+		if last != nil {
+			select {
+			case events <- *last:
+			case <-ctx.Done():
+				return
+			}
+			if last.IsFinal() {
+				return
+			}
+		}
+
+		for {
+			select {
+			case event := <-updates:
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+				if event.IsFinal() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func (s *AnalysisService) ListReportVersions(ctx context.Context, taskId uuid.UUID) ([]*domain.Report, error) {
+	s.logger.Info("listing report versions", zap.String("task_id", taskId.String()))
+
+	dto := &dto.ListReportVersionsDTO{
+		TaskId: taskId,
+	}
+
+	reports, err := s.repo.ListReportVersions(ctx, dto)
+	if err != nil {
+		s.logger.Error("failed to list report versions",
+			zap.String("task_id", taskId.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	if len(reports) == 0 {
+		s.logger.Warn("no reports found", zap.String("task_id", taskId.String()))
+		return nil, fmt.Errorf("no reports for task %s: %w", taskId, errdefs.ErrNotFound)
+	}
+
+	s.logger.Info("report versions retrieved",
+		zap.String("task_id", taskId.String()),
+		zap.Int("count", len(reports)))
+
+	return reports, nil
+}
+
 func (s *AnalysisService) GenerateWordCloud(ctx context.Context, fileContent []byte) (string, error) {
 	s.logger.Info("generating word cloud",
 		zap.Int("file_size", len(fileContent)))
@@ -175,10 +494,75 @@ func (s *AnalysisService) GenerateWordCloud(ctx context.Context, fileContent []b
 	return imageURL, nil
 }
 
-func filterKeys(allKeys []string, target string) []string {
+// plagiarismThreshold возвращает порог плагиата тенанта запроса
+func (s *AnalysisService) plagiarismThreshold(ctx context.Context) float64 {
+	if threshold, ok := s.cfg.TenantThresholds[tenant.FromContext(ctx)]; ok {
+		return threshold
+	}
+	return s.cfg.PlagiarismThreshold
+}
+
+// checkObjectKey проверяет, что файл принадлежит тенанту запроса: иначе в корпус сравнения
+// и в отчет попали бы работы другого тенанта
+func (s *AnalysisService) checkObjectKey(ctx context.Context, objectKey string) error {
+	tenantId := tenant.FromContext(ctx)
+	if objectKey == "" || tenant.OwnsKey(tenantId, objectKey) {
+		return nil
+	}
+
+	s.logger.Warn("object key belongs to another tenant",
+		zap.String("tenant_id", tenantId),
+		zap.String("object_key", objectKey))
+	return fmt.Errorf("object %s does not belong to tenant %s: %w", objectKey, tenantId, errdefs.ErrPermissionDenied)
+}
+
+func (s *AnalysisService) publishProgress(ctx context.Context, event domain.AnalysisEvent) {
+	event.Timestamp = time.Now()
+	s.progress.Publish(tenant.FromContext(ctx), event)
+}
+
+// taskIdFromKey извлекает идентификатор задачи из имени объекта вида <task_id><ext>
+func taskIdFromKey(key string) uuid.UUID {
+	base := path.Base(key)
+	id, err := uuid.Parse(strings.TrimSuffix(base, path.Ext(base)))
+	if err != nil {
+		return uuid.Nil
+	}
+	return id
+}
+
+// readFile читает файл задачи из хранилища целиком
+func (s *AnalysisService) readFile(ctx context.Context, objectKey string) ([]byte, error) {
+	obj, err := s.blobs.Get(ctx, objectKey)
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read %s: %v", errdefs.ErrUnavailable, objectKey, err)
+	}
+	return data, nil
+}
+
+// corpusPrefix возвращает префикс каталога, в котором лежит файл. Корпус сравнения - файлы
+// того же каталога: при схеме ключей {course}/{assignment}/{task_id}{ext} это работы того же задания.
+func corpusPrefix(objectKey string) string {
+	dir := path.Dir(objectKey)
+	if dir == "." {
+		return ""
+	}
+	return dir + "/"
+}
+
+// filterKeys оставляет в корпусе файлы каталога target, кроме самого файла и файлов исключенных задач.
+// Вложенные каталоги (quarantine/, .keys/ и каталоги других заданий) в корпус не входят.
+func filterKeys(allKeys []string, target string, excluded []uuid.UUID) []string {
+	dir := path.Dir(target)
 	filteredKeys := []string{}
 	for _, key := range allKeys {
-		if key != target {
+		if key != target && path.Dir(key) == dir && !slices.Contains(excluded, taskIdFromKey(key)) {
 			filteredKeys = append(filteredKeys, key)
 		}
 	}
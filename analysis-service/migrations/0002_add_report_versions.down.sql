@@ -0,0 +1,10 @@
+DELETE FROM reports r
+WHERE EXISTS (SELECT 1 FROM reports newer WHERE newer.task_id = r.task_id AND newer.version > r.version);
+
+ALTER TABLE reports DROP CONSTRAINT reports_task_id_version_key;
+ALTER TABLE reports DROP COLUMN object_key;
+ALTER TABLE reports DROP COLUMN version;
+
+ALTER TABLE reports DROP CONSTRAINT reports_pkey;
+ALTER TABLE reports DROP COLUMN id;
+ALTER TABLE reports ADD PRIMARY KEY (task_id);
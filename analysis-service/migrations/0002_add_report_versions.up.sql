@@ -0,0 +1,10 @@
+ALTER TABLE reports DROP CONSTRAINT reports_pkey;
+
+ALTER TABLE reports ADD COLUMN id UUID;
+UPDATE reports SET id = gen_random_uuid();
+ALTER TABLE reports ALTER COLUMN id SET NOT NULL;
+ALTER TABLE reports ADD PRIMARY KEY (id);
+
+ALTER TABLE reports ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE reports ADD COLUMN object_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE reports ADD CONSTRAINT reports_task_id_version_key UNIQUE (task_id, version);
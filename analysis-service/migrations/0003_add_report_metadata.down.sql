@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS report_sources;
+
+ALTER TABLE reports DROP COLUMN corpus_snapshot_at;
+ALTER TABLE reports DROP COLUMN policy;
+ALTER TABLE reports DROP COLUMN algorithm_version;
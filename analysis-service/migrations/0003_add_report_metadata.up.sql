@@ -0,0 +1,15 @@
+ALTER TABLE reports ADD COLUMN algorithm_version TEXT NOT NULL DEFAULT '';
+ALTER TABLE reports ADD COLUMN policy JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE reports ADD COLUMN corpus_snapshot_at TIMESTAMP;
+
+CREATE TABLE report_sources
+(
+    report_id UUID NOT NULL REFERENCES reports (id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    source_task_id UUID,
+    object_key TEXT NOT NULL,
+    similarity float NOT NULL,
+    PRIMARY KEY (report_id, position)
+);
+
+CREATE INDEX report_sources_source_task_id_idx ON report_sources (source_task_id);
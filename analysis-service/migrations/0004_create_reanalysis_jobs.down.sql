@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS reanalysis_jobs;
+
+DROP INDEX IF EXISTS reports_assignment_id_idx;
+ALTER TABLE reports DROP COLUMN IF EXISTS assignment_id;
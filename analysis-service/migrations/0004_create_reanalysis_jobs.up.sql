@@ -0,0 +1,23 @@
+ALTER TABLE reports ADD COLUMN assignment_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX reports_assignment_id_idx ON reports (assignment_id);
+
+CREATE TABLE reanalysis_jobs
+(
+    id UUID PRIMARY KEY,
+    assignment_id TEXT NOT NULL DEFAULT '',
+    created_from TIMESTAMP,
+    created_to TIMESTAMP,
+    rate_per_minute INT NOT NULL,
+    status TEXT NOT NULL,
+    total INT NOT NULL DEFAULT 0,
+    processed INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    skipped INT NOT NULL DEFAULT 0,
+    flipped_to_plagiarism INT NOT NULL DEFAULT 0,
+    flipped_to_clean INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
@@ -0,0 +1,2 @@
+-- Задача, побайтово совпадающий файл которой найден по SHA-256 при загрузке
+ALTER TABLE reports ADD COLUMN duplicate_of UUID;
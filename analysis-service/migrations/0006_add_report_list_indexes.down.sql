@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS reports_assignment_id_created_at_idx;
+DROP INDEX IF EXISTS reports_is_plagiarism_created_at_idx;
+DROP INDEX IF EXISTS reports_created_at_id_idx;
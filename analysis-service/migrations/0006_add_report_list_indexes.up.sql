@@ -0,0 +1,4 @@
+-- Список отчетов сортируется по (created_at, id), последняя версия определяется по (task_id, version)
+CREATE INDEX reports_created_at_id_idx ON reports (created_at, id);
+CREATE INDEX reports_is_plagiarism_created_at_idx ON reports (is_plagiarism, created_at, id);
+CREATE INDEX reports_assignment_id_created_at_idx ON reports (assignment_id, created_at, id);
@@ -0,0 +1,2 @@
+-- Задачи, исключенные из сравнения (предыдущие версии посылки), наследуются повторным анализом
+ALTER TABLE reports ADD COLUMN excluded_task_ids UUID[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS reports_course_id_created_at_idx;
+DROP INDEX IF EXISTS reports_uploaded_by_created_at_idx;
+ALTER TABLE reports DROP COLUMN course_id;
+ALTER TABLE reports DROP COLUMN uploaded_by;
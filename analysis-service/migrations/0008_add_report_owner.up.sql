@@ -0,0 +1,5 @@
+-- Автор и курс работы для проверки доступа к отчетам; у отчетов, созданных до миграции, автор неизвестен
+ALTER TABLE reports ADD COLUMN uploaded_by UUID;
+ALTER TABLE reports ADD COLUMN course_id TEXT NOT NULL DEFAULT '';
+CREATE INDEX reports_uploaded_by_created_at_idx ON reports (uploaded_by, created_at, id);
+CREATE INDEX reports_course_id_created_at_idx ON reports (course_id, created_at, id);
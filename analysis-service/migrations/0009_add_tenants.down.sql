@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS reports_assignment_id_idx;
+DROP INDEX IF EXISTS reports_created_at_id_idx;
+DROP INDEX IF EXISTS reports_is_plagiarism_created_at_idx;
+DROP INDEX IF EXISTS reports_assignment_id_created_at_idx;
+DROP INDEX IF EXISTS reports_uploaded_by_created_at_idx;
+DROP INDEX IF EXISTS reports_course_id_created_at_idx;
+
+CREATE INDEX reports_assignment_id_idx ON reports (assignment_id);
+CREATE INDEX reports_created_at_id_idx ON reports (created_at, id);
+CREATE INDEX reports_is_plagiarism_created_at_idx ON reports (is_plagiarism, created_at, id);
+CREATE INDEX reports_assignment_id_created_at_idx ON reports (assignment_id, created_at, id);
+CREATE INDEX reports_uploaded_by_created_at_idx ON reports (uploaded_by, created_at, id);
+CREATE INDEX reports_course_id_created_at_idx ON reports (course_id, created_at, id);
+
+ALTER TABLE reanalysis_jobs DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE reports DROP COLUMN IF EXISTS tenant_id;
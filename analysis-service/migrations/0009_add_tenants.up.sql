@@ -0,0 +1,18 @@
+-- Тенант отчета и задания повторного анализа; данные, созданные до появления тенантов, принадлежат default
+ALTER TABLE reports ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE reanalysis_jobs ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+
+-- Все запросы ограничены тенантом, поэтому он ведущая колонка индексов списка отчетов
+DROP INDEX reports_assignment_id_idx;
+DROP INDEX reports_created_at_id_idx;
+DROP INDEX reports_is_plagiarism_created_at_idx;
+DROP INDEX reports_assignment_id_created_at_idx;
+DROP INDEX reports_uploaded_by_created_at_idx;
+DROP INDEX reports_course_id_created_at_idx;
+
+CREATE INDEX reports_assignment_id_idx ON reports (tenant_id, assignment_id);
+CREATE INDEX reports_created_at_id_idx ON reports (tenant_id, created_at, id);
+CREATE INDEX reports_is_plagiarism_created_at_idx ON reports (tenant_id, is_plagiarism, created_at, id);
+CREATE INDEX reports_assignment_id_created_at_idx ON reports (tenant_id, assignment_id, created_at, id);
+CREATE INDEX reports_uploaded_by_created_at_idx ON reports (tenant_id, uploaded_by, created_at, id);
+CREATE INDEX reports_course_id_created_at_idx ON reports (tenant_id, course_id, created_at, id);
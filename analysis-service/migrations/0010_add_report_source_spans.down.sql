@@ -0,0 +1 @@
+ALTER TABLE report_sources DROP COLUMN matched_spans;
@@ -0,0 +1,3 @@
+-- Фрагменты проверяемого файла, совпавшие с источником: [{"start": 0, "end": 42}, ...] в байтах файла.
+-- У источников отчетов, созданных до миграции, фрагменты не сохранены
+ALTER TABLE report_sources ADD COLUMN matched_spans JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+ALTER TABLE reports DROP CONSTRAINT reports_tenant_id_task_id_version_key;
+ALTER TABLE reports ADD CONSTRAINT reports_task_id_version_key UNIQUE (task_id, version);
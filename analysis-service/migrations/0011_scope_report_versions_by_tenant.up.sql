@@ -0,0 +1,3 @@
+-- Версии отчетов нумеруются в пределах тенанта, как и выбираются
+ALTER TABLE reports DROP CONSTRAINT reports_task_id_version_key;
+ALTER TABLE reports ADD CONSTRAINT reports_tenant_id_task_id_version_key UNIQUE (tenant_id, task_id, version);
@@ -21,10 +21,80 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type AnalysisStage int32
+
+const (
+	AnalysisStage_ANALYSIS_STAGE_UNSPECIFIED AnalysisStage = 0
+	AnalysisStage_ANALYSIS_STAGE_EXTRACTING  AnalysisStage = 1
+	AnalysisStage_ANALYSIS_STAGE_INDEXING    AnalysisStage = 2
+	AnalysisStage_ANALYSIS_STAGE_COMPARING   AnalysisStage = 3
+	AnalysisStage_ANALYSIS_STAGE_DONE        AnalysisStage = 4
+	AnalysisStage_ANALYSIS_STAGE_FAILED      AnalysisStage = 5
+	AnalysisStage_ANALYSIS_STAGE_CANCELLED   AnalysisStage = 6
+)
+
+// Enum value maps for AnalysisStage.
+var (
+	AnalysisStage_name = map[int32]string{
+		0: "ANALYSIS_STAGE_UNSPECIFIED",
+		1: "ANALYSIS_STAGE_EXTRACTING",
+		2: "ANALYSIS_STAGE_INDEXING",
+		3: "ANALYSIS_STAGE_COMPARING",
+		4: "ANALYSIS_STAGE_DONE",
+		5: "ANALYSIS_STAGE_FAILED",
+		6: "ANALYSIS_STAGE_CANCELLED",
+	}
+	AnalysisStage_value = map[string]int32{
+		"ANALYSIS_STAGE_UNSPECIFIED": 0,
+		"ANALYSIS_STAGE_EXTRACTING":  1,
+		"ANALYSIS_STAGE_INDEXING":    2,
+		"ANALYSIS_STAGE_COMPARING":   3,
+		"ANALYSIS_STAGE_DONE":        4,
+		"ANALYSIS_STAGE_FAILED":      5,
+		"ANALYSIS_STAGE_CANCELLED":   6,
+	}
+)
+
+func (x AnalysisStage) Enum() *AnalysisStage {
+	p := new(AnalysisStage)
+	*p = x
+	return p
+}
+
+func (x AnalysisStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AnalysisStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_analysis_service_proto_enumTypes[0].Descriptor()
+}
+
+func (AnalysisStage) Type() protoreflect.EnumType {
+	return &file_analysis_service_proto_enumTypes[0]
+}
+
+func (x AnalysisStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AnalysisStage.Descriptor instead.
+func (AnalysisStage) EnumDescriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{0}
+}
+
 type AnalyzeTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	ObjectKey     string                 `protobuf:"bytes,2,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TaskId       string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	ObjectKey    string                 `protobuf:"bytes,2,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	AssignmentId string                 `protobuf:"bytes,3,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	// Задача с побайтово совпадающим файлом: отчет строится без сравнения
+	DuplicateOf          string `protobuf:"bytes,4,opt,name=duplicate_of,json=duplicateOf,proto3" json:"duplicate_of,omitempty"`
+	DuplicateOfObjectKey string `protobuf:"bytes,5,opt,name=duplicate_of_object_key,json=duplicateOfObjectKey,proto3" json:"duplicate_of_object_key,omitempty"`
+	// Задачи, не участвующие в сравнении (предыдущие версии посылки того же студента)
+	ExcludeTaskIds []string `protobuf:"bytes,6,rep,name=exclude_task_ids,json=excludeTaskIds,proto3" json:"exclude_task_ids,omitempty"`
+	// Автор и курс работы: по ним проверяется доступ к отчетам
+	UploadedBy    string `protobuf:"bytes,7,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	CourseId      string `protobuf:"bytes,8,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,6 +143,48 @@ func (x *AnalyzeTaskRequest) GetObjectKey() string {
 	return ""
 }
 
+func (x *AnalyzeTaskRequest) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *AnalyzeTaskRequest) GetDuplicateOf() string {
+	if x != nil {
+		return x.DuplicateOf
+	}
+	return ""
+}
+
+func (x *AnalyzeTaskRequest) GetDuplicateOfObjectKey() string {
+	if x != nil {
+		return x.DuplicateOfObjectKey
+	}
+	return ""
+}
+
+func (x *AnalyzeTaskRequest) GetExcludeTaskIds() []string {
+	if x != nil {
+		return x.ExcludeTaskIds
+	}
+	return nil
+}
+
+func (x *AnalyzeTaskRequest) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *AnalyzeTaskRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
 type AnalyseTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        bool                   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
@@ -118,8 +230,10 @@ func (x *AnalyseTaskResponse) GetStatus() bool {
 }
 
 type GetReportRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// 0 - последняя версия
+	Version       int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -161,13 +275,31 @@ func (x *GetReportRequest) GetTaskId() string {
 	return ""
 }
 
+func (x *GetReportRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type GetReportResponse struct {
 	state                protoimpl.MessageState `protogen:"open.v1"`
 	TaskId               string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	IsPlagiarism         bool                   `protobuf:"varint,4,opt,name=is_plagiarism,json=isPlagiarism,proto3" json:"is_plagiarism,omitempty"`
 	PlagiarismPercentage float32                `protobuf:"fixed32,5,opt,name=plagiarism_percentage,json=plagiarismPercentage,proto3" json:"plagiarism_percentage,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+	ReportId             string                 `protobuf:"bytes,6,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Version              int32                  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	AlgorithmVersion     string                 `protobuf:"bytes,8,opt,name=algorithm_version,json=algorithmVersion,proto3" json:"algorithm_version,omitempty"`
+	Policy               *ReportPolicy          `protobuf:"bytes,9,opt,name=policy,proto3" json:"policy,omitempty"`
+	CorpusSnapshotAt     string                 `protobuf:"bytes,10,opt,name=corpus_snapshot_at,json=corpusSnapshotAt,proto3" json:"corpus_snapshot_at,omitempty"`
+	CreatedAt            string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Sources              []*ReportSource        `protobuf:"bytes,12,rep,name=sources,proto3" json:"sources,omitempty"`
+	AssignmentId         string                 `protobuf:"bytes,13,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	DuplicateOf          string                 `protobuf:"bytes,14,opt,name=duplicate_of,json=duplicateOf,proto3" json:"duplicate_of,omitempty"`
+	// Отчет построен по точному совпадению файла; заполняется и тогда, когда duplicate_of скрыт
+	ExactDuplicate bool `protobuf:"varint,15,opt,name=exact_duplicate,json=exactDuplicate,proto3" json:"exact_duplicate,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetReportResponse) Reset() {
@@ -221,6 +353,252 @@ func (x *GetReportResponse) GetPlagiarismPercentage() float32 {
 	return 0
 }
 
+func (x *GetReportResponse) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *GetReportResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetReportResponse) GetAlgorithmVersion() string {
+	if x != nil {
+		return x.AlgorithmVersion
+	}
+	return ""
+}
+
+func (x *GetReportResponse) GetPolicy() *ReportPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *GetReportResponse) GetCorpusSnapshotAt() string {
+	if x != nil {
+		return x.CorpusSnapshotAt
+	}
+	return ""
+}
+
+func (x *GetReportResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *GetReportResponse) GetSources() []*ReportSource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *GetReportResponse) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *GetReportResponse) GetDuplicateOf() string {
+	if x != nil {
+		return x.DuplicateOf
+	}
+	return ""
+}
+
+func (x *GetReportResponse) GetExactDuplicate() bool {
+	if x != nil {
+		return x.ExactDuplicate
+	}
+	return false
+}
+
+type ReportPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Threshold     float32                `protobuf:"fixed32,1,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportPolicy) Reset() {
+	*x = ReportPolicy{}
+	mi := &file_analysis_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportPolicy) ProtoMessage() {}
+
+func (x *ReportPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportPolicy.ProtoReflect.Descriptor instead.
+func (*ReportPolicy) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReportPolicy) GetThreshold() float32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+type ReportSource struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TaskId     string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	ObjectKey  string                 `protobuf:"bytes,2,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	Similarity float32                `protobuf:"fixed32,3,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	// Задание последней версии отчета задачи-источника; пусто, если источник не проверялся
+	// или удален. Студенту источник обезличивается: task_id и object_key не передаются.
+	AssignmentId string `protobuf:"bytes,4,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	// Фрагменты проверяемого файла, найденные в источнике; передаются и студенту, так как это его текст
+	MatchedSpans  []*MatchedSpan `protobuf:"bytes,5,rep,name=matched_spans,json=matchedSpans,proto3" json:"matched_spans,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportSource) Reset() {
+	*x = ReportSource{}
+	mi := &file_analysis_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportSource) ProtoMessage() {}
+
+func (x *ReportSource) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportSource.ProtoReflect.Descriptor instead.
+func (*ReportSource) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReportSource) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ReportSource) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *ReportSource) GetSimilarity() float32 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+func (x *ReportSource) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *ReportSource) GetMatchedSpans() []*MatchedSpan {
+	if x != nil {
+		return x.MatchedSpans
+	}
+	return nil
+}
+
+// MatchedSpan - фрагмент проверяемого файла: байты [start, end) исходного файла
+type MatchedSpan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         int64                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           int64                  `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchedSpan) Reset() {
+	*x = MatchedSpan{}
+	mi := &file_analysis_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchedSpan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchedSpan) ProtoMessage() {}
+
+func (x *MatchedSpan) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchedSpan.ProtoReflect.Descriptor instead.
+func (*MatchedSpan) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MatchedSpan) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *MatchedSpan) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
 type GenerateWordCloudRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
@@ -230,7 +608,7 @@ type GenerateWordCloudRequest struct {
 
 func (x *GenerateWordCloudRequest) Reset() {
 	*x = GenerateWordCloudRequest{}
-	mi := &file_analysis_service_proto_msgTypes[4]
+	mi := &file_analysis_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -242,7 +620,7 @@ func (x *GenerateWordCloudRequest) String() string {
 func (*GenerateWordCloudRequest) ProtoMessage() {}
 
 func (x *GenerateWordCloudRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_service_proto_msgTypes[4]
+	mi := &file_analysis_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -255,7 +633,7 @@ func (x *GenerateWordCloudRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateWordCloudRequest.ProtoReflect.Descriptor instead.
 func (*GenerateWordCloudRequest) Descriptor() ([]byte, []int) {
-	return file_analysis_service_proto_rawDescGZIP(), []int{4}
+	return file_analysis_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GenerateWordCloudRequest) GetFileContent() []byte {
@@ -274,7 +652,7 @@ type GenerateWordCloudResponse struct {
 
 func (x *GenerateWordCloudResponse) Reset() {
 	*x = GenerateWordCloudResponse{}
-	mi := &file_analysis_service_proto_msgTypes[5]
+	mi := &file_analysis_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -286,7 +664,7 @@ func (x *GenerateWordCloudResponse) String() string {
 func (*GenerateWordCloudResponse) ProtoMessage() {}
 
 func (x *GenerateWordCloudResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_analysis_service_proto_msgTypes[5]
+	mi := &file_analysis_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -299,7 +677,7 @@ func (x *GenerateWordCloudResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateWordCloudResponse.ProtoReflect.Descriptor instead.
 func (*GenerateWordCloudResponse) Descriptor() ([]byte, []int) {
-	return file_analysis_service_proto_rawDescGZIP(), []int{5}
+	return file_analysis_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GenerateWordCloudResponse) GetImageUrl() string {
@@ -309,31 +687,1744 @@ func (x *GenerateWordCloudResponse) GetImageUrl() string {
 	return ""
 }
 
-var File_analysis_service_proto protoreflect.FileDescriptor
+type WatchAnalysisRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_analysis_service_proto_rawDesc = "" +
-	"\n" +
-	"\x16analysis_service.proto\x12\vanalysis.v1\"L\n" +
-	"\x12AnalyzeTaskRequest\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1d\n" +
-	"\n" +
-	"object_key\x18\x02 \x01(\tR\tobjectKey\"-\n" +
-	"\x13AnalyseTaskResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\bR\x06status\"+\n" +
-	"\x10GetReportRequest\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\x86\x01\n" +
-	"\x11GetReportResponse\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12#\n" +
+func (x *WatchAnalysisRequest) Reset() {
+	*x = WatchAnalysisRequest{}
+	mi := &file_analysis_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAnalysisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAnalysisRequest) ProtoMessage() {}
+
+func (x *WatchAnalysisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAnalysisRequest.ProtoReflect.Descriptor instead.
+func (*WatchAnalysisRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchAnalysisRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type AnalysisEvent struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	TaskId               string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Stage                AnalysisStage          `protobuf:"varint,2,opt,name=stage,proto3,enum=analysis.v1.AnalysisStage" json:"stage,omitempty"`
+	Compared             int32                  `protobuf:"varint,3,opt,name=compared,proto3" json:"compared,omitempty"`
+	Total                int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	IsPlagiarism         bool                   `protobuf:"varint,5,opt,name=is_plagiarism,json=isPlagiarism,proto3" json:"is_plagiarism,omitempty"`
+	PlagiarismPercentage float32                `protobuf:"fixed32,6,opt,name=plagiarism_percentage,json=plagiarismPercentage,proto3" json:"plagiarism_percentage,omitempty"`
+	Message              string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	Timestamp            string                 `protobuf:"bytes,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *AnalysisEvent) Reset() {
+	*x = AnalysisEvent{}
+	mi := &file_analysis_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalysisEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalysisEvent) ProtoMessage() {}
+
+func (x *AnalysisEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalysisEvent.ProtoReflect.Descriptor instead.
+func (*AnalysisEvent) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AnalysisEvent) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *AnalysisEvent) GetStage() AnalysisStage {
+	if x != nil {
+		return x.Stage
+	}
+	return AnalysisStage_ANALYSIS_STAGE_UNSPECIFIED
+}
+
+func (x *AnalysisEvent) GetCompared() int32 {
+	if x != nil {
+		return x.Compared
+	}
+	return 0
+}
+
+func (x *AnalysisEvent) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *AnalysisEvent) GetIsPlagiarism() bool {
+	if x != nil {
+		return x.IsPlagiarism
+	}
+	return false
+}
+
+func (x *AnalysisEvent) GetPlagiarismPercentage() float32 {
+	if x != nil {
+		return x.PlagiarismPercentage
+	}
+	return 0
+}
+
+func (x *AnalysisEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AnalysisEvent) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+type CancelAnalysisRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAnalysisRequest) Reset() {
+	*x = CancelAnalysisRequest{}
+	mi := &file_analysis_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAnalysisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAnalysisRequest) ProtoMessage() {}
+
+func (x *CancelAnalysisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAnalysisRequest.ProtoReflect.Descriptor instead.
+func (*CancelAnalysisRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelAnalysisRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type CancelAnalysisResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        bool                   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAnalysisResponse) Reset() {
+	*x = CancelAnalysisResponse{}
+	mi := &file_analysis_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAnalysisResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAnalysisResponse) ProtoMessage() {}
+
+func (x *CancelAnalysisResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAnalysisResponse.ProtoReflect.Descriptor instead.
+func (*CancelAnalysisResponse) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelAnalysisResponse) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+type ReanalyseTaskRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	TaskId    string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	ObjectKey string                 `protobuf:"bytes,2,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	// Если не заданы, автор и курс берутся из последней версии отчета
+	UploadedBy string `protobuf:"bytes,3,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	CourseId   string `protobuf:"bytes,4,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	// Задачи, не участвующие в сравнении; если не заданы, берутся из последней версии отчета
+	ExcludeTaskIds []string `protobuf:"bytes,5,rep,name=exclude_task_ids,json=excludeTaskIds,proto3" json:"exclude_task_ids,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReanalyseTaskRequest) Reset() {
+	*x = ReanalyseTaskRequest{}
+	mi := &file_analysis_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReanalyseTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReanalyseTaskRequest) ProtoMessage() {}
+
+func (x *ReanalyseTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReanalyseTaskRequest.ProtoReflect.Descriptor instead.
+func (*ReanalyseTaskRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReanalyseTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ReanalyseTaskRequest) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *ReanalyseTaskRequest) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *ReanalyseTaskRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *ReanalyseTaskRequest) GetExcludeTaskIds() []string {
+	if x != nil {
+		return x.ExcludeTaskIds
+	}
+	return nil
+}
+
+type ReanalyseTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        bool                   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReanalyseTaskResponse) Reset() {
+	*x = ReanalyseTaskResponse{}
+	mi := &file_analysis_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReanalyseTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReanalyseTaskResponse) ProtoMessage() {}
+
+func (x *ReanalyseTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReanalyseTaskResponse.ProtoReflect.Descriptor instead.
+func (*ReanalyseTaskResponse) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReanalyseTaskResponse) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+type ListReportVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReportVersionsRequest) Reset() {
+	*x = ListReportVersionsRequest{}
+	mi := &file_analysis_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReportVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReportVersionsRequest) ProtoMessage() {}
+
+func (x *ListReportVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReportVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListReportVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListReportVersionsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type ReportVersion struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ReportId             string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Version              int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	AlgorithmVersion     string                 `protobuf:"bytes,3,opt,name=algorithm_version,json=algorithmVersion,proto3" json:"algorithm_version,omitempty"`
+	Policy               *ReportPolicy          `protobuf:"bytes,4,opt,name=policy,proto3" json:"policy,omitempty"`
+	IsPlagiarism         bool                   `protobuf:"varint,5,opt,name=is_plagiarism,json=isPlagiarism,proto3" json:"is_plagiarism,omitempty"`
+	PlagiarismPercentage float32                `protobuf:"fixed32,6,opt,name=plagiarism_percentage,json=plagiarismPercentage,proto3" json:"plagiarism_percentage,omitempty"`
+	CorpusSnapshotAt     string                 `protobuf:"bytes,7,opt,name=corpus_snapshot_at,json=corpusSnapshotAt,proto3" json:"corpus_snapshot_at,omitempty"`
+	CreatedAt            string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DuplicateOf          string                 `protobuf:"bytes,9,opt,name=duplicate_of,json=duplicateOf,proto3" json:"duplicate_of,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ReportVersion) Reset() {
+	*x = ReportVersion{}
+	mi := &file_analysis_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportVersion) ProtoMessage() {}
+
+func (x *ReportVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportVersion.ProtoReflect.Descriptor instead.
+func (*ReportVersion) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReportVersion) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *ReportVersion) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ReportVersion) GetAlgorithmVersion() string {
+	if x != nil {
+		return x.AlgorithmVersion
+	}
+	return ""
+}
+
+func (x *ReportVersion) GetPolicy() *ReportPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *ReportVersion) GetIsPlagiarism() bool {
+	if x != nil {
+		return x.IsPlagiarism
+	}
+	return false
+}
+
+func (x *ReportVersion) GetPlagiarismPercentage() float32 {
+	if x != nil {
+		return x.PlagiarismPercentage
+	}
+	return 0
+}
+
+func (x *ReportVersion) GetCorpusSnapshotAt() string {
+	if x != nil {
+		return x.CorpusSnapshotAt
+	}
+	return ""
+}
+
+func (x *ReportVersion) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ReportVersion) GetDuplicateOf() string {
+	if x != nil {
+		return x.DuplicateOf
+	}
+	return ""
+}
+
+type ListReportVersionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Versions      []*ReportVersion       `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReportVersionsResponse) Reset() {
+	*x = ListReportVersionsResponse{}
+	mi := &file_analysis_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReportVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReportVersionsResponse) ProtoMessage() {}
+
+func (x *ListReportVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReportVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListReportVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListReportVersionsResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ListReportVersionsResponse) GetVersions() []*ReportVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// Отбор идет по последней версии отчета каждой задачи
+type ListReportsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пустые поля не ограничивают выборку
+	AssignmentId  string   `protobuf:"bytes,1,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	IsPlagiarism  *bool    `protobuf:"varint,2,opt,name=is_plagiarism,json=isPlagiarism,proto3,oneof" json:"is_plagiarism,omitempty"`
+	MinPercentage *float32 `protobuf:"fixed32,3,opt,name=min_percentage,json=minPercentage,proto3,oneof" json:"min_percentage,omitempty"`
+	MaxPercentage *float32 `protobuf:"fixed32,4,opt,name=max_percentage,json=maxPercentage,proto3,oneof" json:"max_percentage,omitempty"`
+	// RFC3339 по дате отчета, created_from включительно, created_to не включительно
+	CreatedFrom string `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   string `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	// 0 - 50, не больше 200
+	Limit int32 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor из предыдущей страницы
+	Cursor        string `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReportsRequest) Reset() {
+	*x = ListReportsRequest{}
+	mi := &file_analysis_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReportsRequest) ProtoMessage() {}
+
+func (x *ListReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListReportsRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListReportsRequest) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *ListReportsRequest) GetIsPlagiarism() bool {
+	if x != nil && x.IsPlagiarism != nil {
+		return *x.IsPlagiarism
+	}
+	return false
+}
+
+func (x *ListReportsRequest) GetMinPercentage() float32 {
+	if x != nil && x.MinPercentage != nil {
+		return *x.MinPercentage
+	}
+	return 0
+}
+
+func (x *ListReportsRequest) GetMaxPercentage() float32 {
+	if x != nil && x.MaxPercentage != nil {
+		return *x.MaxPercentage
+	}
+	return 0
+}
+
+func (x *ListReportsRequest) GetCreatedFrom() string {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return ""
+}
+
+func (x *ListReportsRequest) GetCreatedTo() string {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return ""
+}
+
+func (x *ListReportsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListReportsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ReportSummary struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	TaskId               string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	ReportId             string                 `protobuf:"bytes,2,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Version              int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	AssignmentId         string                 `protobuf:"bytes,4,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	IsPlagiarism         bool                   `protobuf:"varint,5,opt,name=is_plagiarism,json=isPlagiarism,proto3" json:"is_plagiarism,omitempty"`
+	PlagiarismPercentage float32                `protobuf:"fixed32,6,opt,name=plagiarism_percentage,json=plagiarismPercentage,proto3" json:"plagiarism_percentage,omitempty"`
+	DuplicateOf          string                 `protobuf:"bytes,7,opt,name=duplicate_of,json=duplicateOf,proto3" json:"duplicate_of,omitempty"`
+	CreatedAt            string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ReportSummary) Reset() {
+	*x = ReportSummary{}
+	mi := &file_analysis_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportSummary) ProtoMessage() {}
+
+func (x *ReportSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportSummary.ProtoReflect.Descriptor instead.
+func (*ReportSummary) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReportSummary) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ReportSummary) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *ReportSummary) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ReportSummary) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *ReportSummary) GetIsPlagiarism() bool {
+	if x != nil {
+		return x.IsPlagiarism
+	}
+	return false
+}
+
+func (x *ReportSummary) GetPlagiarismPercentage() float32 {
+	if x != nil {
+		return x.PlagiarismPercentage
+	}
+	return 0
+}
+
+func (x *ReportSummary) GetDuplicateOf() string {
+	if x != nil {
+		return x.DuplicateOf
+	}
+	return ""
+}
+
+func (x *ReportSummary) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListReportsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Reports []*ReportSummary       `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	// Пустой, если страница последняя
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReportsResponse) Reset() {
+	*x = ListReportsResponse{}
+	mi := &file_analysis_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReportsResponse) ProtoMessage() {}
+
+func (x *ListReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReportsResponse.ProtoReflect.Descriptor instead.
+func (*ListReportsResponse) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListReportsResponse) GetReports() []*ReportSummary {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+func (x *ListReportsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Удаляет все данные анализа удаленной задачи; повторный вызов безопасен
+type PurgeTaskRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Не удалять задачу из источников чужих отчетов, а обезличить: убрать task_id и object_key,
+	// сохранив процент схожести
+	AnonymiseReferences bool `protobuf:"varint,2,opt,name=anonymise_references,json=anonymiseReferences,proto3" json:"anonymise_references,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PurgeTaskRequest) Reset() {
+	*x = PurgeTaskRequest{}
+	mi := &file_analysis_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeTaskRequest) ProtoMessage() {}
+
+func (x *PurgeTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeTaskRequest.ProtoReflect.Descriptor instead.
+func (*PurgeTaskRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PurgeTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *PurgeTaskRequest) GetAnonymiseReferences() bool {
+	if x != nil {
+		return x.AnonymiseReferences
+	}
+	return false
+}
+
+type PurgeTaskResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReportsDeleted int32                  `protobuf:"varint,1,opt,name=reports_deleted,json=reportsDeleted,proto3" json:"reports_deleted,omitempty"`
+	// Сколько ссылок на задачу удалено из источников и duplicate_of чужих отчетов
+	ReferencesRemoved int32 `protobuf:"varint,2,opt,name=references_removed,json=referencesRemoved,proto3" json:"references_removed,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PurgeTaskResponse) Reset() {
+	*x = PurgeTaskResponse{}
+	mi := &file_analysis_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeTaskResponse) ProtoMessage() {}
+
+func (x *PurgeTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeTaskResponse.ProtoReflect.Descriptor instead.
+func (*PurgeTaskResponse) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PurgeTaskResponse) GetReportsDeleted() int32 {
+	if x != nil {
+		return x.ReportsDeleted
+	}
+	return 0
+}
+
+func (x *PurgeTaskResponse) GetReferencesRemoved() int32 {
+	if x != nil {
+		return x.ReferencesRemoved
+	}
+	return 0
+}
+
+// Совпадения, в которых задачи из task_ids найдены источниками в отчетах других задач.
+// Отчеты других задач не раскрываются: возвращаются только процент схожести, вердикт и дата.
+type ListSourceMatchesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskIds       []string               `protobuf:"bytes,1,rep,name=task_ids,json=taskIds,proto3" json:"task_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSourceMatchesRequest) Reset() {
+	*x = ListSourceMatchesRequest{}
+	mi := &file_analysis_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSourceMatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSourceMatchesRequest) ProtoMessage() {}
+
+func (x *ListSourceMatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSourceMatchesRequest.ProtoReflect.Descriptor instead.
+func (*ListSourceMatchesRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListSourceMatchesRequest) GetTaskIds() []string {
+	if x != nil {
+		return x.TaskIds
+	}
+	return nil
+}
+
+type SourceMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourceTaskId  string                 `protobuf:"bytes,1,opt,name=source_task_id,json=sourceTaskId,proto3" json:"source_task_id,omitempty"`
+	Similarity    float32                `protobuf:"fixed32,2,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	IsPlagiarism  bool                   `protobuf:"varint,3,opt,name=is_plagiarism,json=isPlagiarism,proto3" json:"is_plagiarism,omitempty"`
+	MatchedAt     string                 `protobuf:"bytes,4,opt,name=matched_at,json=matchedAt,proto3" json:"matched_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SourceMatch) Reset() {
+	*x = SourceMatch{}
+	mi := &file_analysis_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SourceMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceMatch) ProtoMessage() {}
+
+func (x *SourceMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceMatch.ProtoReflect.Descriptor instead.
+func (*SourceMatch) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SourceMatch) GetSourceTaskId() string {
+	if x != nil {
+		return x.SourceTaskId
+	}
+	return ""
+}
+
+func (x *SourceMatch) GetSimilarity() float32 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+func (x *SourceMatch) GetIsPlagiarism() bool {
+	if x != nil {
+		return x.IsPlagiarism
+	}
+	return false
+}
+
+func (x *SourceMatch) GetMatchedAt() string {
+	if x != nil {
+		return x.MatchedAt
+	}
+	return ""
+}
+
+type ListSourceMatchesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*SourceMatch         `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSourceMatchesResponse) Reset() {
+	*x = ListSourceMatchesResponse{}
+	mi := &file_analysis_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSourceMatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSourceMatchesResponse) ProtoMessage() {}
+
+func (x *ListSourceMatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSourceMatchesResponse.ProtoReflect.Descriptor instead.
+func (*ListSourceMatchesResponse) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListSourceMatchesResponse) GetMatches() []*SourceMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type StartBulkReanalysisRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пустые поля - без ограничения, пустой запрос - весь корпус
+	AssignmentId string `protobuf:"bytes,1,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	// RFC3339, отбор по дате первого анализа задачи
+	CreatedFrom string `protobuf:"bytes,2,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   string `protobuf:"bytes,3,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	// 0 - значение из конфигурации сервиса
+	RatePerMinute int32 `protobuf:"varint,4,opt,name=rate_per_minute,json=ratePerMinute,proto3" json:"rate_per_minute,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartBulkReanalysisRequest) Reset() {
+	*x = StartBulkReanalysisRequest{}
+	mi := &file_analysis_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartBulkReanalysisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartBulkReanalysisRequest) ProtoMessage() {}
+
+func (x *StartBulkReanalysisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartBulkReanalysisRequest.ProtoReflect.Descriptor instead.
+func (*StartBulkReanalysisRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *StartBulkReanalysisRequest) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *StartBulkReanalysisRequest) GetCreatedFrom() string {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return ""
+}
+
+func (x *StartBulkReanalysisRequest) GetCreatedTo() string {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return ""
+}
+
+func (x *StartBulkReanalysisRequest) GetRatePerMinute() int32 {
+	if x != nil {
+		return x.RatePerMinute
+	}
+	return 0
+}
+
+type StartBulkReanalysisResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *BulkReanalysisJob     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartBulkReanalysisResponse) Reset() {
+	*x = StartBulkReanalysisResponse{}
+	mi := &file_analysis_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartBulkReanalysisResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartBulkReanalysisResponse) ProtoMessage() {}
+
+func (x *StartBulkReanalysisResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartBulkReanalysisResponse.ProtoReflect.Descriptor instead.
+func (*StartBulkReanalysisResponse) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *StartBulkReanalysisResponse) GetJob() *BulkReanalysisJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type GetBulkReanalysisRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBulkReanalysisRequest) Reset() {
+	*x = GetBulkReanalysisRequest{}
+	mi := &file_analysis_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBulkReanalysisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBulkReanalysisRequest) ProtoMessage() {}
+
+func (x *GetBulkReanalysisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBulkReanalysisRequest.ProtoReflect.Descriptor instead.
+func (*GetBulkReanalysisRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetBulkReanalysisRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type GetBulkReanalysisResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *BulkReanalysisJob     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBulkReanalysisResponse) Reset() {
+	*x = GetBulkReanalysisResponse{}
+	mi := &file_analysis_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBulkReanalysisResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBulkReanalysisResponse) ProtoMessage() {}
+
+func (x *GetBulkReanalysisResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBulkReanalysisResponse.ProtoReflect.Descriptor instead.
+func (*GetBulkReanalysisResponse) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetBulkReanalysisResponse) GetJob() *BulkReanalysisJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type CancelBulkReanalysisRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBulkReanalysisRequest) Reset() {
+	*x = CancelBulkReanalysisRequest{}
+	mi := &file_analysis_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBulkReanalysisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBulkReanalysisRequest) ProtoMessage() {}
+
+func (x *CancelBulkReanalysisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBulkReanalysisRequest.ProtoReflect.Descriptor instead.
+func (*CancelBulkReanalysisRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CancelBulkReanalysisRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type CancelBulkReanalysisResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        bool                   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBulkReanalysisResponse) Reset() {
+	*x = CancelBulkReanalysisResponse{}
+	mi := &file_analysis_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBulkReanalysisResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBulkReanalysisResponse) ProtoMessage() {}
+
+func (x *CancelBulkReanalysisResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBulkReanalysisResponse.ProtoReflect.Descriptor instead.
+func (*CancelBulkReanalysisResponse) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CancelBulkReanalysisResponse) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+type BulkReanalysisJob struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	JobId               string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	AssignmentId        string                 `protobuf:"bytes,2,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	CreatedFrom         string                 `protobuf:"bytes,3,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo           string                 `protobuf:"bytes,4,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	RatePerMinute       int32                  `protobuf:"varint,5,opt,name=rate_per_minute,json=ratePerMinute,proto3" json:"rate_per_minute,omitempty"`
+	Status              string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Total               int32                  `protobuf:"varint,7,opt,name=total,proto3" json:"total,omitempty"`
+	Processed           int32                  `protobuf:"varint,8,opt,name=processed,proto3" json:"processed,omitempty"`
+	Failed              int32                  `protobuf:"varint,9,opt,name=failed,proto3" json:"failed,omitempty"`
+	Skipped             int32                  `protobuf:"varint,10,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	FlippedToPlagiarism int32                  `protobuf:"varint,11,opt,name=flipped_to_plagiarism,json=flippedToPlagiarism,proto3" json:"flipped_to_plagiarism,omitempty"`
+	FlippedToClean      int32                  `protobuf:"varint,12,opt,name=flipped_to_clean,json=flippedToClean,proto3" json:"flipped_to_clean,omitempty"`
+	Error               string                 `protobuf:"bytes,13,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt           string                 `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt           string                 `protobuf:"bytes,15,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt          string                 `protobuf:"bytes,16,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BulkReanalysisJob) Reset() {
+	*x = BulkReanalysisJob{}
+	mi := &file_analysis_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkReanalysisJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkReanalysisJob) ProtoMessage() {}
+
+func (x *BulkReanalysisJob) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkReanalysisJob.ProtoReflect.Descriptor instead.
+func (*BulkReanalysisJob) Descriptor() ([]byte, []int) {
+	return file_analysis_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BulkReanalysisJob) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *BulkReanalysisJob) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *BulkReanalysisJob) GetCreatedFrom() string {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return ""
+}
+
+func (x *BulkReanalysisJob) GetCreatedTo() string {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return ""
+}
+
+func (x *BulkReanalysisJob) GetRatePerMinute() int32 {
+	if x != nil {
+		return x.RatePerMinute
+	}
+	return 0
+}
+
+func (x *BulkReanalysisJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BulkReanalysisJob) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BulkReanalysisJob) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *BulkReanalysisJob) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BulkReanalysisJob) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *BulkReanalysisJob) GetFlippedToPlagiarism() int32 {
+	if x != nil {
+		return x.FlippedToPlagiarism
+	}
+	return 0
+}
+
+func (x *BulkReanalysisJob) GetFlippedToClean() int32 {
+	if x != nil {
+		return x.FlippedToClean
+	}
+	return 0
+}
+
+func (x *BulkReanalysisJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BulkReanalysisJob) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *BulkReanalysisJob) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *BulkReanalysisJob) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+var File_analysis_service_proto protoreflect.FileDescriptor
+
+const file_analysis_service_proto_rawDesc = "" +
+	"\n" +
+	"\x16analysis_service.proto\x12\vanalysis.v1\"\xb3\x02\n" +
+	"\x12AnalyzeTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x02 \x01(\tR\tobjectKey\x12#\n" +
+	"\rassignment_id\x18\x03 \x01(\tR\fassignmentId\x12!\n" +
+	"\fduplicate_of\x18\x04 \x01(\tR\vduplicateOf\x125\n" +
+	"\x17duplicate_of_object_key\x18\x05 \x01(\tR\x14duplicateOfObjectKey\x12(\n" +
+	"\x10exclude_task_ids\x18\x06 \x03(\tR\x0eexcludeTaskIds\x12\x1f\n" +
+	"\vuploaded_by\x18\a \x01(\tR\n" +
+	"uploadedBy\x12\x1b\n" +
+	"\tcourse_id\x18\b \x01(\tR\bcourseId\"-\n" +
+	"\x13AnalyseTaskResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\bR\x06status\"E\n" +
+	"\x10GetReportRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\x90\x04\n" +
+	"\x11GetReportResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12#\n" +
 	"\ris_plagiarism\x18\x04 \x01(\bR\fisPlagiarism\x123\n" +
-	"\x15plagiarism_percentage\x18\x05 \x01(\x02R\x14plagiarismPercentage\"=\n" +
+	"\x15plagiarism_percentage\x18\x05 \x01(\x02R\x14plagiarismPercentage\x12\x1b\n" +
+	"\treport_id\x18\x06 \x01(\tR\breportId\x12\x18\n" +
+	"\aversion\x18\a \x01(\x05R\aversion\x12+\n" +
+	"\x11algorithm_version\x18\b \x01(\tR\x10algorithmVersion\x121\n" +
+	"\x06policy\x18\t \x01(\v2\x19.analysis.v1.ReportPolicyR\x06policy\x12,\n" +
+	"\x12corpus_snapshot_at\x18\n" +
+	" \x01(\tR\x10corpusSnapshotAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\x123\n" +
+	"\asources\x18\f \x03(\v2\x19.analysis.v1.ReportSourceR\asources\x12#\n" +
+	"\rassignment_id\x18\r \x01(\tR\fassignmentId\x12!\n" +
+	"\fduplicate_of\x18\x0e \x01(\tR\vduplicateOf\x12'\n" +
+	"\x0fexact_duplicate\x18\x0f \x01(\bR\x0eexactDuplicate\",\n" +
+	"\fReportPolicy\x12\x1c\n" +
+	"\tthreshold\x18\x01 \x01(\x02R\tthreshold\"\xca\x01\n" +
+	"\fReportSource\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x02 \x01(\tR\tobjectKey\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x03 \x01(\x02R\n" +
+	"similarity\x12#\n" +
+	"\rassignment_id\x18\x04 \x01(\tR\fassignmentId\x12=\n" +
+	"\rmatched_spans\x18\x05 \x03(\v2\x18.analysis.v1.MatchedSpanR\fmatchedSpans\"5\n" +
+	"\vMatchedSpan\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x03R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x03R\x03end\"=\n" +
 	"\x18GenerateWordCloudRequest\x12!\n" +
 	"\ffile_content\x18\x01 \x01(\fR\vfileContent\"8\n" +
 	"\x19GenerateWordCloudResponse\x12\x1b\n" +
-	"\timage_url\x18\x01 \x01(\tR\bimageUrl2\x93\x02\n" +
+	"\timage_url\x18\x01 \x01(\tR\bimageUrl\"/\n" +
+	"\x14WatchAnalysisRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\x9e\x02\n" +
+	"\rAnalysisEvent\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x120\n" +
+	"\x05stage\x18\x02 \x01(\x0e2\x1a.analysis.v1.AnalysisStageR\x05stage\x12\x1a\n" +
+	"\bcompared\x18\x03 \x01(\x05R\bcompared\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12#\n" +
+	"\ris_plagiarism\x18\x05 \x01(\bR\fisPlagiarism\x123\n" +
+	"\x15plagiarism_percentage\x18\x06 \x01(\x02R\x14plagiarismPercentage\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\tR\ttimestamp\"0\n" +
+	"\x15CancelAnalysisRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"0\n" +
+	"\x16CancelAnalysisResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\bR\x06status\"\xb6\x01\n" +
+	"\x14ReanalyseTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x02 \x01(\tR\tobjectKey\x12\x1f\n" +
+	"\vuploaded_by\x18\x03 \x01(\tR\n" +
+	"uploadedBy\x12\x1b\n" +
+	"\tcourse_id\x18\x04 \x01(\tR\bcourseId\x12(\n" +
+	"\x10exclude_task_ids\x18\x05 \x03(\tR\x0eexcludeTaskIds\"/\n" +
+	"\x15ReanalyseTaskResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\bR\x06status\"4\n" +
+	"\x19ListReportVersionsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\xf0\x02\n" +
+	"\rReportVersion\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12+\n" +
+	"\x11algorithm_version\x18\x03 \x01(\tR\x10algorithmVersion\x121\n" +
+	"\x06policy\x18\x04 \x01(\v2\x19.analysis.v1.ReportPolicyR\x06policy\x12#\n" +
+	"\ris_plagiarism\x18\x05 \x01(\bR\fisPlagiarism\x123\n" +
+	"\x15plagiarism_percentage\x18\x06 \x01(\x02R\x14plagiarismPercentage\x12,\n" +
+	"\x12corpus_snapshot_at\x18\a \x01(\tR\x10corpusSnapshotAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12!\n" +
+	"\fduplicate_of\x18\t \x01(\tR\vduplicateOf\"m\n" +
+	"\x1aListReportVersionsResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x126\n" +
+	"\bversions\x18\x02 \x03(\v2\x1a.analysis.v1.ReportVersionR\bversions\"\xe3\x02\n" +
+	"\x12ListReportsRequest\x12#\n" +
+	"\rassignment_id\x18\x01 \x01(\tR\fassignmentId\x12(\n" +
+	"\ris_plagiarism\x18\x02 \x01(\bH\x00R\fisPlagiarism\x88\x01\x01\x12*\n" +
+	"\x0emin_percentage\x18\x03 \x01(\x02H\x01R\rminPercentage\x88\x01\x01\x12*\n" +
+	"\x0emax_percentage\x18\x04 \x01(\x02H\x02R\rmaxPercentage\x88\x01\x01\x12!\n" +
+	"\fcreated_from\x18\x05 \x01(\tR\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\tR\tcreatedTo\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\b \x01(\tR\x06cursorB\x10\n" +
+	"\x0e_is_plagiarismB\x11\n" +
+	"\x0f_min_percentageB\x11\n" +
+	"\x0f_max_percentage\"\xa0\x02\n" +
+	"\rReportSummary\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1b\n" +
+	"\treport_id\x18\x02 \x01(\tR\breportId\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12#\n" +
+	"\rassignment_id\x18\x04 \x01(\tR\fassignmentId\x12#\n" +
+	"\ris_plagiarism\x18\x05 \x01(\bR\fisPlagiarism\x123\n" +
+	"\x15plagiarism_percentage\x18\x06 \x01(\x02R\x14plagiarismPercentage\x12!\n" +
+	"\fduplicate_of\x18\a \x01(\tR\vduplicateOf\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\"l\n" +
+	"\x13ListReportsResponse\x124\n" +
+	"\areports\x18\x01 \x03(\v2\x1a.analysis.v1.ReportSummaryR\areports\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"^\n" +
+	"\x10PurgeTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x121\n" +
+	"\x14anonymise_references\x18\x02 \x01(\bR\x13anonymiseReferences\"k\n" +
+	"\x11PurgeTaskResponse\x12'\n" +
+	"\x0freports_deleted\x18\x01 \x01(\x05R\x0ereportsDeleted\x12-\n" +
+	"\x12references_removed\x18\x02 \x01(\x05R\x11referencesRemoved\"5\n" +
+	"\x18ListSourceMatchesRequest\x12\x19\n" +
+	"\btask_ids\x18\x01 \x03(\tR\ataskIds\"\x97\x01\n" +
+	"\vSourceMatch\x12$\n" +
+	"\x0esource_task_id\x18\x01 \x01(\tR\fsourceTaskId\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x02 \x01(\x02R\n" +
+	"similarity\x12#\n" +
+	"\ris_plagiarism\x18\x03 \x01(\bR\fisPlagiarism\x12\x1d\n" +
+	"\n" +
+	"matched_at\x18\x04 \x01(\tR\tmatchedAt\"O\n" +
+	"\x19ListSourceMatchesResponse\x122\n" +
+	"\amatches\x18\x01 \x03(\v2\x18.analysis.v1.SourceMatchR\amatches\"\xab\x01\n" +
+	"\x1aStartBulkReanalysisRequest\x12#\n" +
+	"\rassignment_id\x18\x01 \x01(\tR\fassignmentId\x12!\n" +
+	"\fcreated_from\x18\x02 \x01(\tR\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\x03 \x01(\tR\tcreatedTo\x12&\n" +
+	"\x0frate_per_minute\x18\x04 \x01(\x05R\rratePerMinute\"O\n" +
+	"\x1bStartBulkReanalysisResponse\x120\n" +
+	"\x03job\x18\x01 \x01(\v2\x1e.analysis.v1.BulkReanalysisJobR\x03job\"1\n" +
+	"\x18GetBulkReanalysisRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"M\n" +
+	"\x19GetBulkReanalysisResponse\x120\n" +
+	"\x03job\x18\x01 \x01(\v2\x1e.analysis.v1.BulkReanalysisJobR\x03job\"4\n" +
+	"\x1bCancelBulkReanalysisRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"6\n" +
+	"\x1cCancelBulkReanalysisResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\bR\x06status\"\x8a\x04\n" +
+	"\x11BulkReanalysisJob\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12#\n" +
+	"\rassignment_id\x18\x02 \x01(\tR\fassignmentId\x12!\n" +
+	"\fcreated_from\x18\x03 \x01(\tR\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\x04 \x01(\tR\tcreatedTo\x12&\n" +
+	"\x0frate_per_minute\x18\x05 \x01(\x05R\rratePerMinute\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x14\n" +
+	"\x05total\x18\a \x01(\x05R\x05total\x12\x1c\n" +
+	"\tprocessed\x18\b \x01(\x05R\tprocessed\x12\x16\n" +
+	"\x06failed\x18\t \x01(\x05R\x06failed\x12\x18\n" +
+	"\askipped\x18\n" +
+	" \x01(\x05R\askipped\x122\n" +
+	"\x15flipped_to_plagiarism\x18\v \x01(\x05R\x13flippedToPlagiarism\x12(\n" +
+	"\x10flipped_to_clean\x18\f \x01(\x05R\x0eflippedToClean\x12\x14\n" +
+	"\x05error\x18\r \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x0f \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x10 \x01(\tR\n" +
+	"finishedAt*\xdb\x01\n" +
+	"\rAnalysisStage\x12\x1e\n" +
+	"\x1aANALYSIS_STAGE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19ANALYSIS_STAGE_EXTRACTING\x10\x01\x12\x1b\n" +
+	"\x17ANALYSIS_STAGE_INDEXING\x10\x02\x12\x1c\n" +
+	"\x18ANALYSIS_STAGE_COMPARING\x10\x03\x12\x17\n" +
+	"\x13ANALYSIS_STAGE_DONE\x10\x04\x12\x19\n" +
+	"\x15ANALYSIS_STAGE_FAILED\x10\x05\x12\x1c\n" +
+	"\x18ANALYSIS_STAGE_CANCELLED\x10\x062\xbc\t\n" +
 	"\x0fAnalysisService\x12P\n" +
 	"\vAnalyseTask\x12\x1f.analysis.v1.AnalyzeTaskRequest\x1a .analysis.v1.AnalyseTaskResponse\x12J\n" +
 	"\tGetReport\x12\x1d.analysis.v1.GetReportRequest\x1a\x1e.analysis.v1.GetReportResponse\x12b\n" +
-	"\x11GenerateWordCloud\x12%.analysis.v1.GenerateWordCloudRequest\x1a&.analysis.v1.GenerateWordCloudResponseB\tZ\apkg/apib\x06proto3"
+	"\x11GenerateWordCloud\x12%.analysis.v1.GenerateWordCloudRequest\x1a&.analysis.v1.GenerateWordCloudResponse\x12P\n" +
+	"\rWatchAnalysis\x12!.analysis.v1.WatchAnalysisRequest\x1a\x1a.analysis.v1.AnalysisEvent0\x01\x12Y\n" +
+	"\x0eCancelAnalysis\x12\".analysis.v1.CancelAnalysisRequest\x1a#.analysis.v1.CancelAnalysisResponse\x12V\n" +
+	"\rReanalyseTask\x12!.analysis.v1.ReanalyseTaskRequest\x1a\".analysis.v1.ReanalyseTaskResponse\x12e\n" +
+	"\x12ListReportVersions\x12&.analysis.v1.ListReportVersionsRequest\x1a'.analysis.v1.ListReportVersionsResponse\x12P\n" +
+	"\vListReports\x12\x1f.analysis.v1.ListReportsRequest\x1a .analysis.v1.ListReportsResponse\x12J\n" +
+	"\tPurgeTask\x12\x1d.analysis.v1.PurgeTaskRequest\x1a\x1e.analysis.v1.PurgeTaskResponse\x12b\n" +
+	"\x11ListSourceMatches\x12%.analysis.v1.ListSourceMatchesRequest\x1a&.analysis.v1.ListSourceMatchesResponse\x12h\n" +
+	"\x13StartBulkReanalysis\x12'.analysis.v1.StartBulkReanalysisRequest\x1a(.analysis.v1.StartBulkReanalysisResponse\x12b\n" +
+	"\x11GetBulkReanalysis\x12%.analysis.v1.GetBulkReanalysisRequest\x1a&.analysis.v1.GetBulkReanalysisResponse\x12k\n" +
+	"\x14CancelBulkReanalysis\x12(.analysis.v1.CancelBulkReanalysisRequest\x1a).analysis.v1.CancelBulkReanalysisResponseB\tZ\apkg/apib\x06proto3"
 
 var (
 	file_analysis_service_proto_rawDescOnce sync.Once
@@ -347,27 +2438,86 @@ func file_analysis_service_proto_rawDescGZIP() []byte {
 	return file_analysis_service_proto_rawDescData
 }
 
-var file_analysis_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_analysis_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_analysis_service_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_analysis_service_proto_goTypes = []any{
-	(*AnalyzeTaskRequest)(nil),        // 0: analysis.v1.AnalyzeTaskRequest
-	(*AnalyseTaskResponse)(nil),       // 1: analysis.v1.AnalyseTaskResponse
-	(*GetReportRequest)(nil),          // 2: analysis.v1.GetReportRequest
-	(*GetReportResponse)(nil),         // 3: analysis.v1.GetReportResponse
-	(*GenerateWordCloudRequest)(nil),  // 4: analysis.v1.GenerateWordCloudRequest
-	(*GenerateWordCloudResponse)(nil), // 5: analysis.v1.GenerateWordCloudResponse
+	(AnalysisStage)(0),                   // 0: analysis.v1.AnalysisStage
+	(*AnalyzeTaskRequest)(nil),           // 1: analysis.v1.AnalyzeTaskRequest
+	(*AnalyseTaskResponse)(nil),          // 2: analysis.v1.AnalyseTaskResponse
+	(*GetReportRequest)(nil),             // 3: analysis.v1.GetReportRequest
+	(*GetReportResponse)(nil),            // 4: analysis.v1.GetReportResponse
+	(*ReportPolicy)(nil),                 // 5: analysis.v1.ReportPolicy
+	(*ReportSource)(nil),                 // 6: analysis.v1.ReportSource
+	(*MatchedSpan)(nil),                  // 7: analysis.v1.MatchedSpan
+	(*GenerateWordCloudRequest)(nil),     // 8: analysis.v1.GenerateWordCloudRequest
+	(*GenerateWordCloudResponse)(nil),    // 9: analysis.v1.GenerateWordCloudResponse
+	(*WatchAnalysisRequest)(nil),         // 10: analysis.v1.WatchAnalysisRequest
+	(*AnalysisEvent)(nil),                // 11: analysis.v1.AnalysisEvent
+	(*CancelAnalysisRequest)(nil),        // 12: analysis.v1.CancelAnalysisRequest
+	(*CancelAnalysisResponse)(nil),       // 13: analysis.v1.CancelAnalysisResponse
+	(*ReanalyseTaskRequest)(nil),         // 14: analysis.v1.ReanalyseTaskRequest
+	(*ReanalyseTaskResponse)(nil),        // 15: analysis.v1.ReanalyseTaskResponse
+	(*ListReportVersionsRequest)(nil),    // 16: analysis.v1.ListReportVersionsRequest
+	(*ReportVersion)(nil),                // 17: analysis.v1.ReportVersion
+	(*ListReportVersionsResponse)(nil),   // 18: analysis.v1.ListReportVersionsResponse
+	(*ListReportsRequest)(nil),           // 19: analysis.v1.ListReportsRequest
+	(*ReportSummary)(nil),                // 20: analysis.v1.ReportSummary
+	(*ListReportsResponse)(nil),          // 21: analysis.v1.ListReportsResponse
+	(*PurgeTaskRequest)(nil),             // 22: analysis.v1.PurgeTaskRequest
+	(*PurgeTaskResponse)(nil),            // 23: analysis.v1.PurgeTaskResponse
+	(*ListSourceMatchesRequest)(nil),     // 24: analysis.v1.ListSourceMatchesRequest
+	(*SourceMatch)(nil),                  // 25: analysis.v1.SourceMatch
+	(*ListSourceMatchesResponse)(nil),    // 26: analysis.v1.ListSourceMatchesResponse
+	(*StartBulkReanalysisRequest)(nil),   // 27: analysis.v1.StartBulkReanalysisRequest
+	(*StartBulkReanalysisResponse)(nil),  // 28: analysis.v1.StartBulkReanalysisResponse
+	(*GetBulkReanalysisRequest)(nil),     // 29: analysis.v1.GetBulkReanalysisRequest
+	(*GetBulkReanalysisResponse)(nil),    // 30: analysis.v1.GetBulkReanalysisResponse
+	(*CancelBulkReanalysisRequest)(nil),  // 31: analysis.v1.CancelBulkReanalysisRequest
+	(*CancelBulkReanalysisResponse)(nil), // 32: analysis.v1.CancelBulkReanalysisResponse
+	(*BulkReanalysisJob)(nil),            // 33: analysis.v1.BulkReanalysisJob
 }
 var file_analysis_service_proto_depIdxs = []int32{
-	0, // 0: analysis.v1.AnalysisService.AnalyseTask:input_type -> analysis.v1.AnalyzeTaskRequest
-	2, // 1: analysis.v1.AnalysisService.GetReport:input_type -> analysis.v1.GetReportRequest
-	4, // 2: analysis.v1.AnalysisService.GenerateWordCloud:input_type -> analysis.v1.GenerateWordCloudRequest
-	1, // 3: analysis.v1.AnalysisService.AnalyseTask:output_type -> analysis.v1.AnalyseTaskResponse
-	3, // 4: analysis.v1.AnalysisService.GetReport:output_type -> analysis.v1.GetReportResponse
-	5, // 5: analysis.v1.AnalysisService.GenerateWordCloud:output_type -> analysis.v1.GenerateWordCloudResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	5,  // 0: analysis.v1.GetReportResponse.policy:type_name -> analysis.v1.ReportPolicy
+	6,  // 1: analysis.v1.GetReportResponse.sources:type_name -> analysis.v1.ReportSource
+	7,  // 2: analysis.v1.ReportSource.matched_spans:type_name -> analysis.v1.MatchedSpan
+	0,  // 3: analysis.v1.AnalysisEvent.stage:type_name -> analysis.v1.AnalysisStage
+	5,  // 4: analysis.v1.ReportVersion.policy:type_name -> analysis.v1.ReportPolicy
+	17, // 5: analysis.v1.ListReportVersionsResponse.versions:type_name -> analysis.v1.ReportVersion
+	20, // 6: analysis.v1.ListReportsResponse.reports:type_name -> analysis.v1.ReportSummary
+	25, // 7: analysis.v1.ListSourceMatchesResponse.matches:type_name -> analysis.v1.SourceMatch
+	33, // 8: analysis.v1.StartBulkReanalysisResponse.job:type_name -> analysis.v1.BulkReanalysisJob
+	33, // 9: analysis.v1.GetBulkReanalysisResponse.job:type_name -> analysis.v1.BulkReanalysisJob
+	1,  // 10: analysis.v1.AnalysisService.AnalyseTask:input_type -> analysis.v1.AnalyzeTaskRequest
+	3,  // 11: analysis.v1.AnalysisService.GetReport:input_type -> analysis.v1.GetReportRequest
+	8,  // 12: analysis.v1.AnalysisService.GenerateWordCloud:input_type -> analysis.v1.GenerateWordCloudRequest
+	10, // 13: analysis.v1.AnalysisService.WatchAnalysis:input_type -> analysis.v1.WatchAnalysisRequest
+	12, // 14: analysis.v1.AnalysisService.CancelAnalysis:input_type -> analysis.v1.CancelAnalysisRequest
+	14, // 15: analysis.v1.AnalysisService.ReanalyseTask:input_type -> analysis.v1.ReanalyseTaskRequest
+	16, // 16: analysis.v1.AnalysisService.ListReportVersions:input_type -> analysis.v1.ListReportVersionsRequest
+	19, // 17: analysis.v1.AnalysisService.ListReports:input_type -> analysis.v1.ListReportsRequest
+	22, // 18: analysis.v1.AnalysisService.PurgeTask:input_type -> analysis.v1.PurgeTaskRequest
+	24, // 19: analysis.v1.AnalysisService.ListSourceMatches:input_type -> analysis.v1.ListSourceMatchesRequest
+	27, // 20: analysis.v1.AnalysisService.StartBulkReanalysis:input_type -> analysis.v1.StartBulkReanalysisRequest
+	29, // 21: analysis.v1.AnalysisService.GetBulkReanalysis:input_type -> analysis.v1.GetBulkReanalysisRequest
+	31, // 22: analysis.v1.AnalysisService.CancelBulkReanalysis:input_type -> analysis.v1.CancelBulkReanalysisRequest
+	2,  // 23: analysis.v1.AnalysisService.AnalyseTask:output_type -> analysis.v1.AnalyseTaskResponse
+	4,  // 24: analysis.v1.AnalysisService.GetReport:output_type -> analysis.v1.GetReportResponse
+	9,  // 25: analysis.v1.AnalysisService.GenerateWordCloud:output_type -> analysis.v1.GenerateWordCloudResponse
+	11, // 26: analysis.v1.AnalysisService.WatchAnalysis:output_type -> analysis.v1.AnalysisEvent
+	13, // 27: analysis.v1.AnalysisService.CancelAnalysis:output_type -> analysis.v1.CancelAnalysisResponse
+	15, // 28: analysis.v1.AnalysisService.ReanalyseTask:output_type -> analysis.v1.ReanalyseTaskResponse
+	18, // 29: analysis.v1.AnalysisService.ListReportVersions:output_type -> analysis.v1.ListReportVersionsResponse
+	21, // 30: analysis.v1.AnalysisService.ListReports:output_type -> analysis.v1.ListReportsResponse
+	23, // 31: analysis.v1.AnalysisService.PurgeTask:output_type -> analysis.v1.PurgeTaskResponse
+	26, // 32: analysis.v1.AnalysisService.ListSourceMatches:output_type -> analysis.v1.ListSourceMatchesResponse
+	28, // 33: analysis.v1.AnalysisService.StartBulkReanalysis:output_type -> analysis.v1.StartBulkReanalysisResponse
+	30, // 34: analysis.v1.AnalysisService.GetBulkReanalysis:output_type -> analysis.v1.GetBulkReanalysisResponse
+	32, // 35: analysis.v1.AnalysisService.CancelBulkReanalysis:output_type -> analysis.v1.CancelBulkReanalysisResponse
+	23, // [23:36] is the sub-list for method output_type
+	10, // [10:23] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_analysis_service_proto_init() }
@@ -375,18 +2525,20 @@ func file_analysis_service_proto_init() {
 	if File_analysis_service_proto != nil {
 		return
 	}
+	file_analysis_service_proto_msgTypes[18].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_analysis_service_proto_rawDesc), len(file_analysis_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_analysis_service_proto_goTypes,
 		DependencyIndexes: file_analysis_service_proto_depIdxs,
+		EnumInfos:         file_analysis_service_proto_enumTypes,
 		MessageInfos:      file_analysis_service_proto_msgTypes,
 	}.Build()
 	File_analysis_service_proto = out.File
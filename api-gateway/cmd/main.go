@@ -1,6 +1,7 @@
 package main
 
 import (
+	"api-gateway/internal/auth"
 	"api-gateway/internal/config"
 	"api-gateway/internal/infrastructure/analysis"
 	"api-gateway/internal/infrastructure/storing"
@@ -27,20 +28,30 @@ func main() {
 	}
 	defer appLogger.Sync()
 
-	analysisClient, err := analysis.NewClient(ctx, cfg.AnalysisService.Endpoint, appLogger)
+	authenticator, err := auth.NewAuthenticator(&cfg.Auth)
+	if err != nil {
+		appLogger.Fatal("failed to init authentication", zap.Error(err))
+	}
+
+	signer, err := auth.NewIdentitySigner(cfg.Auth.IdentitySecret)
+	if err != nil {
+		appLogger.Fatal("failed to init identity signing", zap.Error(err))
+	}
+
+	analysisClient, err := analysis.NewClient(ctx, cfg.AnalysisService.Endpoint, signer, appLogger)
 	if err != nil {
 		appLogger.Fatal("failed to connect to analysis service", zap.Error(err))
 	}
 	defer analysisClient.Close()
 
-	storingClient, err := storing.NewClient(ctx, cfg.StoringService.Endpoint, appLogger)
+	storingClient, err := storing.NewClient(ctx, cfg.StoringService.Endpoint, signer, appLogger)
 	if err != nil {
 		appLogger.Fatal("failed to connect to storing service", zap.Error(err))
 	}
 	defer storingClient.Close()
 
-	handler := transport.NewHandler(analysisClient, storingClient, appLogger)
-	router := transport.NewRouter(handler, appLogger)
+	handler := transport.NewHandler(analysisClient, storingClient, &cfg.Upload, appLogger)
+	router := transport.NewRouter(handler, authenticator, appLogger)
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.App.HTTPPort),
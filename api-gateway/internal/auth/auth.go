@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"api-gateway/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrUnauthenticated - запрос без учетных данных или с недействительными учетными данными
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+const apiKeyHeader = "X-API-Key"
+
+var (
+	hmacMethods       = []string{"HS256", "HS384", "HS512"}
+	publicKeyMethods  = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+	errNoCredentials  = fmt.Errorf("bearer token or %s header is required: %w", apiKeyHeader, ErrUnauthenticated)
+	errInvalidAPIKey  = fmt.Errorf("invalid API key: %w", ErrUnauthenticated)
+	errMethodDisabled = errors.New("signing method is not configured")
+)
+
+// Authenticator проверяет JWT пользователей и API-ключи сервисов
+type Authenticator struct {
+	secret       []byte
+	keys         *keySet
+	parser       *jwt.Parser
+	userClaim    string
+	roleClaim    string
+	coursesClaim string
+	tenantClaim  string
+	// sha256 ключа -> имя сервиса; ключи сравниваются по хешам за постоянное время
+	apiKeys map[[sha256.Size]byte]string
+	// имя сервиса -> тенант
+	apiKeyTenants map[string]string
+}
+
+func NewAuthenticator(cfg *config.AuthConfig) (*Authenticator, error) {
+	a := &Authenticator{
+		userClaim:     cfg.JWTUserClaim,
+		roleClaim:     cfg.JWTRoleClaim,
+		coursesClaim:  cfg.JWTCoursesClaim,
+		tenantClaim:   cfg.JWTTenantClaim,
+		apiKeys:       make(map[[sha256.Size]byte]string, len(cfg.APIKeys)),
+		apiKeyTenants: cfg.APIKeyTenants,
+	}
+
+	var methods []string
+	if cfg.JWTSecret != "" {
+		a.secret = []byte(cfg.JWTSecret)
+		methods = append(methods, hmacMethods...)
+	}
+	if cfg.JWKSFile != "" {
+		keys, err := loadKeySet(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JWKS: %w", err)
+		}
+		a.keys = keys
+		methods = append(methods, publicKeyMethods...)
+	}
+	for name, key := range cfg.APIKeys {
+		a.apiKeys[sha256.Sum256([]byte(key))] = name
+	}
+	for name, tenant := range cfg.APIKeyTenants {
+		if _, ok := cfg.APIKeys[name]; !ok {
+			return nil, fmt.Errorf("AUTH_API_KEY_TENANTS has unknown API key %q", name)
+		}
+		if !ValidTenant(tenant) {
+			return nil, fmt.Errorf("API key %s has invalid tenant %q", name, tenant)
+		}
+	}
+	// Ключ без тенанта получил бы права администратора неизвестно какого тенанта
+	for name := range cfg.APIKeys {
+		if _, ok := cfg.APIKeyTenants[name]; !ok {
+			return nil, fmt.Errorf("API key %s has no tenant in AUTH_API_KEY_TENANTS", name)
+		}
+	}
+
+	if len(methods) == 0 && len(a.apiKeys) == 0 {
+		return nil, errors.New("no authentication method configured: set AUTH_JWT_SECRET, AUTH_JWKS_FILE or AUTH_API_KEYS")
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.JWTIssuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.JWTIssuer))
+	}
+	if cfg.JWTAudience != "" {
+		options = append(options, jwt.WithAudience(cfg.JWTAudience))
+	}
+	a.parser = jwt.NewParser(options...)
+	return a, nil
+}
+
+// Authenticate проверяет заголовок Authorization: Bearer <JWT> или X-API-Key.
+// Сервисы с API-ключом получают роль администратора в тенанте, к которому привязан ключ.
+// Клиент без тенанта (токен без claim тенанта, ключ без привязки) не аутентифицируется.
+func (a *Authenticator) Authenticate(r *http.Request) (*Principal, error) {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		return a.authenticateAPIKey(key)
+	}
+
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, errNoCredentials
+	}
+	return a.authenticateToken(strings.TrimSpace(token))
+}
+
+func (a *Authenticator) authenticateAPIKey(key string) (*Principal, error) {
+	sum := sha256.Sum256([]byte(key))
+	for known, name := range a.apiKeys {
+		if subtle.ConstantTimeCompare(sum[:], known[:]) == 1 {
+			tenant, ok := a.apiKeyTenants[name]
+			if !ok {
+				return nil, errInvalidAPIKey
+			}
+			return &Principal{Subject: name, Kind: KindService, Role: RoleAdmin, Tenant: tenant}, nil
+		}
+	}
+	return nil, errInvalidAPIKey
+}
+
+func (a *Authenticator) authenticateToken(token string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(token, claims, a.verificationKey); err != nil {
+		return nil, fmt.Errorf("invalid token: %v: %w", err, ErrUnauthenticated)
+	}
+
+	subject, _ := claims[a.userClaim].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("token has no %s claim: %w", a.userClaim, ErrUnauthenticated)
+	}
+	tenant, _ := claims[a.tenantClaim].(string)
+	if tenant == "" {
+		return nil, fmt.Errorf("token has no %s claim: %w", a.tenantClaim, ErrUnauthenticated)
+	}
+	if !ValidTenant(tenant) {
+		return nil, fmt.Errorf("token has invalid %s claim: %w", a.tenantClaim, ErrUnauthenticated)
+	}
+	return &Principal{
+		Subject: subject,
+		Kind:    KindUser,
+		Role:    highestRole(claimStrings(claims[a.roleClaim])),
+		Courses: claimStrings(claims[a.coursesClaim]),
+		Tenant:  tenant,
+	}, nil
+}
+
+// highestRole выбирает старшую из известных ролей токена; без ролей пользователь - студент
+func highestRole(names []string) Role {
+	result := RoleStudent
+	for _, name := range names {
+		if role, ok := ParseRole(name); ok && role.rank() > result.rank() {
+			result = role
+		}
+	}
+	return result
+}
+
+// claimStrings читает claim, заданный строкой или массивом строк
+func claimStrings(value any) []string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// verificationKey выбирает ключ по алгоритму токена: HMAC проверяется только секретом,
+// остальные алгоритмы - только ключами из JWKS
+func (a *Authenticator) verificationKey(token *jwt.Token) (any, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if a.secret == nil {
+			return nil, errMethodDisabled
+		}
+		return a.secret, nil
+	}
+
+	if a.keys == nil {
+		return nil, errMethodDisabled
+	}
+	kid, _ := token.Header["kid"].(string)
+	return a.keys.lookup(kid)
+}
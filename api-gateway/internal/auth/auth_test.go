@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"api-gateway/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testJWTSecret = "jwt-secret-jwt-secret-jwt-secret"
+
+func newTestAuthenticator(t *testing.T) *Authenticator {
+	t.Helper()
+	a, err := NewAuthenticator(&config.AuthConfig{
+		JWTSecret:      testJWTSecret,
+		JWTUserClaim:   "sub",
+		JWTRoleClaim:   "role",
+		JWTTenantClaim: "tenant",
+		APIKeys:        map[string]string{"lms": "lms-key"},
+		APIKeyTenants:  map[string]string{"lms": "uni"},
+	})
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+	return a
+}
+
+func signedToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	claims["exp"] = time.Now().Add(time.Hour).Unix()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+func TestAuthenticateTokenRequiresTenant(t *testing.T) {
+	a := newTestAuthenticator(t)
+
+	for name, claims := range map[string]jwt.MapClaims{
+		"missing": {"sub": "user", "role": "admin"},
+		"empty":   {"sub": "user", "role": "admin", "tenant": ""},
+		"invalid": {"sub": "user", "role": "admin", "tenant": "../uni"},
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Authorization", "Bearer "+signedToken(t, claims))
+		if _, err := a.Authenticate(r); !errors.Is(err, ErrUnauthenticated) {
+			t.Errorf("%s tenant: err = %v, want ErrUnauthenticated", name, err)
+		}
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer "+signedToken(t, jwt.MapClaims{"sub": "user", "tenant": "uni"}))
+	principal, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if principal.Tenant != "uni" {
+		t.Errorf("tenant = %q, want uni", principal.Tenant)
+	}
+}
+
+func TestAuthenticateAPIKeyTenant(t *testing.T) {
+	a := newTestAuthenticator(t)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set(apiKeyHeader, "lms-key")
+	principal, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if principal.Tenant != "uni" || principal.Role != RoleAdmin {
+		t.Errorf("principal = %+v, want admin of uni", principal)
+	}
+}
+
+func TestNewAuthenticatorRequiresAPIKeyTenant(t *testing.T) {
+	_, err := NewAuthenticator(&config.AuthConfig{
+		APIKeys:       map[string]string{"lms": "lms-key", "sis": "sis-key"},
+		APIKeyTenants: map[string]string{"lms": "uni"},
+	})
+	if err == nil {
+		t.Fatal("NewAuthenticator accepted an API key without tenant")
+	}
+}
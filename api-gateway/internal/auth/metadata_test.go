@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const testMethod = "/storing.v1.StoringService/GetTask"
+
+func newTestSigner(t *testing.T) *IdentitySigner {
+	t.Helper()
+	signer, err := NewIdentitySigner("0123456789abcdef0123456789abcdef")
+	if err != nil {
+		t.Fatalf("NewIdentitySigner: %v", err)
+	}
+	return signer
+}
+
+// Та же подпись проверяется в тестах storing-service/pkg/identity
+func TestSignMatchesServiceVector(t *testing.T) {
+	p := &Principal{
+		Subject: "2c9b7f6e-8d3a-4c1e-9f0a-5b6d7e8f9a0b",
+		Kind:    KindUser,
+		Role:    RoleTeacher,
+		Tenant:  "uni",
+		Courses: []string{"cs101", "cs102"},
+	}
+	const want = "1767225600.8dc7f2e222174cdd1c3a9618558f1dbfd9da68400328ab006358dddfb586ad24"
+	if got := newTestSigner(t).sign(testMethod, p, time.Unix(1767225600, 0)); got != want {
+		t.Fatalf("sign = %s, want %s", got, want)
+	}
+}
+
+func TestNewIdentitySignerRequiresSecret(t *testing.T) {
+	if _, err := NewIdentitySigner(""); err == nil {
+		t.Fatal("empty secret accepted")
+	}
+	if _, err := NewIdentitySigner("short"); err == nil {
+		t.Fatal("short secret accepted")
+	}
+}
+
+func TestOutgoingContext(t *testing.T) {
+	signer := newTestSigner(t)
+
+	md, _ := metadata.FromOutgoingContext(signer.outgoingContext(context.Background(), testMethod))
+	if len(md) != 0 {
+		t.Fatalf("metadata without principal: %v", md)
+	}
+
+	ctx := WithPrincipal(context.Background(), &Principal{
+		Subject: "u1", Kind: KindUser, Role: RoleTeacher, Tenant: "uni", Courses: []string{"cs101", "cs102"},
+	})
+	md, _ = metadata.FromOutgoingContext(signer.outgoingContext(ctx, testMethod))
+	if got := md.Get(CourseMetadataKey); strings.Join(got, ",") != "cs101,cs102" {
+		t.Fatalf("courses = %v", got)
+	}
+	if got := md.Get(TenantMetadataKey); len(got) != 1 || got[0] != "uni" {
+		t.Fatalf("tenant = %v", got)
+	}
+	if got := md.Get(SignatureMetadataKey); len(got) != 1 || !strings.Contains(got[0], ".") {
+		t.Fatalf("signature = %v", got)
+	}
+}
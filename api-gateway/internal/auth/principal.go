@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"regexp"
+	"slices"
+)
+
+type Kind string
+
+const (
+	// KindUser - пользователь, аутентифицированный по JWT
+	KindUser Kind = "user"
+	// KindService - сервис, аутентифицированный по API-ключу
+	KindService Kind = "service"
+)
+
+// Role - роль клиента; роли упорядочены по возрастанию прав
+type Role string
+
+const (
+	RoleStudent Role = "student"
+	RoleTeacher Role = "teacher"
+	RoleAdmin   Role = "admin"
+)
+
+func (r Role) rank() int {
+	switch r {
+	case RoleAdmin:
+		return 2
+	case RoleTeacher:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ParseRole возвращает роль по имени; неизвестная роль не дает прав сверх студента
+func ParseRole(name string) (Role, bool) {
+	switch role := Role(name); role {
+	case RoleStudent, RoleTeacher, RoleAdmin:
+		return role, true
+	default:
+		return RoleStudent, false
+	}
+}
+
+// tenantPattern - допустимый идентификатор тенанта, как в storing-service и analysis-service
+var tenantPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// ValidTenant проверяет идентификатор тенанта
+func ValidTenant(id string) bool {
+	return tenantPattern.MatchString(id)
+}
+
+// Principal - аутентифицированный клиент запроса. Для пользователя Subject - UUID из JWT,
+// для сервиса - имя API-ключа. Courses - курсы, которые ведет преподаватель.
+// Tenant - учреждение клиента, задано всегда.
+type Principal struct {
+	Subject string
+	Kind    Kind
+	Role    Role
+	Courses []string
+	Tenant  string
+}
+
+// HasRole сообщает, что роль клиента не ниже role
+func (p *Principal) HasRole(role Role) bool {
+	return p.Role.rank() >= role.rank()
+}
+
+// Teaches сообщает, что клиент - преподаватель курса courseId
+func (p *Principal) Teaches(courseId string) bool {
+	return courseId != "" && p.HasRole(RoleTeacher) && slices.Contains(p.Courses, courseId)
+}
+
+// CanAccess сообщает, что клиенту доступна работа пользователя uploadedBy в курсе courseId:
+// своя работа, работа в курсе преподавателя или любая работа для администратора
+func (p *Principal) CanAccess(uploadedBy, courseId string) bool {
+	if p.HasRole(RoleAdmin) {
+		return true
+	}
+	if p.Kind == KindUser && uploadedBy != "" && p.Subject == uploadedBy {
+		return true
+	}
+	return p.Teaches(courseId)
+}
+
+type principalKey struct{}
+
+// WithPrincipal сохраняет аутентифицированного клиента в контексте запроса
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext возвращает аутентифицированного клиента запроса
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
@@ -1,6 +1,10 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"strings"
+)
 
 type AppConfig struct {
 	HTTPPort string
@@ -18,11 +22,44 @@ type LoggerConfig struct {
 	Level string
 }
 
+type UploadConfig struct {
+	// Максимальный размер тела multipart-запроса загрузки файла в байтах
+	MaxSize int64
+	// Максимальный размер тела multipart-запроса импорта ZIP-архива в байтах
+	ImportMaxSize int64
+}
+
+type AuthConfig struct {
+	// Секрет для JWT с подписью HMAC (HS256, HS384, HS512)
+	JWTSecret string
+	// Файл JWKS с открытыми ключами для JWT с подписью RSA, ECDSA или Ed25519
+	JWKSFile string
+	// Ожидаемые iss и aud токена, не проверяются, если пусты
+	JWTIssuer   string
+	JWTAudience string
+	// Claim с UUID пользователя
+	JWTUserClaim string
+	// Claim с ролью (student, teacher, admin) или списком ролей
+	JWTRoleClaim string
+	// Claim со списком курсов, которые ведет преподаватель
+	JWTCoursesClaim string
+	// Claim с тенантом (учреждением) пользователя, токен без него отклоняется
+	JWTTenantClaim string
+	// API-ключи сервисов: имя сервиса -> ключ
+	APIKeys map[string]string
+	// Тенанты сервисов: имя сервиса -> тенант, привязка обязательна для каждого ключа
+	APIKeyTenants map[string]string
+	// Общий со storing-service и analysis-service секрет, которым подписывается клиент запроса
+	IdentitySecret string
+}
+
 type Config struct {
 	App             AppConfig
 	StoringService  StoringServiceConfig
 	AnalysisService AnalysisServiceConfig
 	Logger          LoggerConfig
+	Upload          UploadConfig
+	Auth            AuthConfig
 }
 
 func LoadConfig() *Config {
@@ -39,6 +76,23 @@ func LoadConfig() *Config {
 		Logger: LoggerConfig{
 			Level: getEnv("LOG_LEVEL", "prod"),
 		},
+		Upload: UploadConfig{
+			MaxSize:       getEnvInt64("UPLOAD_MAX_SIZE", 50<<20),
+			ImportMaxSize: getEnvInt64("IMPORT_MAX_SIZE", 512<<20),
+		},
+		Auth: AuthConfig{
+			JWTSecret:       getEnv("AUTH_JWT_SECRET", ""),
+			JWKSFile:        getEnv("AUTH_JWKS_FILE", ""),
+			JWTIssuer:       getEnv("AUTH_JWT_ISSUER", ""),
+			JWTAudience:     getEnv("AUTH_JWT_AUDIENCE", ""),
+			JWTUserClaim:    getEnv("AUTH_JWT_USER_CLAIM", "sub"),
+			JWTRoleClaim:    getEnv("AUTH_JWT_ROLE_CLAIM", "role"),
+			JWTCoursesClaim: getEnv("AUTH_JWT_COURSES_CLAIM", "courses"),
+			JWTTenantClaim:  getEnv("AUTH_JWT_TENANT_CLAIM", "tenant"),
+			APIKeys:         getEnvMap("AUTH_API_KEYS"),
+			APIKeyTenants:   getEnvMap("AUTH_API_KEY_TENANTS"),
+			IdentitySecret:  getEnv("IDENTITY_SECRET", ""),
+		},
 	}
 }
 
@@ -48,3 +102,24 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvInt64(key string, fallback int64) int64 {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+			return n
+		}
+	}
+	return fallback
+}
+
+// getEnvMap разбирает список вида "name1:value1,name2:value2"
+func getEnvMap(key string) map[string]string {
+	result := make(map[string]string)
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(item), ":")
+		if ok && name != "" && value != "" {
+			result[name] = value
+		}
+	}
+	return result
+}
@@ -2,9 +2,9 @@ package analysis
 
 import (
 	analysispb "analysis-service/pkg/api"
+	"api-gateway/internal/auth"
 	"context"
 	"fmt"
-	"path/filepath"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -17,9 +17,12 @@ type Client struct {
 	logger *zap.Logger
 }
 
-func NewClient(ctx context.Context, endpoint string, logger *zap.Logger) (*Client, error) {
+func NewClient(ctx context.Context, endpoint string, signer *auth.IdentitySigner, logger *zap.Logger) (*Client, error) {
 	logger.Info("connecting to analysis service", zap.String("endpoint", endpoint))
-	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(signer.UnaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(signer.StreamClientInterceptor))
 	if err != nil {
 		logger.Error("failed to connect to analysis service", zap.String("endpoint", endpoint), zap.Error(err))
 		return nil, fmt.Errorf("failed to connect to analysis service: %w", err)
@@ -33,15 +36,23 @@ func NewClient(ctx context.Context, endpoint string, logger *zap.Logger) (*Clien
 	}, nil
 }
 
-func (c *Client) AnalyseTask(ctx context.Context, taskId, filename string) (*analysispb.AnalyseTaskResponse, error) {
-	objectKey := makeObjectKey(taskId, filename)
+func (c *Client) AnalyseTask(ctx context.Context, taskId, objectKey, assignmentId, uploadedBy, courseId, duplicateOf, duplicateOfKey string, excludeTaskIds []string) (*analysispb.AnalyseTaskResponse, error) {
 	c.logger.Debug("calling analysis service AnalyseTask",
 		zap.String("task_id", taskId),
-		zap.String("object_key", objectKey))
+		zap.String("object_key", objectKey),
+		zap.String("assignment_id", assignmentId),
+		zap.String("duplicate_of", duplicateOf),
+		zap.Int("excluded", len(excludeTaskIds)))
 
 	res, err := c.client.AnalyseTask(ctx, &analysispb.AnalyzeTaskRequest{
-		TaskId:    taskId,
-		ObjectKey: objectKey,
+		TaskId:               taskId,
+		ObjectKey:            objectKey,
+		AssignmentId:         assignmentId,
+		UploadedBy:           uploadedBy,
+		CourseId:             courseId,
+		DuplicateOf:          duplicateOf,
+		DuplicateOfObjectKey: duplicateOfKey,
+		ExcludeTaskIds:       excludeTaskIds,
 	})
 
 	if err != nil {
@@ -57,11 +68,14 @@ func (c *Client) AnalyseTask(ctx context.Context, taskId, filename string) (*ana
 	return res, nil
 }
 
-func (c *Client) GetReport(ctx context.Context, taskId string) (*analysispb.GetReportResponse, error) {
-	c.logger.Debug("calling analysis service GetReport", zap.String("task_id", taskId))
+func (c *Client) GetReport(ctx context.Context, taskId string, version int32) (*analysispb.GetReportResponse, error) {
+	c.logger.Debug("calling analysis service GetReport",
+		zap.String("task_id", taskId),
+		zap.Int32("version", version))
 
 	res, err := c.client.GetReport(ctx, &analysispb.GetReportRequest{
-		TaskId: taskId,
+		TaskId:  taskId,
+		Version: version,
 	})
 
 	if err != nil {
@@ -95,14 +109,183 @@ func (c *Client) GenerateWordCloud(ctx context.Context, fileContent []byte) (*an
 	return res, nil
 }
 
+func (c *Client) WatchAnalysis(ctx context.Context, taskId string) (analysispb.AnalysisService_WatchAnalysisClient, error) {
+	c.logger.Debug("calling analysis service WatchAnalysis", zap.String("task_id", taskId))
+
+	stream, err := c.client.WatchAnalysis(ctx, &analysispb.WatchAnalysisRequest{
+		TaskId: taskId,
+	})
+
+	if err != nil {
+		c.logger.Error("analysis service WatchAnalysis failed",
+			zap.String("task_id", taskId),
+			zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("analysis service WatchAnalysis stream opened", zap.String("task_id", taskId))
+	return stream, nil
+}
+
+func (c *Client) CancelAnalysis(ctx context.Context, taskId string) (*analysispb.CancelAnalysisResponse, error) {
+	c.logger.Debug("calling analysis service CancelAnalysis", zap.String("task_id", taskId))
+
+	res, err := c.client.CancelAnalysis(ctx, &analysispb.CancelAnalysisRequest{
+		TaskId: taskId,
+	})
+
+	if err != nil {
+		c.logger.Error("analysis service CancelAnalysis failed",
+			zap.String("task_id", taskId),
+			zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("analysis service CancelAnalysis success", zap.String("task_id", taskId))
+	return res, nil
+}
+
+func (c *Client) ReanalyseTask(ctx context.Context, taskId, objectKey, uploadedBy, courseId string, excludeTaskIds []string) (*analysispb.ReanalyseTaskResponse, error) {
+	c.logger.Debug("calling analysis service ReanalyseTask",
+		zap.String("task_id", taskId),
+		zap.String("object_key", objectKey),
+		zap.Int("excluded", len(excludeTaskIds)))
+
+	res, err := c.client.ReanalyseTask(ctx, &analysispb.ReanalyseTaskRequest{
+		TaskId:         taskId,
+		ObjectKey:      objectKey,
+		UploadedBy:     uploadedBy,
+		CourseId:       courseId,
+		ExcludeTaskIds: excludeTaskIds,
+	})
+
+	if err != nil {
+		c.logger.Error("analysis service ReanalyseTask failed",
+			zap.String("task_id", taskId),
+			zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("analysis service ReanalyseTask success", zap.String("task_id", taskId))
+	return res, nil
+}
+
+func (c *Client) ListReportVersions(ctx context.Context, taskId string) (*analysispb.ListReportVersionsResponse, error) {
+	c.logger.Debug("calling analysis service ListReportVersions", zap.String("task_id", taskId))
+
+	res, err := c.client.ListReportVersions(ctx, &analysispb.ListReportVersionsRequest{
+		TaskId: taskId,
+	})
+
+	if err != nil {
+		c.logger.Error("analysis service ListReportVersions failed",
+			zap.String("task_id", taskId),
+			zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("analysis service ListReportVersions success",
+		zap.String("task_id", taskId),
+		zap.Int("count", len(res.Versions)))
+	return res, nil
+}
+
+func (c *Client) ListSourceMatches(ctx context.Context, taskIds []string) (*analysispb.ListSourceMatchesResponse, error) {
+	c.logger.Debug("calling analysis service ListSourceMatches", zap.Int("task_ids", len(taskIds)))
+
+	res, err := c.client.ListSourceMatches(ctx, &analysispb.ListSourceMatchesRequest{
+		TaskIds: taskIds,
+	})
+
+	if err != nil {
+		c.logger.Error("analysis service ListSourceMatches failed", zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("analysis service ListSourceMatches success", zap.Int("count", len(res.Matches)))
+	return res, nil
+}
+
+// ListReports передает фильтры и параметры пагинации в analysis-service без изменений
+func (c *Client) ListReports(ctx context.Context, req *analysispb.ListReportsRequest) (*analysispb.ListReportsResponse, error) {
+	c.logger.Debug("calling analysis service ListReports",
+		zap.String("assignment_id", req.AssignmentId),
+		zap.Int32("limit", req.Limit))
+
+	res, err := c.client.ListReports(ctx, req)
+	if err != nil {
+		c.logger.Error("analysis service ListReports failed", zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("analysis service ListReports success", zap.Int("count", len(res.Reports)))
+	return res, nil
+}
+
+func (c *Client) StartBulkReanalysis(ctx context.Context, assignmentId, createdFrom, createdTo string, ratePerMinute int32) (*analysispb.StartBulkReanalysisResponse, error) {
+	c.logger.Debug("calling analysis service StartBulkReanalysis",
+		zap.String("assignment_id", assignmentId),
+		zap.String("created_from", createdFrom),
+		zap.String("created_to", createdTo),
+		zap.Int32("rate_per_minute", ratePerMinute))
+
+	res, err := c.client.StartBulkReanalysis(ctx, &analysispb.StartBulkReanalysisRequest{
+		AssignmentId:  assignmentId,
+		CreatedFrom:   createdFrom,
+		CreatedTo:     createdTo,
+		RatePerMinute: ratePerMinute,
+	})
+
+	if err != nil {
+		c.logger.Error("analysis service StartBulkReanalysis failed", zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("analysis service StartBulkReanalysis success", zap.String("job_id", res.Job.GetJobId()))
+	return res, nil
+}
+
+func (c *Client) GetBulkReanalysis(ctx context.Context, jobId string) (*analysispb.GetBulkReanalysisResponse, error) {
+	c.logger.Debug("calling analysis service GetBulkReanalysis", zap.String("job_id", jobId))
+
+	res, err := c.client.GetBulkReanalysis(ctx, &analysispb.GetBulkReanalysisRequest{
+		JobId: jobId,
+	})
+
+	if err != nil {
+		c.logger.Error("analysis service GetBulkReanalysis failed",
+			zap.String("job_id", jobId),
+			zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("analysis service GetBulkReanalysis success",
+		zap.String("job_id", jobId),
+		zap.String("status", res.Job.GetStatus()))
+	return res, nil
+}
+
+func (c *Client) CancelBulkReanalysis(ctx context.Context, jobId string) (*analysispb.CancelBulkReanalysisResponse, error) {
+	c.logger.Debug("calling analysis service CancelBulkReanalysis", zap.String("job_id", jobId))
+
+	res, err := c.client.CancelBulkReanalysis(ctx, &analysispb.CancelBulkReanalysisRequest{
+		JobId: jobId,
+	})
+
+	if err != nil {
+		c.logger.Error("analysis service CancelBulkReanalysis failed",
+			zap.String("job_id", jobId),
+			zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("analysis service CancelBulkReanalysis success", zap.String("job_id", jobId))
+	return res, nil
+}
+
 func (c *Client) Close() error {
 	if c.conn != nil {
 		return c.conn.Close()
 	}
 	return nil
 }
-
-func makeObjectKey(id, filename string) string {
-	ext := filepath.Ext(filename)
-	return fmt.Sprintf("%s%s", id, ext)
-}
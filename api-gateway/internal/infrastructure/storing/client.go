@@ -1,8 +1,11 @@
 package storing
 
 import (
+	"api-gateway/internal/auth"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"go.uber.org/zap"
@@ -15,9 +18,12 @@ type Client struct {
 	logger *zap.Logger
 }
 
-func NewClient(ctx context.Context, endpoint string, logger *zap.Logger) (*Client, error) {
+func NewClient(ctx context.Context, endpoint string, signer *auth.IdentitySigner, logger *zap.Logger) (*Client, error) {
 	logger.Info("connecting to storing service", zap.String("endpoint", endpoint))
-	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(signer.UnaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(signer.StreamClientInterceptor))
 	if err != nil {
 		logger.Error("failed to connect to storing service", zap.String("endpoint", endpoint), zap.Error(err))
 		return nil, fmt.Errorf("failed to connect to storing service: %w", err)
@@ -31,14 +37,18 @@ func NewClient(ctx context.Context, endpoint string, logger *zap.Logger) (*Clien
 	}, nil
 }
 
-func (c *Client) UploadTask(ctx context.Context, filename, uploadedBy string) (*storingpb.UploadTaskResponse, error) {
+func (c *Client) UploadTask(ctx context.Context, filename, uploadedBy, courseId, assignmentId string, size int64, sha256 string) (*storingpb.UploadTaskResponse, error) {
 	c.logger.Debug("calling storing service UploadTask",
 		zap.String("filename", filename),
 		zap.String("uploaded_by", uploadedBy))
 
 	res, err := c.client.UploadTask(ctx, &storingpb.UploadTaskRequest{
-		Filename:   filename,
-		UploadedBy: uploadedBy,
+		Filename:     filename,
+		UploadedBy:   uploadedBy,
+		CourseId:     courseId,
+		AssignmentId: assignmentId,
+		Size:         size,
+		Sha256:       sha256,
 	})
 
 	if err != nil {
@@ -53,6 +63,131 @@ func (c *Client) UploadTask(ctx context.Context, filename, uploadedBy string) (*
 	return res, nil
 }
 
+// uploadChunkSize - размер части файла в одном сообщении UploadTaskStream
+const uploadChunkSize = 64 << 10
+
+// UploadTaskStream передает файл из content в storing-service частями по uploadChunkSize.
+// Ошибка чтения content возвращается как есть, ошибки сервиса - как gRPC статус.
+func (c *Client) UploadTaskStream(ctx context.Context, filename, uploadedBy, courseId, assignmentId string, size int64, sha256 string, content io.Reader) (*storingpb.UploadTaskStreamResponse, error) {
+	c.logger.Debug("calling storing service UploadTaskStream",
+		zap.String("filename", filename),
+		zap.String("uploaded_by", uploadedBy))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.UploadTaskStream(ctx)
+	if err != nil {
+		c.logger.Error("failed to open storing service UploadTaskStream", zap.Error(err))
+		return nil, err
+	}
+
+	err = stream.Send(&storingpb.UploadTaskStreamRequest{
+		Payload: &storingpb.UploadTaskStreamRequest_Metadata{
+			Metadata: &storingpb.UploadTaskMetadata{
+				Filename:     filename,
+				UploadedBy:   uploadedBy,
+				CourseId:     courseId,
+				AssignmentId: assignmentId,
+				Size:         size,
+				Sha256:       sha256,
+			},
+		},
+	})
+
+	buf := make([]byte, uploadChunkSize)
+	for err == nil {
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			err = stream.Send(&storingpb.UploadTaskStreamRequest{
+				Payload: &storingpb.UploadTaskStreamRequest_Chunk{Chunk: buf[:n]},
+			})
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			c.logger.Warn("failed to read upload content", zap.Error(readErr))
+			return nil, readErr
+		}
+	}
+
+	// При ошибке Send причина возвращается из CloseAndRecv
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		c.logger.Error("storing service UploadTaskStream failed",
+			zap.String("filename", filename),
+			zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("storing service UploadTaskStream success",
+		zap.String("file_id", res.FileId),
+		zap.Int64("size", res.Size),
+		zap.String("status", res.Status))
+	return res, nil
+}
+
+// ImportArchive передает ZIP-архив в storing-service частями по uploadChunkSize
+// и возвращает отчет импорта по каждому файлу архива.
+func (c *Client) ImportArchive(ctx context.Context, courseId, assignmentId, namingRule string, manifestCSV []byte, archive io.Reader) (*storingpb.ImportArchiveResponse, error) {
+	c.logger.Debug("calling storing service ImportArchive",
+		zap.String("course_id", courseId),
+		zap.String("assignment_id", assignmentId))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.ImportArchive(ctx)
+	if err != nil {
+		c.logger.Error("failed to open storing service ImportArchive", zap.Error(err))
+		return nil, err
+	}
+
+	err = stream.Send(&storingpb.ImportArchiveRequest{
+		Payload: &storingpb.ImportArchiveRequest_Metadata{
+			Metadata: &storingpb.ImportArchiveMetadata{
+				CourseId:     courseId,
+				AssignmentId: assignmentId,
+				NamingRule:   namingRule,
+				ManifestCsv:  manifestCSV,
+			},
+		},
+	})
+
+	buf := make([]byte, uploadChunkSize)
+	for err == nil {
+		n, readErr := archive.Read(buf)
+		if n > 0 {
+			err = stream.Send(&storingpb.ImportArchiveRequest{
+				Payload: &storingpb.ImportArchiveRequest_Chunk{Chunk: buf[:n]},
+			})
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			c.logger.Warn("failed to read import archive", zap.Error(readErr))
+			return nil, readErr
+		}
+	}
+
+	// При ошибке Send причина возвращается из CloseAndRecv
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		c.logger.Error("storing service ImportArchive failed",
+			zap.String("course_id", courseId),
+			zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("storing service ImportArchive success",
+		zap.Int32("imported", res.Imported),
+		zap.Int32("rejected", res.Rejected),
+		zap.Int32("failed", res.Failed))
+	return res, nil
+}
+
 func (c *Client) GetTask(ctx context.Context, fileId string) (*storingpb.GetTaskResponse, error) {
 	c.logger.Debug("calling storing service GetTask", zap.String("file_id", fileId))
 
@@ -71,6 +206,64 @@ func (c *Client) GetTask(ctx context.Context, fileId string) (*storingpb.GetTask
 	return res, nil
 }
 
+// GetAnalysisInput возвращает параметры анализа задачи: ключ файла, исключаемые предыдущие версии
+// посылки и оригинал побайтового дубликата
+func (c *Client) GetAnalysisInput(ctx context.Context, fileId string) (*storingpb.GetAnalysisInputResponse, error) {
+	c.logger.Debug("calling storing service GetAnalysisInput", zap.String("file_id", fileId))
+
+	res, err := c.client.GetAnalysisInput(ctx, &storingpb.GetAnalysisInputRequest{
+		FileId: fileId,
+	})
+
+	if err != nil {
+		c.logger.Error("storing service GetAnalysisInput failed",
+			zap.String("file_id", fileId),
+			zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("storing service GetAnalysisInput success",
+		zap.String("file_id", fileId),
+		zap.Int("excluded", len(res.ExcludeTaskIds)))
+	return res, nil
+}
+
+// ListTasks передает фильтры и параметры пагинации в storing-service без изменений
+func (c *Client) ListTasks(ctx context.Context, req *storingpb.ListTasksRequest) (*storingpb.ListTasksResponse, error) {
+	c.logger.Debug("calling storing service ListTasks",
+		zap.String("uploaded_by", req.UploadedBy),
+		zap.String("assignment_id", req.AssignmentId),
+		zap.Int32("limit", req.Limit))
+
+	res, err := c.client.ListTasks(ctx, req)
+	if err != nil {
+		c.logger.Error("storing service ListTasks failed", zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("storing service ListTasks success", zap.Int("count", len(res.Tasks)))
+	return res, nil
+}
+
+func (c *Client) GetSubmission(ctx context.Context, submissionId string) (*storingpb.GetSubmissionResponse, error) {
+	c.logger.Debug("calling storing service GetSubmission", zap.String("submission_id", submissionId))
+
+	res, err := c.client.GetSubmission(ctx, &storingpb.GetSubmissionRequest{
+		SubmissionId: submissionId,
+	})
+	if err != nil {
+		c.logger.Error("storing service GetSubmission failed",
+			zap.String("submission_id", submissionId),
+			zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("storing service GetSubmission success",
+		zap.String("submission_id", submissionId),
+		zap.Int("versions", len(res.Versions)))
+	return res, nil
+}
+
 func (c *Client) GetFileContent(ctx context.Context, fileId string) (*storingpb.GetFileContentResponse, error) {
 	c.logger.Debug("calling storing service GetFileContent", zap.String("file_id", fileId))
 
@@ -91,9 +284,221 @@ func (c *Client) GetFileContent(ctx context.Context, fileId string) (*storingpb.
 	return res, nil
 }
 
+func (c *Client) DeleteTask(ctx context.Context, fileId string) (*storingpb.DeleteTaskResponse, error) {
+	c.logger.Debug("calling storing service DeleteTask", zap.String("file_id", fileId))
+
+	res, err := c.client.DeleteTask(ctx, &storingpb.DeleteTaskRequest{
+		FileId: fileId,
+	})
+
+	if err != nil {
+		c.logger.Error("storing service DeleteTask failed",
+			zap.String("file_id", fileId),
+			zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("storing service DeleteTask success",
+		zap.String("file_id", fileId),
+		zap.String("purge_at", res.PurgeAt))
+	return res, nil
+}
+
+func (c *Client) EraseUserData(ctx context.Context, uploadedBy string) (*storingpb.EraseUserDataResponse, error) {
+	c.logger.Debug("calling storing service EraseUserData", zap.String("uploaded_by", uploadedBy))
+
+	res, err := c.client.EraseUserData(ctx, &storingpb.EraseUserDataRequest{
+		UploadedBy: uploadedBy,
+	})
+
+	if err != nil {
+		c.logger.Error("storing service EraseUserData failed",
+			zap.String("uploaded_by", uploadedBy),
+			zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("storing service EraseUserData success",
+		zap.String("uploaded_by", uploadedBy),
+		zap.Int32("tasks_erased", res.TasksErased))
+	return res, nil
+}
+
+func (c *Client) RunRetention(ctx context.Context, dryRun bool) (*storingpb.RunRetentionResponse, error) {
+	c.logger.Debug("calling storing service RunRetention", zap.Bool("dry_run", dryRun))
+
+	res, err := c.client.RunRetention(ctx, &storingpb.RunRetentionRequest{
+		DryRun: dryRun,
+	})
+
+	if err != nil {
+		c.logger.Error("storing service RunRetention failed", zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("storing service RunRetention success",
+		zap.String("run_id", res.RunId),
+		zap.Int32("expired", res.Expired))
+	return res, nil
+}
+
+func (c *Client) RunReconciliation(ctx context.Context, dryRun bool) (*storingpb.RunReconciliationResponse, error) {
+	c.logger.Debug("calling storing service RunReconciliation", zap.Bool("dry_run", dryRun))
+
+	res, err := c.client.RunReconciliation(ctx, &storingpb.RunReconciliationRequest{
+		DryRun: dryRun,
+	})
+
+	if err != nil {
+		c.logger.Error("storing service RunReconciliation failed", zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("storing service RunReconciliation success",
+		zap.Int32("expired_uploads", res.ExpiredUploads),
+		zap.Int32("stray_objects", res.StrayObjects))
+	return res, nil
+}
+
+func (c *Client) RotateEncryptionKeys(ctx context.Context) (*storingpb.RotateEncryptionKeysResponse, error) {
+	c.logger.Debug("calling storing service RotateEncryptionKeys")
+
+	res, err := c.client.RotateEncryptionKeys(ctx, &storingpb.RotateEncryptionKeysRequest{})
+	if err != nil {
+		c.logger.Error("storing service RotateEncryptionKeys failed", zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("storing service RotateEncryptionKeys success",
+		zap.Int32("checked", res.Checked),
+		zap.Int32("rewrapped", res.Rewrapped))
+	return res, nil
+}
+
+func (c *Client) GetQuotaUsage(ctx context.Context, uploadedBy, assignmentId string) (*storingpb.GetQuotaUsageResponse, error) {
+	c.logger.Debug("calling storing service GetQuotaUsage",
+		zap.String("uploaded_by", uploadedBy),
+		zap.String("assignment_id", assignmentId))
+
+	res, err := c.client.GetQuotaUsage(ctx, &storingpb.GetQuotaUsageRequest{
+		UploadedBy:   uploadedBy,
+		AssignmentId: assignmentId,
+	})
+	if err != nil {
+		c.logger.Error("storing service GetQuotaUsage failed", zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("storing service GetQuotaUsage success")
+	return res, nil
+}
+
 func (c *Client) Close() error {
 	if c.conn != nil {
 		return c.conn.Close()
 	}
 	return nil
 }
+
+// ReadBlob открывает файл по подписанной ссылке хранилища fs. Сведения о файле возвращаются
+// до начала передачи, поэтому ошибка подписи или отсутствие файла видны сразу.
+func (c *Client) ReadBlob(ctx context.Context, objectKey string, params map[string]string) (*storingpb.BlobInfo, io.Reader, error) {
+	c.logger.Debug("calling storing service ReadBlob", zap.String("object_key", objectKey))
+
+	stream, err := c.client.ReadBlob(ctx, &storingpb.ReadBlobRequest{
+		ObjectKey: objectKey,
+		Params:    params,
+	})
+	if err != nil {
+		c.logger.Error("failed to open storing service ReadBlob", zap.Error(err))
+		return nil, nil, err
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		c.logger.Warn("storing service ReadBlob failed",
+			zap.String("object_key", objectKey),
+			zap.Error(err))
+		return nil, nil, err
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return nil, nil, fmt.Errorf("storing service ReadBlob: first message must contain blob info")
+	}
+
+	return info, &blobReader{stream: stream}, nil
+}
+
+// blobReader представляет части файла из потока ReadBlob как io.Reader
+type blobReader struct {
+	stream storingpb.StoringService_ReadBlobClient
+	buf    []byte
+}
+
+func (r *blobReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = msg.GetChunk()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// WriteBlob передает файл по подписанной ссылке хранилища fs частями по uploadChunkSize
+func (c *Client) WriteBlob(ctx context.Context, objectKey string, params map[string]string, content io.Reader) (*storingpb.WriteBlobResponse, error) {
+	c.logger.Debug("calling storing service WriteBlob", zap.String("object_key", objectKey))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.WriteBlob(ctx)
+	if err != nil {
+		c.logger.Error("failed to open storing service WriteBlob", zap.Error(err))
+		return nil, err
+	}
+
+	err = stream.Send(&storingpb.WriteBlobRequest{
+		Payload: &storingpb.WriteBlobRequest_Metadata{
+			Metadata: &storingpb.WriteBlobMetadata{
+				ObjectKey: objectKey,
+				Params:    params,
+			},
+		},
+	})
+
+	buf := make([]byte, uploadChunkSize)
+	for err == nil {
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			err = stream.Send(&storingpb.WriteBlobRequest{
+				Payload: &storingpb.WriteBlobRequest_Chunk{Chunk: buf[:n]},
+			})
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			c.logger.Warn("failed to read blob content", zap.Error(readErr))
+			return nil, readErr
+		}
+	}
+
+	// При ошибке Send причина возвращается из CloseAndRecv
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		c.logger.Error("storing service WriteBlob failed",
+			zap.String("object_key", objectKey),
+			zap.Error(err))
+		return nil, err
+	}
+
+	c.logger.Debug("storing service WriteBlob success",
+		zap.String("object_key", objectKey),
+		zap.Int64("size", res.Size))
+	return res, nil
+}
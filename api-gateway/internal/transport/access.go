@@ -0,0 +1,151 @@
+package transport
+
+import (
+	"api-gateway/internal/auth"
+	"encoding/json"
+	"net/http"
+
+	storingpb "storing-service/pkg/api"
+
+	"go.uber.org/zap"
+)
+
+// writeForbidden отвечает 403 в формате ошибок gRPC-маппера
+func writeForbidden(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	json.NewEncoder(w).Encode(ErrorResponse{
+		Error:   message,
+		Code:    "PERMISSION_DENIED",
+		Message: message,
+	})
+}
+
+// requirePrincipal возвращает аутентифицированного клиента запроса
+func (h *Handler) requirePrincipal(w http.ResponseWriter, r *http.Request) (*auth.Principal, bool) {
+	principal, ok := auth.FromContext(r.Context())
+	if !ok {
+		h.logger.Error("request without authenticated principal", zap.String("path", r.URL.Path))
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return nil, false
+	}
+	return principal, true
+}
+
+// authorizeTask получает задачу и проверяет, что клиенту доступна работа: студенту - своя,
+// преподавателю - работы его курсов, администратору - любая
+func (h *Handler) authorizeTask(w http.ResponseWriter, r *http.Request, taskId string) (*storingpb.GetTaskResponse, bool) {
+	principal, ok := h.requirePrincipal(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	task, err := h.storingClient.GetTask(r.Context(), taskId)
+	if err != nil {
+		h.logger.Error("failed to get task for access check",
+			zap.String("task_id", taskId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return nil, false
+	}
+
+	if !principal.CanAccess(task.UploadedBy, task.CourseId) {
+		h.logger.Warn("task access denied",
+			zap.String("task_id", taskId),
+			zap.String("subject", principal.Subject),
+			zap.String("role", string(principal.Role)))
+		writeForbidden(w, "access to the task is denied")
+		return nil, false
+	}
+	return task, true
+}
+
+// authorizeUser проверяет, что клиент работает с данными своего пользователя или он администратор
+func (h *Handler) authorizeUser(w http.ResponseWriter, r *http.Request, userId string) bool {
+	principal, ok := h.requirePrincipal(w, r)
+	if !ok {
+		return false
+	}
+
+	if principal.HasRole(auth.RoleAdmin) || (principal.Kind == auth.KindUser && principal.Subject == userId) {
+		return true
+	}
+
+	h.logger.Warn("user data access denied",
+		zap.String("user_id", userId),
+		zap.String("subject", principal.Subject))
+	writeForbidden(w, "access to the user data is denied")
+	return false
+}
+
+// scopeListTasks ограничивает фильтры списка задач доступными клиенту работами: студент видит
+// только свои задачи, преподаватель - свои и задачи своих курсов. Без course_id преподавателю
+// возвращаются задачи всех его курсов, это ограничение применяет storing-service.
+func (h *Handler) scopeListTasks(w http.ResponseWriter, r *http.Request, req *storingpb.ListTasksRequest) bool {
+	principal, ok := h.requirePrincipal(w, r)
+	if !ok {
+		return false
+	}
+	if principal.HasRole(auth.RoleAdmin) {
+		return true
+	}
+
+	if principal.Kind == auth.KindUser && req.UploadedBy == principal.Subject {
+		return true
+	}
+
+	if principal.HasRole(auth.RoleTeacher) {
+		if req.CourseId == "" || principal.Teaches(req.CourseId) {
+			return true
+		}
+		h.logger.Warn("list tasks of foreign course denied",
+			zap.String("course_id", req.CourseId),
+			zap.String("subject", principal.Subject))
+		writeForbidden(w, "access to the course is denied")
+		return false
+	}
+
+	if req.UploadedBy != "" {
+		h.logger.Warn("list tasks of another user denied",
+			zap.String("uploaded_by", req.UploadedBy),
+			zap.String("subject", principal.Subject))
+		writeForbidden(w, "students can list only their own tasks")
+		return false
+	}
+	req.UploadedBy = principal.Subject
+	return true
+}
+
+// authorizeCourse проверяет, что клиент ведет курс courseId или он администратор
+func (h *Handler) authorizeCourse(w http.ResponseWriter, r *http.Request, courseId string) bool {
+	principal, ok := h.requirePrincipal(w, r)
+	if !ok {
+		return false
+	}
+	if principal.HasRole(auth.RoleAdmin) || principal.Teaches(courseId) {
+		return true
+	}
+
+	h.logger.Warn("course access denied",
+		zap.String("course_id", courseId),
+		zap.String("subject", principal.Subject))
+	writeForbidden(w, "access to the course is denied")
+	return false
+}
+
+// authorizeSubmission проверяет доступ к посылке по ее автору и курсу
+func (h *Handler) authorizeSubmission(w http.ResponseWriter, r *http.Request, submission *storingpb.GetSubmissionResponse) bool {
+	principal, ok := h.requirePrincipal(w, r)
+	if !ok {
+		return false
+	}
+	if principal.CanAccess(submission.UploadedBy, submission.CourseId) {
+		return true
+	}
+
+	h.logger.Warn("submission access denied",
+		zap.String("submission_id", submission.SubmissionId),
+		zap.String("subject", principal.Subject))
+	writeForbidden(w, "access to the submission is denied")
+	return false
+}
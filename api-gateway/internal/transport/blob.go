@@ -0,0 +1,117 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+)
+
+// Подписанные ссылки хранилища fs в storing-service. Подпись и срок действия проверяет storing-service,
+// gateway только передает файл. При хранилище MinIO ссылки ведут напрямую в MinIO и эти маршруты не используются.
+
+func (h *Handler) ReadBlob(w http.ResponseWriter, r *http.Request) {
+	objectKey := chi.URLParam(r, "*")
+	h.logger.Info("read blob request", zap.String("object_key", objectKey))
+
+	info, content, err := h.storingClient.ReadBlob(r.Context(), objectKey, blobParams(r))
+	if err != nil {
+		h.logger.Warn("failed to read blob",
+			zap.String("object_key", objectKey),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Передача большого файла может идти дольше WriteTimeout сервера
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	if info.ContentType != "" {
+		w.Header().Set("Content-Type", info.ContentType)
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	if modifiedAt, err := time.Parse(time.RFC3339, info.ModifiedAt); err == nil {
+		w.Header().Set("Last-Modified", modifiedAt.UTC().Format(http.TimeFormat))
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, content); err != nil && !errors.Is(err, io.EOF) {
+		// Заголовки уже отправлены, клиент увидит оборванный ответ
+		h.logger.Error("failed to stream blob",
+			zap.String("object_key", objectKey),
+			zap.Error(err))
+	}
+}
+
+// WriteBlob принимает multipart-форму с полем file, как presigned POST в MinIO
+func (h *Handler) WriteBlob(w http.ResponseWriter, r *http.Request) {
+	objectKey := chi.URLParam(r, "*")
+	r.Body = http.MaxBytesReader(w, r.Body, h.uploadCfg.MaxSize)
+
+	// Загрузка большого файла может идти дольше ReadTimeout сервера
+	_ = http.NewResponseController(w).SetReadDeadline(time.Time{})
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		h.logger.Warn("invalid multipart blob upload", zap.Error(err))
+		http.Error(w, "multipart/form-data body is required", http.StatusBadRequest)
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			h.logger.Warn("blob upload without file", zap.String("object_key", objectKey))
+			http.Error(w, "file is required", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			h.logger.Warn("failed to read multipart part", zap.Error(err))
+			h.writeUploadReadError(w, err)
+			return
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		h.logger.Info("write blob request", zap.String("object_key", objectKey))
+
+		res, err := h.storingClient.WriteBlob(r.Context(), objectKey, blobParams(r), part)
+		if err != nil {
+			h.logger.Error("failed to write blob",
+				zap.String("object_key", objectKey),
+				zap.Error(err))
+			if _, ok := status.FromError(err); ok {
+				handleGRPCError(w, err)
+			} else {
+				h.writeUploadReadError(w, err)
+			}
+			return
+		}
+
+		h.logger.Info("blob written",
+			zap.String("object_key", objectKey),
+			zap.Int64("size", res.Size))
+
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+}
+
+// blobParams возвращает параметры подписанной ссылки из строки запроса
+func blobParams(r *http.Request) map[string]string {
+	params := make(map[string]string)
+	for key, values := range r.URL.Query() {
+		if len(values) > 0 {
+			params[key] = values[0]
+		}
+	}
+	return params
+}
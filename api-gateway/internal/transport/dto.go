@@ -2,28 +2,159 @@ package transport
 
 // ==== UPLOAD TASK ====
 type UploadTaskRequest struct {
-	Filename   string `json:"filename"`
-	UploadedBy string `json:"uploaded_by"`
+	Filename     string `json:"filename"`
+	UploadedBy   string `json:"uploaded_by"`
+	CourseId     string `json:"course_id,omitempty"`
+	AssignmentId string `json:"assignment_id,omitempty"`
+	Size         int64  `json:"size"`
+	Sha256       string `json:"sha256"`
 }
 
 type UploadTaskResponse struct {
-	FileId    string `json:"file_id"`
-	UploadUrl string `json:"upload_url"`
+	FileId    string            `json:"file_id"`
+	UploadUrl string            `json:"upload_url"`
+	FormData  map[string]string `json:"form_data"`
+}
+
+// ==== UPLOAD TASK FILE ====
+type UploadTaskFileResponse struct {
+	FileId       string `json:"file_id"`
+	Size         int64  `json:"size"`
+	Status       string `json:"status"`
+	StatusReason string `json:"status_reason,omitempty"`
+}
+
+// ==== IMPORT ARCHIVE ====
+type ImportEntry struct {
+	Path       string `json:"path"`
+	UploadedBy string `json:"uploaded_by,omitempty"`
+	FileId     string `json:"file_id,omitempty"`
+	// imported, rejected, failed или skipped
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ImportArchiveResponse struct {
+	Imported int32         `json:"imported"`
+	Rejected int32         `json:"rejected"`
+	Failed   int32         `json:"failed"`
+	Skipped  int32         `json:"skipped"`
+	Entries  []ImportEntry `json:"entries"`
 }
 
 // ==== GET TASK ====
 type GetTaskResponse struct {
-	FileId     string `json:"file_id"`
-	Filename   string `json:"filename"`
-	Url        string `json:"url"`
-	UploadedBy string `json:"uploaded_by"`
-	UploadedAt string `json:"uploaded_at"`
+	FileId        string `json:"file_id"`
+	Filename      string `json:"filename"`
+	Url           string `json:"url"`
+	UploadedBy    string `json:"uploaded_by"`
+	UploadedAt    string `json:"uploaded_at"`
+	CourseId      string `json:"course_id,omitempty"`
+	AssignmentId  string `json:"assignment_id,omitempty"`
+	Status        string `json:"status"`
+	StatusReason  string `json:"status_reason,omitempty"`
+	Size          int64  `json:"size"`
+	Sha256        string `json:"sha256,omitempty"`
+	ContentType   string `json:"content_type,omitempty"`
+	ContentSha256 string `json:"content_sha256,omitempty"`
+	SubmissionId  string `json:"submission_id,omitempty"`
+	Version       int32  `json:"version,omitempty"`
+}
+
+// ==== LIST TASKS ====
+type TaskSummary struct {
+	FileId       string `json:"file_id"`
+	Filename     string `json:"filename"`
+	UploadedBy   string `json:"uploaded_by"`
+	UploadedAt   string `json:"uploaded_at"`
+	CourseId     string `json:"course_id,omitempty"`
+	AssignmentId string `json:"assignment_id,omitempty"`
+	Status       string `json:"status"`
+	Size         int64  `json:"size"`
+	ContentType  string `json:"content_type,omitempty"`
+	SubmissionId string `json:"submission_id,omitempty"`
+	Version      int32  `json:"version,omitempty"`
+}
+
+type ListTasksResponse struct {
+	Tasks      []TaskSummary `json:"tasks"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+// ==== SUBMISSIONS ====
+type GetSubmissionResponse struct {
+	SubmissionId  string        `json:"submission_id"`
+	UploadedBy    string        `json:"uploaded_by"`
+	CourseId      string        `json:"course_id,omitempty"`
+	AssignmentId  string        `json:"assignment_id"`
+	CreatedAt     string        `json:"created_at"`
+	LatestVersion int32         `json:"latest_version,omitempty"`
+	Versions      []TaskSummary `json:"versions"`
+}
+
+// LatestVersionResponse - последняя версия посылки; Report пуст, если анализ еще не завершен.
+// Report - *GetReportResponse или для студента *StudentReportResponse.
+type LatestVersionResponse struct {
+	SubmissionId string      `json:"submission_id"`
+	Version      int32       `json:"version"`
+	Task         TaskSummary `json:"task"`
+	Report       any         `json:"report"`
+}
+
+type ReportDiffSide struct {
+	Version              int32   `json:"version"`
+	FileId               string  `json:"file_id"`
+	ReportVersion        int32   `json:"report_version"`
+	IsPlagiarism         bool    `json:"is_plagiarism"`
+	PlagiarismPercentage float64 `json:"plagiarism_percentage"`
+}
+
+type SourceDiff struct {
+	TaskId         string  `json:"task_id,omitempty"`
+	ObjectKey      string  `json:"object_key"`
+	FromSimilarity float64 `json:"from_similarity"`
+	ToSimilarity   float64 `json:"to_similarity"`
+	Delta          float64 `json:"delta"`
+}
+
+// ReportDiffResponse - разница между последними отчетами двух версий посылки.
+// Неизменившиеся источники не перечисляются.
+type ReportDiffResponse struct {
+	SubmissionId    string         `json:"submission_id"`
+	From            ReportDiffSide `json:"from"`
+	To              ReportDiffSide `json:"to"`
+	PercentageDelta float64        `json:"percentage_delta"`
+	VerdictChanged  bool           `json:"verdict_changed"`
+	SourcesAdded    []ReportSource `json:"sources_added"`
+	SourcesRemoved  []ReportSource `json:"sources_removed"`
+	SourcesChanged  []SourceDiff   `json:"sources_changed"`
+}
+
+// ==== DELETE TASK ====
+type DeleteTaskResponse struct {
+	FileId  string `json:"file_id"`
+	PurgeAt string `json:"purge_at"`
+}
+
+// ==== USER DATA ====
+
+// ExportMatch - совпадение, в котором работа пользователя найдена источником в отчете другой задачи.
+// Другая задача и ее автор в выгрузку не попадают.
+type ExportMatch struct {
+	TaskId       string  `json:"task_id"`
+	Similarity   float64 `json:"similarity"`
+	IsPlagiarism bool    `json:"is_plagiarism"`
+	MatchedAt    string  `json:"matched_at"`
+}
+
+type EraseUserDataResponse struct {
+	UploadedBy  string `json:"uploaded_by"`
+	TasksErased int32  `json:"tasks_erased"`
 }
 
 // ==== ANALYSE TASK ====
 type AnalyzeTaskRequest struct {
-	TaskId   string `json:"task_id"`
-	Filename string `json:"filename"`
+	TaskId string `json:"task_id"`
 }
 
 type AnalyzeTaskResponse struct {
@@ -32,7 +163,212 @@ type AnalyzeTaskResponse struct {
 
 // ==== GET REPORT ====
 type GetReportResponse struct {
+	TaskId               string         `json:"task_id"`
+	IsPlagiarism         bool           `json:"is_plagiarism"`
+	PlagiarismPercentage float64        `json:"plagiarism_percentage"`
+	ReportId             string         `json:"report_id"`
+	Version              int32          `json:"version"`
+	AlgorithmVersion     string         `json:"algorithm_version"`
+	Policy               ReportPolicy   `json:"policy"`
+	CorpusSnapshotAt     string         `json:"corpus_snapshot_at"`
+	CreatedAt            string         `json:"created_at"`
+	Sources              []ReportSource `json:"sources"`
+	AssignmentId         string         `json:"assignment_id,omitempty"`
+	DuplicateOf          string         `json:"duplicate_of,omitempty"`
+}
+
+type ReportPolicy struct {
+	Threshold float64 `json:"threshold"`
+}
+
+type ReportSource struct {
+	TaskId           string            `json:"task_id,omitempty"`
+	ObjectKey        string            `json:"object_key"`
+	Similarity       float64           `json:"similarity"`
+	AssignmentId     string            `json:"assignment_id,omitempty"`
+	MatchedFragments []MatchedFragment `json:"matched_fragments,omitempty"`
+}
+
+// MatchedFragment - фрагмент проверенного файла, найденный в источнике: байты [start, end) файла
+type MatchedFragment struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// StudentReportResponse - отчет для студента: источники обезличены, чужие работы и их авторы не раскрываются
+type StudentReportResponse struct {
+	TaskId               string                `json:"task_id"`
+	IsPlagiarism         bool                  `json:"is_plagiarism"`
+	PlagiarismPercentage float64               `json:"plagiarism_percentage"`
+	ReportId             string                `json:"report_id"`
+	Version              int32                 `json:"version"`
+	Policy               ReportPolicy          `json:"policy"`
+	CreatedAt            string                `json:"created_at"`
+	AssignmentId         string                `json:"assignment_id,omitempty"`
+	ExactDuplicate       bool                  `json:"exact_duplicate"`
+	Sources              []StudentReportSource `json:"sources"`
+}
+
+// StudentReportSource - обезличенный источник: номер в отчете, задание относительно проверенной работы
+// (same_assignment, other_assignment или unknown), сходство и совпавшие фрагменты работы самого студента
+type StudentReportSource struct {
+	Label            string            `json:"label"`
+	Position         int               `json:"position"`
+	Scope            string            `json:"scope"`
+	Similarity       float64           `json:"similarity"`
+	MatchedFragments []MatchedFragment `json:"matched_fragments"`
+}
+
+// ==== LIST REPORTS ====
+type ReportListItem struct {
 	TaskId               string  `json:"task_id"`
+	ReportId             string  `json:"report_id"`
+	Version              int32   `json:"version"`
+	Filename             string  `json:"filename"`
+	UploadedBy           string  `json:"uploaded_by"`
+	AssignmentId         string  `json:"assignment_id,omitempty"`
 	IsPlagiarism         bool    `json:"is_plagiarism"`
 	PlagiarismPercentage float64 `json:"plagiarism_percentage"`
+	DuplicateOf          string  `json:"duplicate_of,omitempty"`
+	CreatedAt            string  `json:"created_at"`
+}
+
+type ListReportsResponse struct {
+	Reports    []ReportListItem `json:"reports"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// ==== LIST REPORT VERSIONS ====
+type ReportVersion struct {
+	ReportId             string       `json:"report_id"`
+	Version              int32        `json:"version"`
+	AlgorithmVersion     string       `json:"algorithm_version"`
+	Policy               ReportPolicy `json:"policy"`
+	IsPlagiarism         bool         `json:"is_plagiarism"`
+	PlagiarismPercentage float64      `json:"plagiarism_percentage"`
+	CorpusSnapshotAt     string       `json:"corpus_snapshot_at"`
+	CreatedAt            string       `json:"created_at"`
+	DuplicateOf          string       `json:"duplicate_of,omitempty"`
+}
+
+type ListReportVersionsResponse struct {
+	TaskId   string          `json:"task_id"`
+	Versions []ReportVersion `json:"versions"`
+}
+
+// ==== REPORT EVENTS ====
+type ReportEvent struct {
+	TaskId               string  `json:"task_id"`
+	Stage                string  `json:"stage"`
+	Compared             int32   `json:"compared"`
+	Total                int32   `json:"total"`
+	IsPlagiarism         bool    `json:"is_plagiarism,omitempty"`
+	PlagiarismPercentage float64 `json:"plagiarism_percentage,omitempty"`
+	Message              string  `json:"message,omitempty"`
+	Timestamp            string  `json:"timestamp"`
+}
+
+// ==== BULK REANALYSIS ====
+type StartBulkReanalysisRequest struct {
+	AssignmentId  string `json:"assignment_id"`
+	CreatedFrom   string `json:"created_from"`
+	CreatedTo     string `json:"created_to"`
+	RatePerMinute int32  `json:"rate_per_minute"`
+}
+
+type BulkReanalysisJob struct {
+	JobId               string `json:"job_id"`
+	AssignmentId        string `json:"assignment_id,omitempty"`
+	CreatedFrom         string `json:"created_from,omitempty"`
+	CreatedTo           string `json:"created_to,omitempty"`
+	RatePerMinute       int32  `json:"rate_per_minute"`
+	Status              string `json:"status"`
+	Total               int32  `json:"total"`
+	Processed           int32  `json:"processed"`
+	Failed              int32  `json:"failed"`
+	Skipped             int32  `json:"skipped"`
+	FlippedToPlagiarism int32  `json:"flipped_to_plagiarism"`
+	FlippedToClean      int32  `json:"flipped_to_clean"`
+	Error               string `json:"error,omitempty"`
+	CreatedAt           string `json:"created_at"`
+	StartedAt           string `json:"started_at,omitempty"`
+	FinishedAt          string `json:"finished_at,omitempty"`
+}
+
+// ==== RETENTION ====
+type RunRetentionRequest struct {
+	DryRun bool `json:"dry_run"`
+}
+
+type RetentionItem struct {
+	FileId      string `json:"file_id"`
+	CourseId    string `json:"course_id,omitempty"`
+	UploadedAt  string `json:"uploaded_at"`
+	RetainUntil string `json:"retain_until"`
+	Error       string `json:"error,omitempty"`
+}
+
+type RetentionRun struct {
+	RunId      string          `json:"run_id"`
+	DryRun     bool            `json:"dry_run"`
+	Expired    int32           `json:"expired"`
+	Purged     int32           `json:"purged"`
+	Failed     int32           `json:"failed"`
+	StartedAt  string          `json:"started_at"`
+	FinishedAt string          `json:"finished_at"`
+	Items      []RetentionItem `json:"items"`
+}
+
+// ==== RECONCILIATION ====
+type RunReconciliationRequest struct {
+	DryRun bool `json:"dry_run"`
+}
+
+type ReconciliationItem struct {
+	Kind      string `json:"kind"`
+	FileId    string `json:"file_id,omitempty"`
+	ObjectKey string `json:"object_key"`
+	Error     string `json:"error,omitempty"`
+}
+
+type ReconciliationRun struct {
+	DryRun         bool                 `json:"dry_run"`
+	StrayAction    string               `json:"stray_action"`
+	ExpiredUploads int32                `json:"expired_uploads"`
+	ResumedUploads int32                `json:"resumed_uploads"`
+	MissingObjects int32                `json:"missing_objects"`
+	StrayObjects   int32                `json:"stray_objects"`
+	Failed         int32                `json:"failed"`
+	StartedAt      string               `json:"started_at"`
+	FinishedAt     string               `json:"finished_at"`
+	Items          []ReconciliationItem `json:"items"`
+}
+
+// ==== ENCRYPTION ====
+type KeyRotation struct {
+	Checked   int32 `json:"checked"`
+	Rewrapped int32 `json:"rewrapped"`
+	Failed    int32 `json:"failed"`
+}
+
+// ==== QUOTAS ====
+type UserUsage struct {
+	UploadedBy string `json:"uploaded_by"`
+	Uploads    int64  `json:"uploads"`
+	Bytes      int64  `json:"bytes"`
+}
+
+type AssignmentUsage struct {
+	AssignmentId string `json:"assignment_id"`
+	Bytes        int64  `json:"bytes"`
+}
+
+type QuotaUsage struct {
+	Window             string           `json:"window"`
+	MaxFileSize        int64            `json:"max_file_size"`
+	MaxUploads         int64            `json:"max_uploads"`
+	MaxUserBytes       int64            `json:"max_user_bytes"`
+	MaxAssignmentBytes int64            `json:"max_assignment_bytes"`
+	User               *UserUsage       `json:"user,omitempty"`
+	Assignment         *AssignmentUsage `json:"assignment,omitempty"`
 }
@@ -1,25 +1,44 @@
 package transport
 
 import (
+	"api-gateway/internal/auth"
+	"api-gateway/internal/config"
 	"api-gateway/internal/infrastructure/analysis"
 	"api-gateway/internal/infrastructure/storing"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	analysispb "analysis-service/pkg/api"
+	storingpb "storing-service/pkg/api"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
 )
 
+const maxFormFieldSize = 1 << 10
+
+// uploadStatusRejected - статус задачи, файл которой не прошел проверку в storing-service
+const uploadStatusRejected = "rejected"
+
 type Handler struct {
 	analysisClient *analysis.Client
 	storingClient  *storing.Client
+	uploadCfg      *config.UploadConfig
 	logger         *zap.Logger
 }
 
-func NewHandler(analysisClient *analysis.Client, storingClient *storing.Client, logger *zap.Logger) *Handler {
+func NewHandler(analysisClient *analysis.Client, storingClient *storing.Client, uploadCfg *config.UploadConfig, logger *zap.Logger) *Handler {
 	return &Handler{
 		analysisClient: analysisClient,
 		storingClient:  storingClient,
+		uploadCfg:      uploadCfg,
 		logger:         logger,
 	}
 }
@@ -32,11 +51,18 @@ func (h *Handler) UploadTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	uploadedBy, ok := h.resolveUploader(w, r, req.UploadedBy)
+	if !ok {
+		return
+	}
+
 	h.logger.Info("upload task request",
 		zap.String("filename", req.Filename),
-		zap.String("uploaded_by", req.UploadedBy))
+		zap.String("uploaded_by", uploadedBy),
+		zap.String("assignment_id", req.AssignmentId),
+		zap.Int64("size", req.Size))
 
-	res, err := h.storingClient.UploadTask(r.Context(), req.Filename, req.UploadedBy)
+	res, err := h.storingClient.UploadTask(r.Context(), req.Filename, uploadedBy, req.CourseId, req.AssignmentId, req.Size, req.Sha256)
 	if err != nil {
 		h.logger.Error("failed to upload task", zap.Error(err))
 		handleGRPCError(w, err)
@@ -46,6 +72,7 @@ func (h *Handler) UploadTask(w http.ResponseWriter, r *http.Request) {
 	resp := &UploadTaskResponse{
 		FileId:    res.FileId,
 		UploadUrl: res.UploadUrl,
+		FormData:  res.FormData,
 	}
 
 	h.logger.Info("upload task success",
@@ -59,6 +86,149 @@ func (h *Handler) UploadTask(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// UploadTaskFile принимает файл в multipart/form-data и передает его в storing-service потоком,
+// не буферизуя целиком. Текстовые поля формы должны идти до части file.
+// Если файл не прошел проверку, возвращается 422 с причиной отказа.
+func (h *Handler) UploadTaskFile(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, h.uploadCfg.MaxSize)
+
+	// Загрузка большого файла может идти дольше ReadTimeout сервера
+	_ = http.NewResponseController(w).SetReadDeadline(time.Time{})
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		h.logger.Warn("invalid multipart upload request", zap.Error(err))
+		http.Error(w, "multipart/form-data body is required", http.StatusBadRequest)
+		return
+	}
+
+	fields := make(map[string]string)
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			h.logger.Warn("multipart upload request without file")
+			http.Error(w, "file is required", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			h.logger.Warn("failed to read multipart part", zap.Error(err))
+			h.writeUploadReadError(w, err)
+			return
+		}
+
+		if part.FormName() != "file" {
+			value, err := io.ReadAll(io.LimitReader(part, maxFormFieldSize))
+			if err != nil {
+				h.logger.Warn("failed to read form field",
+					zap.String("field", part.FormName()),
+					zap.Error(err))
+				h.writeUploadReadError(w, err)
+				return
+			}
+			fields[part.FormName()] = string(value)
+			continue
+		}
+
+		var size int64
+		if fields["size"] != "" {
+			size, err = strconv.ParseInt(fields["size"], 10, 64)
+			if err != nil {
+				h.logger.Warn("invalid size field", zap.String("size", fields["size"]))
+				http.Error(w, "size must be an integer", http.StatusBadRequest)
+				return
+			}
+		}
+
+		uploadedBy, ok := h.resolveUploader(w, r, fields["uploaded_by"])
+		if !ok {
+			return
+		}
+
+		filename := part.FileName()
+		h.logger.Info("upload task file request",
+			zap.String("filename", filename),
+			zap.String("uploaded_by", uploadedBy),
+			zap.String("assignment_id", fields["assignment_id"]))
+
+		res, err := h.storingClient.UploadTaskStream(r.Context(), filename, uploadedBy, fields["course_id"], fields["assignment_id"], size, fields["sha256"], part)
+		if err != nil {
+			h.logger.Error("failed to upload task file",
+				zap.String("filename", filename),
+				zap.Error(err))
+			if _, ok := status.FromError(err); ok {
+				handleGRPCError(w, err)
+			} else {
+				h.writeUploadReadError(w, err)
+			}
+			return
+		}
+
+		resp := &UploadTaskFileResponse{
+			FileId:       res.FileId,
+			Size:         res.Size,
+			Status:       res.Status,
+			StatusReason: res.StatusReason,
+		}
+
+		code := http.StatusOK
+		if res.Status == uploadStatusRejected {
+			code = http.StatusUnprocessableEntity
+		}
+
+		h.logger.Info("upload task file processed",
+			zap.String("file_id", res.FileId),
+			zap.Int64("size", res.Size),
+			zap.String("status", res.Status))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			h.logger.Error("failed to encode upload task file response", zap.Error(err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+}
+
+// resolveUploader определяет uploaded_by загрузки по аутентифицированному клиенту: пользователь загружает
+// только от своего имени (uploaded_by из запроса можно не передавать), сервис по API-ключу - от имени
+// пользователя из запроса
+func (h *Handler) resolveUploader(w http.ResponseWriter, r *http.Request, requested string) (string, bool) {
+	principal, ok := auth.FromContext(r.Context())
+	if !ok {
+		h.logger.Error("upload request without authenticated principal")
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return "", false
+	}
+
+	if principal.Kind == auth.KindService {
+		if requested == "" {
+			h.logger.Warn("service upload without uploaded_by", zap.String("service", principal.Subject))
+			http.Error(w, "uploaded_by is required for service uploads", http.StatusBadRequest)
+			return "", false
+		}
+		return requested, true
+	}
+
+	if requested != "" && requested != principal.Subject {
+		h.logger.Warn("upload on behalf of another user rejected",
+			zap.String("subject", principal.Subject),
+			zap.String("uploaded_by", requested))
+		writeForbidden(w, "uploaded_by must match the authenticated user")
+		return "", false
+	}
+	return principal.Subject, true
+}
+
+func (h *Handler) writeUploadReadError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		http.Error(w, fmt.Sprintf("file is too large, limit is %d bytes", maxBytesErr.Limit), http.StatusRequestEntityTooLarge)
+		return
+	}
+	http.Error(w, "failed to read upload body", http.StatusBadRequest)
+}
+
 func (h *Handler) GetTask(w http.ResponseWriter, r *http.Request) {
 	taskId := chi.URLParam(r, "task_id")
 	if taskId == "" {
@@ -69,19 +239,27 @@ func (h *Handler) GetTask(w http.ResponseWriter, r *http.Request) {
 
 	h.logger.Info("get task request", zap.String("task_id", taskId))
 
-	res, err := h.storingClient.GetTask(r.Context(), taskId)
-	if err != nil {
-		h.logger.Error("failed to get task", zap.String("task_id", taskId), zap.Error(err))
-		handleGRPCError(w, err)
+	res, ok := h.authorizeTask(w, r, taskId)
+	if !ok {
 		return
 	}
 
 	resp := &GetTaskResponse{
-		FileId:     res.FileId,
-		Filename:   res.Filename,
-		Url:        res.Url,
-		UploadedBy: res.UploadedBy,
-		UploadedAt: res.UploadedAt,
+		FileId:        res.FileId,
+		Filename:      res.Filename,
+		Url:           res.Url,
+		UploadedBy:    res.UploadedBy,
+		UploadedAt:    res.UploadedAt,
+		CourseId:      res.CourseId,
+		AssignmentId:  res.AssignmentId,
+		Status:        res.Status,
+		StatusReason:  res.StatusReason,
+		Size:          res.Size,
+		Sha256:        res.Sha256,
+		ContentType:   res.ContentType,
+		ContentSha256: res.ContentSha256,
+		SubmissionId:  res.SubmissionId,
+		Version:       res.Version,
 	}
 
 	h.logger.Info("get task success", zap.String("task_id", taskId))
@@ -94,6 +272,111 @@ func (h *Handler) GetTask(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// DeleteTask удаляет задачу; файл и отчеты удаляются окончательно в момент purge_at
+func (h *Handler) DeleteTask(w http.ResponseWriter, r *http.Request) {
+	taskId := chi.URLParam(r, "task_id")
+	if taskId == "" {
+		h.logger.Warn("delete task request without task_id")
+		http.Error(w, "task_id is required", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("delete task request", zap.String("task_id", taskId))
+
+	if _, ok := h.authorizeTask(w, r, taskId); !ok {
+		return
+	}
+
+	res, err := h.storingClient.DeleteTask(r.Context(), taskId)
+	if err != nil {
+		h.logger.Error("failed to delete task", zap.String("task_id", taskId), zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	resp := &DeleteTaskResponse{
+		FileId:  taskId,
+		PurgeAt: res.PurgeAt,
+	}
+
+	h.logger.Info("delete task success",
+		zap.String("task_id", taskId),
+		zap.String("purge_at", res.PurgeAt))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode delete task response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ListTasks возвращает страницу задач. Фильтры и курсор передаются query-параметрами,
+// следующая страница запрашивается с next_cursor из ответа.
+func (h *Handler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit := 0
+	if v := query.Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			h.logger.Warn("invalid list tasks limit", zap.String("limit", v))
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	req := &storingpb.ListTasksRequest{
+		UploadedBy:   query.Get("uploaded_by"),
+		CourseId:     query.Get("course_id"),
+		AssignmentId: query.Get("assignment_id"),
+		Status:       query.Get("status"),
+		CreatedFrom:  query.Get("created_from"),
+		CreatedTo:    query.Get("created_to"),
+		Filename:     query.Get("filename"),
+		Order:        query.Get("order"),
+		Limit:        int32(limit),
+		Cursor:       query.Get("cursor"),
+	}
+
+	if !h.scopeListTasks(w, r, req) {
+		return
+	}
+
+	h.logger.Info("list tasks request",
+		zap.String("uploaded_by", req.UploadedBy),
+		zap.String("assignment_id", req.AssignmentId),
+		zap.String("status", req.Status),
+		zap.Int32("limit", req.Limit))
+
+	res, err := h.storingClient.ListTasks(r.Context(), req)
+	if err != nil {
+		h.logger.Error("failed to list tasks", zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	tasks := make([]TaskSummary, 0, len(res.Tasks))
+	for _, task := range res.Tasks {
+		tasks = append(tasks, toTaskSummary(task))
+	}
+
+	resp := &ListTasksResponse{
+		Tasks:      tasks,
+		NextCursor: res.NextCursor,
+	}
+
+	h.logger.Info("list tasks success", zap.Int("count", len(tasks)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode list tasks response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func (h *Handler) AnalyseTask(w http.ResponseWriter, r *http.Request) {
 	req := &AnalyzeTaskRequest{}
 	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
@@ -102,11 +385,25 @@ func (h *Handler) AnalyseTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.logger.Info("analyse task request",
-		zap.String("task_id", req.TaskId),
-		zap.String("filename", req.Filename))
+	h.logger.Info("analyse task request", zap.String("task_id", req.TaskId))
 
-	res, err := h.analysisClient.AnalyseTask(r.Context(), req.TaskId, req.Filename)
+	if _, ok := h.authorizeTask(w, r, req.TaskId); !ok {
+		return
+	}
+
+	// Параметры собираются storing-service так же, как при загрузке: предыдущие версии посылки
+	// исключаются из сравнения, побайтовый дубликат чужой работы дает отчет без сравнения
+	input, err := h.storingClient.GetAnalysisInput(r.Context(), req.TaskId)
+	if err != nil {
+		h.logger.Error("failed to get analysis input",
+			zap.String("task_id", req.TaskId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	res, err := h.analysisClient.AnalyseTask(r.Context(), req.TaskId, input.ObjectKey, input.AssignmentId, input.UploadedBy, input.CourseId,
+		input.DuplicateOf, input.DuplicateOfObjectKey, input.ExcludeTaskIds)
 	if err != nil {
 		h.logger.Error("failed to analyse task",
 			zap.String("task_id", req.TaskId),
@@ -131,6 +428,89 @@ func (h *Handler) AnalyseTask(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *Handler) CancelAnalysis(w http.ResponseWriter, r *http.Request) {
+	taskId := chi.URLParam(r, "task_id")
+	if taskId == "" {
+		h.logger.Warn("cancel analysis request without task_id")
+		http.Error(w, "task_id is required", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("cancel analysis request", zap.String("task_id", taskId))
+
+	if _, ok := h.authorizeTask(w, r, taskId); !ok {
+		return
+	}
+
+	res, err := h.analysisClient.CancelAnalysis(r.Context(), taskId)
+	if err != nil {
+		h.logger.Error("failed to cancel analysis",
+			zap.String("task_id", taskId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	resp := &AnalyzeTaskResponse{
+		Status: res.Status,
+	}
+
+	h.logger.Info("cancel analysis success", zap.String("task_id", taskId))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode cancel analysis response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) ReanalyseTask(w http.ResponseWriter, r *http.Request) {
+	taskId := chi.URLParam(r, "task_id")
+	if taskId == "" {
+		h.logger.Warn("reanalyse task request without task_id")
+		http.Error(w, "task_id is required", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("reanalyse task request", zap.String("task_id", taskId))
+
+	if _, ok := h.authorizeTask(w, r, taskId); !ok {
+		return
+	}
+
+	input, err := h.storingClient.GetAnalysisInput(r.Context(), taskId)
+	if err != nil {
+		h.logger.Error("failed to get analysis input",
+			zap.String("task_id", taskId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	res, err := h.analysisClient.ReanalyseTask(r.Context(), taskId, input.ObjectKey, input.UploadedBy, input.CourseId, input.ExcludeTaskIds)
+	if err != nil {
+		h.logger.Error("failed to reanalyse task",
+			zap.String("task_id", taskId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	resp := &AnalyzeTaskResponse{
+		Status: res.Status,
+	}
+
+	h.logger.Info("reanalyse task started", zap.String("task_id", taskId))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode reanalyse task response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func (h *Handler) GetReport(w http.ResponseWriter, r *http.Request) {
 	taskId := chi.URLParam(r, "task_id")
 	if taskId == "" {
@@ -139,9 +519,26 @@ func (h *Handler) GetReport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.logger.Info("get report request", zap.String("task_id", taskId))
+	version := 0
+	if v := r.URL.Query().Get("version"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			h.logger.Warn("invalid report version", zap.String("version", v))
+			http.Error(w, "version must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		version = parsed
+	}
+
+	h.logger.Info("get report request",
+		zap.String("task_id", taskId),
+		zap.Int("version", version))
+
+	if _, ok := h.authorizeTask(w, r, taskId); !ok {
+		return
+	}
 
-	res, err := h.analysisClient.GetReport(r.Context(), taskId)
+	res, err := h.analysisClient.GetReport(r.Context(), taskId, int32(version))
 	if err != nil {
 		h.logger.Error("failed to get report",
 			zap.String("task_id", taskId),
@@ -150,11 +547,7 @@ func (h *Handler) GetReport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := &GetReportResponse{
-		TaskId:               res.TaskId,
-		IsPlagiarism:         res.IsPlagiarism,
-		PlagiarismPercentage: float64(res.PlagiarismPercentage),
-	}
+	resp := reportView(r, res)
 
 	h.logger.Info("get report success",
 		zap.String("task_id", taskId),
@@ -169,6 +562,226 @@ func (h *Handler) GetReport(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func toTaskSummary(task *storingpb.TaskSummary) TaskSummary {
+	return TaskSummary{
+		FileId:       task.FileId,
+		Filename:     task.Filename,
+		UploadedBy:   task.UploadedBy,
+		UploadedAt:   task.UploadedAt,
+		CourseId:     task.CourseId,
+		AssignmentId: task.AssignmentId,
+		Status:       task.Status,
+		Size:         task.Size,
+		ContentType:  task.ContentType,
+		SubmissionId: task.SubmissionId,
+		Version:      task.Version,
+	}
+}
+
+func toReportResponse(res *analysispb.GetReportResponse) *GetReportResponse {
+	sources := make([]ReportSource, 0, len(res.Sources))
+	for _, source := range res.Sources {
+		sources = append(sources, ReportSource{
+			TaskId:           source.TaskId,
+			ObjectKey:        source.ObjectKey,
+			Similarity:       float64(source.Similarity),
+			AssignmentId:     source.AssignmentId,
+			MatchedFragments: toMatchedFragments(source.MatchedSpans),
+		})
+	}
+
+	return &GetReportResponse{
+		TaskId:               res.TaskId,
+		IsPlagiarism:         res.IsPlagiarism,
+		PlagiarismPercentage: float64(res.PlagiarismPercentage),
+		ReportId:             res.ReportId,
+		Version:              res.Version,
+		AlgorithmVersion:     res.AlgorithmVersion,
+		Policy:               toReportPolicy(res.Policy),
+		CorpusSnapshotAt:     res.CorpusSnapshotAt,
+		CreatedAt:            res.CreatedAt,
+		Sources:              sources,
+		AssignmentId:         res.AssignmentId,
+		DuplicateOf:          res.DuplicateOf,
+	}
+}
+
+func (h *Handler) ListReportVersions(w http.ResponseWriter, r *http.Request) {
+	taskId := chi.URLParam(r, "task_id")
+	if taskId == "" {
+		h.logger.Warn("list report versions request without task_id")
+		http.Error(w, "task_id is required", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("list report versions request", zap.String("task_id", taskId))
+
+	if _, ok := h.authorizeTask(w, r, taskId); !ok {
+		return
+	}
+
+	res, err := h.analysisClient.ListReportVersions(r.Context(), taskId)
+	if err != nil {
+		h.logger.Error("failed to list report versions",
+			zap.String("task_id", taskId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	versions := make([]ReportVersion, 0, len(res.Versions))
+	for _, v := range res.Versions {
+		versions = append(versions, ReportVersion{
+			ReportId:             v.ReportId,
+			Version:              v.Version,
+			AlgorithmVersion:     v.AlgorithmVersion,
+			Policy:               toReportPolicy(v.Policy),
+			IsPlagiarism:         v.IsPlagiarism,
+			PlagiarismPercentage: float64(v.PlagiarismPercentage),
+			CorpusSnapshotAt:     v.CorpusSnapshotAt,
+			CreatedAt:            v.CreatedAt,
+			DuplicateOf:          v.DuplicateOf,
+		})
+	}
+
+	resp := &ListReportVersionsResponse{
+		TaskId:   res.TaskId,
+		Versions: versions,
+	}
+
+	h.logger.Info("list report versions success",
+		zap.String("task_id", taskId),
+		zap.Int("count", len(versions)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode list report versions response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func toReportPolicy(policy *analysispb.ReportPolicy) ReportPolicy {
+	return ReportPolicy{
+		Threshold: float64(policy.GetThreshold()),
+	}
+}
+
+// ListReports возвращает страницу последних версий отчетов, дополненных метаданными задач
+// (имя файла, автор) из storing-service.
+func (h *Handler) ListReports(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	req := &analysispb.ListReportsRequest{
+		AssignmentId: query.Get("assignment_id"),
+		CreatedFrom:  query.Get("created_from"),
+		CreatedTo:    query.Get("created_to"),
+		Cursor:       query.Get("cursor"),
+	}
+
+	if v := query.Get("is_plagiarism"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			h.logger.Warn("invalid is_plagiarism filter", zap.String("is_plagiarism", v))
+			http.Error(w, "is_plagiarism must be true or false", http.StatusBadRequest)
+			return
+		}
+		req.IsPlagiarism = &parsed
+	}
+
+	minPercentage, err := parseOptionalFloat(query.Get("min_percentage"))
+	if err != nil {
+		h.logger.Warn("invalid min_percentage filter", zap.String("min_percentage", query.Get("min_percentage")))
+		http.Error(w, "min_percentage must be a number", http.StatusBadRequest)
+		return
+	}
+	req.MinPercentage = minPercentage
+
+	maxPercentage, err := parseOptionalFloat(query.Get("max_percentage"))
+	if err != nil {
+		h.logger.Warn("invalid max_percentage filter", zap.String("max_percentage", query.Get("max_percentage")))
+		http.Error(w, "max_percentage must be a number", http.StatusBadRequest)
+		return
+	}
+	req.MaxPercentage = maxPercentage
+
+	if v := query.Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			h.logger.Warn("invalid list reports limit", zap.String("limit", v))
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		req.Limit = int32(parsed)
+	}
+
+	h.logger.Info("list reports request",
+		zap.String("assignment_id", req.AssignmentId),
+		zap.Int32("limit", req.Limit))
+
+	res, err := h.analysisClient.ListReports(r.Context(), req)
+	if err != nil {
+		h.logger.Error("failed to list reports", zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	tasks := make(map[string]*storingpb.TaskSummary, len(res.Reports))
+	if len(res.Reports) > 0 {
+		taskIds := make([]string, 0, len(res.Reports))
+		for _, report := range res.Reports {
+			taskIds = append(taskIds, report.TaskId)
+		}
+
+		taskRes, err := h.storingClient.ListTasks(r.Context(), &storingpb.ListTasksRequest{
+			FileIds: taskIds,
+			Limit:   int32(len(taskIds)),
+		})
+		if err != nil {
+			h.logger.Error("failed to get tasks for reports", zap.Error(err))
+			handleGRPCError(w, err)
+			return
+		}
+		for _, task := range taskRes.Tasks {
+			tasks[task.FileId] = task
+		}
+	}
+
+	reports := make([]ReportListItem, 0, len(res.Reports))
+	for _, report := range res.Reports {
+		item := ReportListItem{
+			TaskId:               report.TaskId,
+			ReportId:             report.ReportId,
+			Version:              report.Version,
+			AssignmentId:         report.AssignmentId,
+			IsPlagiarism:         report.IsPlagiarism,
+			PlagiarismPercentage: float64(report.PlagiarismPercentage),
+			DuplicateOf:          report.DuplicateOf,
+			CreatedAt:            report.CreatedAt,
+		}
+		// Задача может отсутствовать в storing-service, отчет возвращается без ее метаданных
+		if task, ok := tasks[report.TaskId]; ok {
+			item.Filename = task.Filename
+			item.UploadedBy = task.UploadedBy
+		}
+		reports = append(reports, item)
+	}
+
+	resp := &ListReportsResponse{
+		Reports:    reports,
+		NextCursor: res.NextCursor,
+	}
+
+	h.logger.Info("list reports success", zap.Int("count", len(reports)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode list reports response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func (h *Handler) GetWordCloud(w http.ResponseWriter, r *http.Request) {
 	taskId := chi.URLParam(r, "task_id")
 	if taskId == "" {
@@ -179,6 +792,10 @@ func (h *Handler) GetWordCloud(w http.ResponseWriter, r *http.Request) {
 
 	h.logger.Info("get word cloud request", zap.String("task_id", taskId))
 
+	if _, ok := h.authorizeTask(w, r, taskId); !ok {
+		return
+	}
+
 	fileContent, err := h.storingClient.GetFileContent(r.Context(), taskId)
 	if err != nil {
 		h.logger.Error("failed to get file content",
@@ -213,3 +830,408 @@ func (h *Handler) GetWordCloud(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
+
+func (h *Handler) GetReportEvents(w http.ResponseWriter, r *http.Request) {
+	taskId := chi.URLParam(r, "task_id")
+	if taskId == "" {
+		h.logger.Warn("get report events request without task_id")
+		http.Error(w, "task_id is required", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("get report events request", zap.String("task_id", taskId))
+
+	// Проверяем задачу и доступ до открытия потока, чтобы вернуть 400/403/404 обычным HTTP ответом
+	if _, ok := h.authorizeTask(w, r, taskId); !ok {
+		return
+	}
+
+	stream, err := h.analysisClient.WatchAnalysis(r.Context(), taskId)
+	if err != nil {
+		h.logger.Error("failed to watch analysis",
+			zap.String("task_id", taskId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	type received struct {
+		event *analysispb.AnalysisEvent
+		err   error
+	}
+
+	updates := make(chan received)
+	go func() {
+		for {
+			event, err := stream.Recv()
+			select {
+			case updates <- received{event: event, err: err}:
+			case <-r.Context().Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	sse := newSSEWriter(w)
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case upd := <-updates:
+			if errors.Is(upd.err, io.EOF) {
+				h.logger.Info("report events stream finished", zap.String("task_id", taskId))
+				return
+			}
+			if upd.err != nil {
+				h.logger.Error("report events stream failed",
+					zap.String("task_id", taskId),
+					zap.Error(upd.err))
+				st, _ := status.FromError(upd.err)
+				_, code := mapGRPCCodeToHTTP(st.Code())
+				_ = sse.Event("error", ErrorResponse{Error: st.Message(), Code: code, Message: st.Message()})
+				return
+			}
+
+			event := toReportEvent(upd.event)
+			if err := sse.Event(event.Stage, event); err != nil {
+				h.logger.Warn("failed to write report event",
+					zap.String("task_id", taskId),
+					zap.Error(err))
+				return
+			}
+		case <-keepAlive.C:
+			if err := sse.KeepAlive(); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			h.logger.Info("report events client disconnected", zap.String("task_id", taskId))
+			return
+		}
+	}
+}
+
+func toReportEvent(event *analysispb.AnalysisEvent) *ReportEvent {
+	return &ReportEvent{
+		TaskId:               event.TaskId,
+		Stage:                strings.ToLower(strings.TrimPrefix(event.Stage.String(), "ANALYSIS_STAGE_")),
+		Compared:             event.Compared,
+		Total:                event.Total,
+		IsPlagiarism:         event.IsPlagiarism,
+		PlagiarismPercentage: float64(event.PlagiarismPercentage),
+		Message:              event.Message,
+		Timestamp:            event.Timestamp,
+	}
+}
+
+func (h *Handler) StartBulkReanalysis(w http.ResponseWriter, r *http.Request) {
+	req := &StartBulkReanalysisRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		h.logger.Warn("failed to decode bulk reanalysis request", zap.Error(err))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("start bulk reanalysis request",
+		zap.String("assignment_id", req.AssignmentId),
+		zap.String("created_from", req.CreatedFrom),
+		zap.String("created_to", req.CreatedTo),
+		zap.Int32("rate_per_minute", req.RatePerMinute))
+
+	res, err := h.analysisClient.StartBulkReanalysis(r.Context(), req.AssignmentId, req.CreatedFrom, req.CreatedTo, req.RatePerMinute)
+	if err != nil {
+		h.logger.Error("failed to start bulk reanalysis", zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	resp := toBulkReanalysisJob(res.Job)
+
+	h.logger.Info("bulk reanalysis started", zap.String("job_id", resp.JobId))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode bulk reanalysis response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) GetBulkReanalysis(w http.ResponseWriter, r *http.Request) {
+	jobId := chi.URLParam(r, "job_id")
+	if jobId == "" {
+		h.logger.Warn("get bulk reanalysis request without job_id")
+		http.Error(w, "job_id is required", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("get bulk reanalysis request", zap.String("job_id", jobId))
+
+	res, err := h.analysisClient.GetBulkReanalysis(r.Context(), jobId)
+	if err != nil {
+		h.logger.Error("failed to get bulk reanalysis",
+			zap.String("job_id", jobId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	resp := toBulkReanalysisJob(res.Job)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode bulk reanalysis response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) CancelBulkReanalysis(w http.ResponseWriter, r *http.Request) {
+	jobId := chi.URLParam(r, "job_id")
+	if jobId == "" {
+		h.logger.Warn("cancel bulk reanalysis request without job_id")
+		http.Error(w, "job_id is required", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("cancel bulk reanalysis request", zap.String("job_id", jobId))
+
+	res, err := h.analysisClient.CancelBulkReanalysis(r.Context(), jobId)
+	if err != nil {
+		h.logger.Error("failed to cancel bulk reanalysis",
+			zap.String("job_id", jobId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	resp := &AnalyzeTaskResponse{
+		Status: res.Status,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode cancel bulk reanalysis response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RunRetention запускает политику хранения storing-service; пустое тело - обычный запуск
+func (h *Handler) RunRetention(w http.ResponseWriter, r *http.Request) {
+	req := &RunRetentionRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("failed to decode retention request", zap.Error(err))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("run retention request", zap.Bool("dry_run", req.DryRun))
+
+	res, err := h.storingClient.RunRetention(r.Context(), req.DryRun)
+	if err != nil {
+		h.logger.Error("failed to run retention", zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	resp := &RetentionRun{
+		RunId:      res.RunId,
+		DryRun:     res.DryRun,
+		Expired:    res.Expired,
+		Purged:     res.Purged,
+		Failed:     res.Failed,
+		StartedAt:  res.StartedAt,
+		FinishedAt: res.FinishedAt,
+		Items:      make([]RetentionItem, 0, len(res.Items)),
+	}
+	for _, item := range res.Items {
+		resp.Items = append(resp.Items, RetentionItem{
+			FileId:      item.FileId,
+			CourseId:    item.CourseId,
+			UploadedAt:  item.UploadedAt,
+			RetainUntil: item.RetainUntil,
+			Error:       item.Error,
+		})
+	}
+
+	h.logger.Info("run retention success",
+		zap.String("run_id", res.RunId),
+		zap.Int32("expired", res.Expired),
+		zap.Int32("purged", res.Purged))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode retention response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RunReconciliation сверяет задачи storing-service с хранилищем; пустое тело - обычный запуск
+func (h *Handler) RunReconciliation(w http.ResponseWriter, r *http.Request) {
+	req := &RunReconciliationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("failed to decode reconciliation request", zap.Error(err))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("run reconciliation request", zap.Bool("dry_run", req.DryRun))
+
+	res, err := h.storingClient.RunReconciliation(r.Context(), req.DryRun)
+	if err != nil {
+		h.logger.Error("failed to run reconciliation", zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	resp := &ReconciliationRun{
+		DryRun:         res.DryRun,
+		StrayAction:    res.StrayAction,
+		ExpiredUploads: res.ExpiredUploads,
+		ResumedUploads: res.ResumedUploads,
+		MissingObjects: res.MissingObjects,
+		StrayObjects:   res.StrayObjects,
+		Failed:         res.Failed,
+		StartedAt:      res.StartedAt,
+		FinishedAt:     res.FinishedAt,
+		Items:          make([]ReconciliationItem, 0, len(res.Items)),
+	}
+	for _, item := range res.Items {
+		resp.Items = append(resp.Items, ReconciliationItem{
+			Kind:      item.Kind,
+			FileId:    item.FileId,
+			ObjectKey: item.ObjectKey,
+			Error:     item.Error,
+		})
+	}
+
+	h.logger.Info("run reconciliation success",
+		zap.Int32("expired_uploads", res.ExpiredUploads),
+		zap.Int32("missing_objects", res.MissingObjects),
+		zap.Int32("stray_objects", res.StrayObjects))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode reconciliation response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RotateEncryptionKeys перешифровывает ключи файлов активным мастер-ключом storing-service
+func (h *Handler) RotateEncryptionKeys(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("rotate encryption keys request")
+
+	res, err := h.storingClient.RotateEncryptionKeys(r.Context())
+	if err != nil {
+		h.logger.Error("failed to rotate encryption keys", zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	resp := &KeyRotation{
+		Checked:   res.Checked,
+		Rewrapped: res.Rewrapped,
+		Failed:    res.Failed,
+	}
+
+	h.logger.Info("rotate encryption keys success",
+		zap.Int32("checked", res.Checked),
+		zap.Int32("rewrapped", res.Rewrapped),
+		zap.Int32("failed", res.Failed))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode key rotation response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func toBulkReanalysisJob(job *analysispb.BulkReanalysisJob) *BulkReanalysisJob {
+	return &BulkReanalysisJob{
+		JobId:               job.GetJobId(),
+		AssignmentId:        job.GetAssignmentId(),
+		CreatedFrom:         job.GetCreatedFrom(),
+		CreatedTo:           job.GetCreatedTo(),
+		RatePerMinute:       job.GetRatePerMinute(),
+		Status:              job.GetStatus(),
+		Total:               job.GetTotal(),
+		Processed:           job.GetProcessed(),
+		Failed:              job.GetFailed(),
+		Skipped:             job.GetSkipped(),
+		FlippedToPlagiarism: job.GetFlippedToPlagiarism(),
+		FlippedToClean:      job.GetFlippedToClean(),
+		Error:               job.GetError(),
+		CreatedAt:           job.GetCreatedAt(),
+		StartedAt:           job.GetStartedAt(),
+		FinishedAt:          job.GetFinishedAt(),
+	}
+}
+
+func parseOptionalFloat(value string) (*float32, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return nil, err
+	}
+	f := float32(parsed)
+	return &f, nil
+}
+
+// GetQuotaUsage возвращает лимиты квот storing-service и использование их пользователем и (или) заданием
+func (h *Handler) GetQuotaUsage(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	uploadedBy := query.Get("uploaded_by")
+	assignmentId := query.Get("assignment_id")
+
+	h.logger.Info("get quota usage request",
+		zap.String("uploaded_by", uploadedBy),
+		zap.String("assignment_id", assignmentId))
+
+	res, err := h.storingClient.GetQuotaUsage(r.Context(), uploadedBy, assignmentId)
+	if err != nil {
+		h.logger.Error("failed to get quota usage", zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	resp := &QuotaUsage{
+		Window:             res.Window,
+		MaxFileSize:        res.MaxFileSize,
+		MaxUploads:         res.MaxUploads,
+		MaxUserBytes:       res.MaxUserBytes,
+		MaxAssignmentBytes: res.MaxAssignmentBytes,
+	}
+	if res.User != nil {
+		resp.User = &UserUsage{
+			UploadedBy: res.User.UploadedBy,
+			Uploads:    res.User.Uploads,
+			Bytes:      res.User.Bytes,
+		}
+	}
+	if res.Assignment != nil {
+		resp.Assignment = &AssignmentUsage{
+			AssignmentId: res.Assignment.AssignmentId,
+			Bytes:        res.Assignment.Bytes,
+		}
+	}
+
+	h.logger.Info("get quota usage success",
+		zap.String("uploaded_by", uploadedBy),
+		zap.String("assignment_id", assignmentId))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode quota usage response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,130 @@
+package transport
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+)
+
+// maxManifestSize - максимальный размер CSV-манифеста импорта
+const maxManifestSize = 1 << 20
+
+// ImportArchive принимает ZIP-архив с работами в multipart/form-data и передает его в storing-service
+// потоком. Поля формы и часть manifest должны идти до части archive. Возвращает отчет по каждому
+// файлу архива; ошибки отдельных файлов не влияют на код ответа.
+func (h *Handler) ImportArchive(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, h.uploadCfg.ImportMaxSize)
+
+	// Загрузка и обработка архива может идти дольше таймаутов сервера
+	controller := http.NewResponseController(w)
+	_ = controller.SetReadDeadline(time.Time{})
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		h.logger.Warn("invalid multipart import request", zap.Error(err))
+		http.Error(w, "multipart/form-data body is required", http.StatusBadRequest)
+		return
+	}
+
+	fields := make(map[string]string)
+	var manifest []byte
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			h.logger.Warn("multipart import request without archive")
+			http.Error(w, "archive is required", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			h.logger.Warn("failed to read multipart part", zap.Error(err))
+			h.writeUploadReadError(w, err)
+			return
+		}
+
+		switch part.FormName() {
+		case "archive":
+		case "manifest":
+			manifest, err = io.ReadAll(io.LimitReader(part, maxManifestSize+1))
+			if err != nil {
+				h.logger.Warn("failed to read import manifest", zap.Error(err))
+				h.writeUploadReadError(w, err)
+				return
+			}
+			if len(manifest) > maxManifestSize {
+				h.logger.Warn("import manifest is too large")
+				http.Error(w, "manifest is too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			continue
+		default:
+			value, err := io.ReadAll(io.LimitReader(part, maxFormFieldSize))
+			if err != nil {
+				h.logger.Warn("failed to read form field",
+					zap.String("field", part.FormName()),
+					zap.Error(err))
+				h.writeUploadReadError(w, err)
+				return
+			}
+			fields[part.FormName()] = string(value)
+			continue
+		}
+
+		h.logger.Info("import archive request",
+			zap.String("filename", part.FileName()),
+			zap.String("course_id", fields["course_id"]),
+			zap.String("assignment_id", fields["assignment_id"]),
+			zap.Bool("manifest", len(manifest) > 0))
+
+		if !h.authorizeCourse(w, r, fields["course_id"]) {
+			return
+		}
+
+		res, err := h.storingClient.ImportArchive(r.Context(), fields["course_id"], fields["assignment_id"], fields["naming_rule"], manifest, part)
+		if err != nil {
+			h.logger.Error("failed to import archive", zap.Error(err))
+			if _, ok := status.FromError(err); ok {
+				handleGRPCError(w, err)
+			} else {
+				h.writeUploadReadError(w, err)
+			}
+			return
+		}
+
+		resp := &ImportArchiveResponse{
+			Imported: res.Imported,
+			Rejected: res.Rejected,
+			Failed:   res.Failed,
+			Skipped:  res.Skipped,
+			Entries:  make([]ImportEntry, 0, len(res.Entries)),
+		}
+		for _, entry := range res.Entries {
+			resp.Entries = append(resp.Entries, ImportEntry{
+				Path:       entry.Path,
+				UploadedBy: entry.UploadedBy,
+				FileId:     entry.FileId,
+				Status:     entry.Status,
+				Error:      entry.Error,
+			})
+		}
+
+		h.logger.Info("import archive processed",
+			zap.Int32("imported", res.Imported),
+			zap.Int32("rejected", res.Rejected),
+			zap.Int32("failed", res.Failed),
+			zap.Int32("skipped", res.Skipped))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			h.logger.Error("failed to encode import archive response", zap.Error(err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+}
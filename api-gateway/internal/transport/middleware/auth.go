@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"api-gateway/internal/auth"
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// AuthMiddleware пропускает только запросы с действительным JWT или API-ключом и сохраняет
+// аутентифицированного клиента в контексте запроса
+func AuthMiddleware(authenticator *auth.Authenticator, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := authenticator.Authenticate(r)
+			if err != nil {
+				logger.Warn("request authentication failed",
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.String("remote_addr", r.RemoteAddr),
+					zap.Error(err))
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error": err.Error(),
+					"code":  "UNAUTHENTICATED",
+				})
+				return
+			}
+
+			logger.Debug("request authenticated",
+				zap.String("subject", principal.Subject),
+				zap.String("kind", string(principal.Kind)),
+				zap.String("role", string(principal.Role)),
+				zap.String("tenant", principal.Tenant))
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// RequireRole пропускает только клиентов с ролью не ниже role
+func RequireRole(role auth.Role, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := auth.FromContext(r.Context())
+			if !ok || !principal.HasRole(role) {
+				logger.Warn("request rejected by role",
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.String("required_role", string(role)))
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error": "role " + string(role) + " is required",
+					"code":  "PERMISSION_DENIED",
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package transport
 
 import (
+	"api-gateway/internal/auth"
+
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
@@ -9,7 +11,7 @@ import (
 	middleware2 "github.com/go-chi/chi/v5/middleware"
 )
 
-func NewRouter(handler *Handler, logger *zap.Logger) *chi.Mux {
+func NewRouter(handler *Handler, authenticator *auth.Authenticator, logger *zap.Logger) *chi.Mux {
 	router := chi.NewRouter()
 
 	router.Use(middleware2.RequestID)
@@ -19,11 +21,51 @@ func NewRouter(handler *Handler, logger *zap.Logger) *chi.Mux {
 	SetupSwaggerRoutes(router)
 
 	router.Route("/api/v1", func(r chi.Router) {
-		r.Post("/task", handler.UploadTask)
-		r.Get("/task/{task_id}", handler.GetTask)
-		r.Post("/analyse", handler.AnalyseTask)
-		r.Get("/report/{task_id}", handler.GetReport)
-		r.Get("/wordcloud/{task_id}", handler.GetWordCloud)
+		// Ссылки на файлы подписаны storing-service и проверяются по подписи
+		r.Get("/blobs/*", handler.ReadBlob)
+		r.Post("/blobs/*", handler.WriteBlob)
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware1.AuthMiddleware(authenticator, logger))
+
+			r.Post("/task", handler.UploadTask)
+			r.Post("/task/upload", handler.UploadTaskFile)
+			r.Get("/task/{task_id}", handler.GetTask)
+			r.Delete("/task/{task_id}", handler.DeleteTask)
+			r.Get("/tasks", handler.ListTasks)
+			r.Get("/submissions/{submission_id}", handler.GetSubmission)
+			r.Get("/submissions/{submission_id}/latest", handler.GetLatestVersion)
+			r.Get("/submissions/{submission_id}/diff", handler.DiffVersions)
+			r.Get("/report/{task_id}", handler.GetReport)
+			r.Get("/report/{task_id}/events", handler.GetReportEvents)
+			r.Get("/report/{task_id}/versions", handler.ListReportVersions)
+			r.Get("/reports", handler.ListReports)
+			r.Get("/wordcloud/{task_id}", handler.GetWordCloud)
+			r.Get("/users/{user_id}/export", handler.ExportUserData)
+			r.Delete("/users/{user_id}", handler.EraseUserData)
+
+			// Импорт и запуск анализа - действия преподавателя, доступ к курсу проверяет обработчик
+			r.Group(func(r chi.Router) {
+				r.Use(middleware1.RequireRole(auth.RoleTeacher, logger))
+
+				r.Post("/task/import", handler.ImportArchive)
+				r.Post("/analyse", handler.AnalyseTask)
+				r.Post("/analyse/{task_id}/cancel", handler.CancelAnalysis)
+				r.Post("/analyse/{task_id}/reanalyse", handler.ReanalyseTask)
+			})
+
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(middleware1.RequireRole(auth.RoleAdmin, logger))
+
+				r.Post("/reanalysis", handler.StartBulkReanalysis)
+				r.Get("/reanalysis/{job_id}", handler.GetBulkReanalysis)
+				r.Post("/reanalysis/{job_id}/cancel", handler.CancelBulkReanalysis)
+				r.Post("/retention/run", handler.RunRetention)
+				r.Post("/reconciliation/run", handler.RunReconciliation)
+				r.Post("/encryption/rotate", handler.RotateEncryptionKeys)
+				r.Get("/quotas/usage", handler.GetQuotaUsage)
+			})
+		})
 	})
 	return router
 }
@@ -0,0 +1,92 @@
+package transport
+
+import (
+	"api-gateway/internal/auth"
+	"fmt"
+	"net/http"
+
+	analysispb "analysis-service/pkg/api"
+)
+
+// Положение источника относительно задания проверенной работы
+const (
+	sourceScopeSameAssignment  = "same_assignment"
+	sourceScopeOtherAssignment = "other_assignment"
+	sourceScopeUnknown         = "unknown"
+)
+
+// servesStudentReport сообщает, что клиенту отдается обезличенный отчет: студент видит степень
+// совпадения своей работы, но не чужие работы, их файлы и авторов
+func servesStudentReport(r *http.Request) bool {
+	principal, ok := auth.FromContext(r.Context())
+	return !ok || !principal.HasRole(auth.RoleTeacher)
+}
+
+// reportView возвращает отчет в представлении, соответствующем роли клиента
+func reportView(r *http.Request, res *analysispb.GetReportResponse) any {
+	if servesStudentReport(r) {
+		return toStudentReportResponse(res)
+	}
+	return toReportResponse(res)
+}
+
+// toStudentReportResponse строит отчет для студента. Задачи и файлы источников analysis-service
+// студенту не передает, проекция их не использует, источник описывается только номером и заданием.
+func toStudentReportResponse(res *analysispb.GetReportResponse) *StudentReportResponse {
+	sources := make([]StudentReportSource, 0, len(res.Sources))
+	for i, source := range res.Sources {
+		scope := sourceScope(res.AssignmentId, source.AssignmentId)
+		sources = append(sources, StudentReportSource{
+			Label:            sourceLabel(i+1, scope),
+			Position:         i + 1,
+			Scope:            scope,
+			Similarity:       float64(source.Similarity),
+			MatchedFragments: toMatchedFragments(source.MatchedSpans),
+		})
+	}
+
+	return &StudentReportResponse{
+		TaskId:               res.TaskId,
+		IsPlagiarism:         res.IsPlagiarism,
+		PlagiarismPercentage: float64(res.PlagiarismPercentage),
+		ReportId:             res.ReportId,
+		Version:              res.Version,
+		Policy:               toReportPolicy(res.Policy),
+		CreatedAt:            res.CreatedAt,
+		AssignmentId:         res.AssignmentId,
+		ExactDuplicate:       res.ExactDuplicate,
+		Sources:              sources,
+	}
+}
+
+// toMatchedFragments переводит фрагменты проверенного файла. Они ссылаются только на текст самой
+// работы, поэтому отдаются и студенту; текст источника не передается.
+func toMatchedFragments(spans []*analysispb.MatchedSpan) []MatchedFragment {
+	fragments := make([]MatchedFragment, 0, len(spans))
+	for _, span := range spans {
+		fragments = append(fragments, MatchedFragment{Start: span.Start, End: span.End})
+	}
+	return fragments
+}
+
+func sourceScope(assignmentId, sourceAssignmentId string) string {
+	switch {
+	case assignmentId == "" || sourceAssignmentId == "":
+		return sourceScopeUnknown
+	case assignmentId == sourceAssignmentId:
+		return sourceScopeSameAssignment
+	default:
+		return sourceScopeOtherAssignment
+	}
+}
+
+func sourceLabel(position int, scope string) string {
+	switch scope {
+	case sourceScopeSameAssignment:
+		return fmt.Sprintf("Submission #%d, same assignment", position)
+	case sourceScopeOtherAssignment:
+		return fmt.Sprintf("Submission #%d, other assignment", position)
+	default:
+		return fmt.Sprintf("Submission #%d", position)
+	}
+}
@@ -0,0 +1,330 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	storingpb "storing-service/pkg/api"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetSubmission возвращает посылку со всеми версиями по возрастанию номера
+func (h *Handler) GetSubmission(w http.ResponseWriter, r *http.Request) {
+	submissionId := chi.URLParam(r, "submission_id")
+	if submissionId == "" {
+		h.logger.Warn("get submission request without submission_id")
+		http.Error(w, "submission_id is required", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("get submission request", zap.String("submission_id", submissionId))
+
+	res, err := h.storingClient.GetSubmission(r.Context(), submissionId)
+	if err != nil {
+		h.logger.Error("failed to get submission",
+			zap.String("submission_id", submissionId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+	if !h.authorizeSubmission(w, r, res) {
+		return
+	}
+
+	versions := make([]TaskSummary, 0, len(res.Versions))
+	for _, task := range res.Versions {
+		versions = append(versions, toTaskSummary(task))
+	}
+
+	resp := &GetSubmissionResponse{
+		SubmissionId: res.SubmissionId,
+		UploadedBy:   res.UploadedBy,
+		CourseId:     res.CourseId,
+		AssignmentId: res.AssignmentId,
+		CreatedAt:    res.CreatedAt,
+		Versions:     versions,
+	}
+	if len(versions) > 0 {
+		resp.LatestVersion = versions[len(versions)-1].Version
+	}
+
+	h.logger.Info("get submission success",
+		zap.String("submission_id", submissionId),
+		zap.Int("versions", len(versions)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode get submission response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// GetLatestVersion возвращает последнюю версию посылки вместе с ее отчетом.
+// Если анализ последней версии еще не завершен, report равен null.
+func (h *Handler) GetLatestVersion(w http.ResponseWriter, r *http.Request) {
+	submissionId := chi.URLParam(r, "submission_id")
+	if submissionId == "" {
+		h.logger.Warn("get latest version request without submission_id")
+		http.Error(w, "submission_id is required", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("get latest version request", zap.String("submission_id", submissionId))
+
+	submission, err := h.storingClient.GetSubmission(r.Context(), submissionId)
+	if err != nil {
+		h.logger.Error("failed to get submission",
+			zap.String("submission_id", submissionId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+	if !h.authorizeSubmission(w, r, submission) {
+		return
+	}
+	if len(submission.Versions) == 0 {
+		h.logger.Warn("submission has no versions", zap.String("submission_id", submissionId))
+		http.Error(w, "submission has no versions", http.StatusNotFound)
+		return
+	}
+
+	latest := submission.Versions[len(submission.Versions)-1]
+	resp := &LatestVersionResponse{
+		SubmissionId: submission.SubmissionId,
+		Version:      latest.Version,
+		Task:         toTaskSummary(latest),
+	}
+
+	report, err := h.analysisClient.GetReport(r.Context(), latest.FileId, 0)
+	switch {
+	case err == nil:
+		resp.Report = reportView(r, report)
+	case status.Code(err) == codes.NotFound:
+		h.logger.Debug("latest version has no report yet", zap.String("task_id", latest.FileId))
+	default:
+		h.logger.Error("failed to get latest version report",
+			zap.String("task_id", latest.FileId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	h.logger.Info("get latest version success",
+		zap.String("submission_id", submissionId),
+		zap.Int32("version", latest.Version))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode get latest version response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// DiffVersions сравнивает последние отчеты двух версий посылки. По умолчанию to - последняя версия,
+// from - предшествующая ей версия; удаленные версии пропускаются.
+func (h *Handler) DiffVersions(w http.ResponseWriter, r *http.Request) {
+	submissionId := chi.URLParam(r, "submission_id")
+	if submissionId == "" {
+		h.logger.Warn("diff versions request without submission_id")
+		http.Error(w, "submission_id is required", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	fromVersion, err := parseVersionParam(query.Get("from"))
+	if err != nil {
+		h.logger.Warn("invalid from version", zap.String("from", query.Get("from")))
+		http.Error(w, "from must be a positive integer", http.StatusBadRequest)
+		return
+	}
+	toVersion, err := parseVersionParam(query.Get("to"))
+	if err != nil {
+		h.logger.Warn("invalid to version", zap.String("to", query.Get("to")))
+		http.Error(w, "to must be a positive integer", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("diff versions request",
+		zap.String("submission_id", submissionId),
+		zap.Int32("from", fromVersion),
+		zap.Int32("to", toVersion))
+
+	submission, err := h.storingClient.GetSubmission(r.Context(), submissionId)
+	if err != nil {
+		h.logger.Error("failed to get submission",
+			zap.String("submission_id", submissionId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+	if !h.authorizeSubmission(w, r, submission) {
+		return
+	}
+
+	from, to, err := selectDiffVersions(submission.Versions, fromVersion, toVersion)
+	if err != nil {
+		h.logger.Warn("versions to diff not found",
+			zap.String("submission_id", submissionId),
+			zap.Error(err))
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	fromReport, err := h.analysisClient.GetReport(r.Context(), from.FileId, 0)
+	if err != nil {
+		h.logger.Error("failed to get report of from version",
+			zap.String("task_id", from.FileId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+	toReport, err := h.analysisClient.GetReport(r.Context(), to.FileId, 0)
+	if err != nil {
+		h.logger.Error("failed to get report of to version",
+			zap.String("task_id", to.FileId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	resp := diffReports(toReportResponse(fromReport), toReportResponse(toReport))
+	resp.SubmissionId = submission.SubmissionId
+	resp.From.Version = from.Version
+	resp.To.Version = to.Version
+
+	h.logger.Info("diff versions success",
+		zap.String("submission_id", submissionId),
+		zap.Int32("from", from.Version),
+		zap.Int32("to", to.Version),
+		zap.Float64("percentage_delta", resp.PercentageDelta))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode diff versions response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// parseVersionParam разбирает номер версии, 0 - параметр не задан
+func parseVersionParam(value string) (int32, error) {
+	if value == "" {
+		return 0, nil
+	}
+	version, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid version %q", value)
+	}
+	return int32(version), nil
+}
+
+// selectDiffVersions выбирает версии для сравнения из списка по возрастанию номера
+func selectDiffVersions(versions []*storingpb.TaskSummary, fromVersion, toVersion int32) (*storingpb.TaskSummary, *storingpb.TaskSummary, error) {
+	toIdx := len(versions) - 1
+	if toVersion != 0 {
+		toIdx = -1
+		for i, v := range versions {
+			if v.Version == toVersion {
+				toIdx = i
+			}
+		}
+		if toIdx < 0 {
+			return nil, nil, fmt.Errorf("version %d not found", toVersion)
+		}
+	}
+
+	fromIdx := toIdx - 1
+	if fromVersion != 0 {
+		fromIdx = -1
+		for i, v := range versions {
+			if v.Version == fromVersion {
+				fromIdx = i
+			}
+		}
+		if fromIdx < 0 {
+			return nil, nil, fmt.Errorf("version %d not found", fromVersion)
+		}
+	}
+
+	if toIdx < 0 || fromIdx < 0 || fromIdx == toIdx {
+		return nil, nil, fmt.Errorf("submission has no previous version to compare")
+	}
+	return versions[fromIdx], versions[toIdx], nil
+}
+
+// diffReports сравнивает источники отчетов по задаче-источнику (для обезличенных источников - по ключу объекта).
+// Отчет хранит ограниченное число источников, поэтому удаленный источник мог лишь выпасть из списка.
+// Студенту источники приходят обезличенными и не сравниваются: остаются вердикт и изменение процента.
+func diffReports(from, to *GetReportResponse) *ReportDiffResponse {
+	resp := &ReportDiffResponse{
+		From: ReportDiffSide{
+			FileId:               from.TaskId,
+			ReportVersion:        from.Version,
+			IsPlagiarism:         from.IsPlagiarism,
+			PlagiarismPercentage: from.PlagiarismPercentage,
+		},
+		To: ReportDiffSide{
+			FileId:               to.TaskId,
+			ReportVersion:        to.Version,
+			IsPlagiarism:         to.IsPlagiarism,
+			PlagiarismPercentage: to.PlagiarismPercentage,
+		},
+		PercentageDelta: to.PlagiarismPercentage - from.PlagiarismPercentage,
+		VerdictChanged:  from.IsPlagiarism != to.IsPlagiarism,
+		SourcesAdded:    []ReportSource{},
+		SourcesRemoved:  []ReportSource{},
+		SourcesChanged:  []SourceDiff{},
+	}
+
+	sourceKey := func(source ReportSource) string {
+		if source.TaskId != "" {
+			return source.TaskId
+		}
+		return source.ObjectKey
+	}
+
+	previous := make(map[string]ReportSource, len(from.Sources))
+	for _, source := range from.Sources {
+		if key := sourceKey(source); key != "" {
+			previous[key] = source
+		}
+	}
+
+	for _, source := range to.Sources {
+		key := sourceKey(source)
+		if key == "" {
+			continue
+		}
+		old, ok := previous[key]
+		delete(previous, key)
+		switch {
+		case !ok:
+			resp.SourcesAdded = append(resp.SourcesAdded, source)
+		case old.Similarity != source.Similarity:
+			resp.SourcesChanged = append(resp.SourcesChanged, SourceDiff{
+				TaskId:         source.TaskId,
+				ObjectKey:      source.ObjectKey,
+				FromSimilarity: old.Similarity,
+				ToSimilarity:   source.Similarity,
+				Delta:          source.Similarity - old.Similarity,
+			})
+		}
+	}
+
+	// Порядок удаленных источников - как в исходном отчете
+	for _, source := range from.Sources {
+		if _, ok := previous[sourceKey(source)]; ok {
+			resp.SourcesRemoved = append(resp.SourcesRemoved, source)
+		}
+	}
+
+	return resp
+}
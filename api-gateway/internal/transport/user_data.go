@@ -0,0 +1,289 @@
+package transport
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+
+	analysispb "analysis-service/pkg/api"
+	storingpb "storing-service/pkg/api"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportPageSize - размер страницы ListTasks при выгрузке задач пользователя
+const exportPageSize = 200
+
+// userExport - данные пользователя, собранные до начала записи архива
+type userExport struct {
+	tasks   []GetTaskResponse
+	reports map[string][]GetReportResponse
+	matches []ExportMatch
+}
+
+// ExportUserData выгружает ZIP-архив со всеми задачами пользователя: метаданные (tasks.json),
+// файлы (files/), все версии отчетов (reports/) и совпадения работ пользователя в чужих отчетах (matches.json).
+// Источники в отчетах пользователя обезличены: чужие задачи в выгрузку не попадают.
+func (h *Handler) ExportUserData(w http.ResponseWriter, r *http.Request) {
+	userId := chi.URLParam(r, "user_id")
+	if userId == "" {
+		h.logger.Warn("export user data request without user_id")
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("export user data request", zap.String("user_id", userId))
+
+	if !h.authorizeUser(w, r, userId) {
+		return
+	}
+
+	// Метаданные собираются заранее: после начала записи архива ошибку уже нельзя вернуть статусом
+	export, err := h.collectUserExport(r.Context(), userId)
+	if err != nil {
+		h.logger.Error("failed to collect user data",
+			zap.String("user_id", userId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%s.zip"`, userId))
+	w.WriteHeader(http.StatusOK)
+
+	if err := h.writeUserExport(r.Context(), w, export); err != nil {
+		h.logger.Error("failed to write user data archive",
+			zap.String("user_id", userId),
+			zap.Error(err))
+		return
+	}
+
+	h.logger.Info("export user data success",
+		zap.String("user_id", userId),
+		zap.Int("tasks", len(export.tasks)),
+		zap.Int("matches", len(export.matches)))
+}
+
+func (h *Handler) collectUserExport(ctx context.Context, userId string) (*userExport, error) {
+	export := &userExport{reports: make(map[string][]GetReportResponse)}
+
+	var taskIds []string
+	cursor := ""
+	for {
+		page, err := h.storingClient.ListTasks(ctx, &storingpb.ListTasksRequest{
+			UploadedBy: userId,
+			Order:      "asc",
+			Limit:      exportPageSize,
+			Cursor:     cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range page.Tasks {
+			taskIds = append(taskIds, summary.FileId)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	for _, taskId := range taskIds {
+		task, err := h.storingClient.GetTask(ctx, taskId)
+		if err != nil {
+			return nil, err
+		}
+		export.tasks = append(export.tasks, GetTaskResponse{
+			FileId:        task.FileId,
+			Filename:      task.Filename,
+			UploadedBy:    task.UploadedBy,
+			UploadedAt:    task.UploadedAt,
+			CourseId:      task.CourseId,
+			AssignmentId:  task.AssignmentId,
+			Status:        task.Status,
+			StatusReason:  task.StatusReason,
+			Size:          task.Size,
+			Sha256:        task.Sha256,
+			ContentType:   task.ContentType,
+			ContentSha256: task.ContentSha256,
+			SubmissionId:  task.SubmissionId,
+			Version:       task.Version,
+		})
+
+		reports, err := h.collectTaskReports(ctx, taskId)
+		if err != nil {
+			return nil, err
+		}
+		if len(reports) > 0 {
+			export.reports[taskId] = reports
+		}
+	}
+
+	if len(taskIds) > 0 {
+		res, err := h.analysisClient.ListSourceMatches(ctx, taskIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range res.Matches {
+			export.matches = append(export.matches, ExportMatch{
+				TaskId:       match.SourceTaskId,
+				Similarity:   float64(match.Similarity),
+				IsPlagiarism: match.IsPlagiarism,
+				MatchedAt:    match.MatchedAt,
+			})
+		}
+	}
+
+	return export, nil
+}
+
+// collectTaskReports возвращает все версии отчета задачи; задача без отчета - пустой список
+func (h *Handler) collectTaskReports(ctx context.Context, taskId string) ([]GetReportResponse, error) {
+	versions, err := h.analysisClient.ListReportVersions(ctx, taskId)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]GetReportResponse, 0, len(versions.Versions))
+	for _, v := range versions.Versions {
+		report, err := h.analysisClient.GetReport(ctx, taskId, v.Version)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, toExportReport(report))
+	}
+	return reports, nil
+}
+
+func (h *Handler) writeUserExport(ctx context.Context, w http.ResponseWriter, export *userExport) error {
+	archive := zip.NewWriter(w)
+
+	if err := writeZipJSON(archive, "tasks.json", export.tasks); err != nil {
+		return err
+	}
+
+	for _, task := range export.tasks {
+		content, err := h.storingClient.GetFileContent(ctx, task.FileId)
+		if status.Code(err) == codes.NotFound {
+			// Файл еще не загружен по presigned URL
+			h.logger.Debug("task file not found, skipping", zap.String("task_id", task.FileId))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		f, err := archive.Create(path.Join("files", task.FileId+path.Ext(task.Filename)))
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(content.Content); err != nil {
+			return err
+		}
+	}
+
+	for _, task := range export.tasks {
+		reports, ok := export.reports[task.FileId]
+		if !ok {
+			continue
+		}
+		if err := writeZipJSON(archive, path.Join("reports", task.FileId+".json"), reports); err != nil {
+			return err
+		}
+	}
+
+	if err := writeZipJSON(archive, "matches.json", export.matches); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func writeZipJSON(archive *zip.Writer, name string, value any) error {
+	f, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// toExportReport преобразует отчет для выгрузки, оставляя у источников только процент схожести
+// и совпавшие фрагменты файла автора
+func toExportReport(res *analysispb.GetReportResponse) GetReportResponse {
+	sources := make([]ReportSource, 0, len(res.Sources))
+	for _, source := range res.Sources {
+		sources = append(sources, ReportSource{
+			Similarity:       float64(source.Similarity),
+			MatchedFragments: toMatchedFragments(source.MatchedSpans),
+		})
+	}
+
+	return GetReportResponse{
+		TaskId:               res.TaskId,
+		IsPlagiarism:         res.IsPlagiarism,
+		PlagiarismPercentage: float64(res.PlagiarismPercentage),
+		ReportId:             res.ReportId,
+		Version:              res.Version,
+		AlgorithmVersion:     res.AlgorithmVersion,
+		Policy:               toReportPolicy(res.Policy),
+		CorpusSnapshotAt:     res.CorpusSnapshotAt,
+		CreatedAt:            res.CreatedAt,
+		Sources:              sources,
+		AssignmentId:         res.AssignmentId,
+	}
+}
+
+// EraseUserData окончательно удаляет все задачи пользователя вместе с файлами и отчетами.
+// В отчетах других задач совпадения с удаленными работами сохраняются обезличенными.
+func (h *Handler) EraseUserData(w http.ResponseWriter, r *http.Request) {
+	userId := chi.URLParam(r, "user_id")
+	if userId == "" {
+		h.logger.Warn("erase user data request without user_id")
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("erase user data request", zap.String("user_id", userId))
+
+	if !h.authorizeUser(w, r, userId) {
+		return
+	}
+
+	res, err := h.storingClient.EraseUserData(r.Context(), userId)
+	if err != nil {
+		h.logger.Error("failed to erase user data",
+			zap.String("user_id", userId),
+			zap.Error(err))
+		handleGRPCError(w, err)
+		return
+	}
+
+	resp := &EraseUserDataResponse{
+		UploadedBy:  userId,
+		TasksErased: res.TasksErased,
+	}
+
+	h.logger.Info("erase user data success",
+		zap.String("user_id", userId),
+		zap.Int32("tasks_erased", res.TasksErased))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode erase user data response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -19,9 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	StoringService_UploadTask_FullMethodName     = "/storing.v1.StoringService/UploadTask"
-	StoringService_GetTask_FullMethodName        = "/storing.v1.StoringService/GetTask"
-	StoringService_GetFileContent_FullMethodName = "/storing.v1.StoringService/GetFileContent"
+	StoringService_UploadTask_FullMethodName           = "/storing.v1.StoringService/UploadTask"
+	StoringService_UploadTaskStream_FullMethodName     = "/storing.v1.StoringService/UploadTaskStream"
+	StoringService_ImportArchive_FullMethodName        = "/storing.v1.StoringService/ImportArchive"
+	StoringService_GetTask_FullMethodName              = "/storing.v1.StoringService/GetTask"
+	StoringService_ListTasks_FullMethodName            = "/storing.v1.StoringService/ListTasks"
+	StoringService_GetSubmission_FullMethodName        = "/storing.v1.StoringService/GetSubmission"
+	StoringService_GetAnalysisInput_FullMethodName     = "/storing.v1.StoringService/GetAnalysisInput"
+	StoringService_GetFileContent_FullMethodName       = "/storing.v1.StoringService/GetFileContent"
+	StoringService_DeleteTask_FullMethodName           = "/storing.v1.StoringService/DeleteTask"
+	StoringService_EraseUserData_FullMethodName        = "/storing.v1.StoringService/EraseUserData"
+	StoringService_RunRetention_FullMethodName         = "/storing.v1.StoringService/RunRetention"
+	StoringService_RunReconciliation_FullMethodName    = "/storing.v1.StoringService/RunReconciliation"
+	StoringService_RotateEncryptionKeys_FullMethodName = "/storing.v1.StoringService/RotateEncryptionKeys"
+	StoringService_GetQuotaUsage_FullMethodName        = "/storing.v1.StoringService/GetQuotaUsage"
+	StoringService_ReadBlob_FullMethodName             = "/storing.v1.StoringService/ReadBlob"
+	StoringService_WriteBlob_FullMethodName            = "/storing.v1.StoringService/WriteBlob"
 )
 
 // StoringServiceClient is the client API for StoringService service.
@@ -29,8 +42,21 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type StoringServiceClient interface {
 	UploadTask(ctx context.Context, in *UploadTaskRequest, opts ...grpc.CallOption) (*UploadTaskResponse, error)
+	UploadTaskStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadTaskStreamRequest, UploadTaskStreamResponse], error)
+	ImportArchive(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportArchiveRequest, ImportArchiveResponse], error)
 	GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskResponse, error)
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	GetSubmission(ctx context.Context, in *GetSubmissionRequest, opts ...grpc.CallOption) (*GetSubmissionResponse, error)
+	GetAnalysisInput(ctx context.Context, in *GetAnalysisInputRequest, opts ...grpc.CallOption) (*GetAnalysisInputResponse, error)
 	GetFileContent(ctx context.Context, in *GetFileContentRequest, opts ...grpc.CallOption) (*GetFileContentResponse, error)
+	DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*DeleteTaskResponse, error)
+	EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error)
+	RunRetention(ctx context.Context, in *RunRetentionRequest, opts ...grpc.CallOption) (*RunRetentionResponse, error)
+	RunReconciliation(ctx context.Context, in *RunReconciliationRequest, opts ...grpc.CallOption) (*RunReconciliationResponse, error)
+	RotateEncryptionKeys(ctx context.Context, in *RotateEncryptionKeysRequest, opts ...grpc.CallOption) (*RotateEncryptionKeysResponse, error)
+	GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error)
+	ReadBlob(ctx context.Context, in *ReadBlobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReadBlobResponse], error)
+	WriteBlob(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[WriteBlobRequest, WriteBlobResponse], error)
 }
 
 type storingServiceClient struct {
@@ -51,6 +77,32 @@ func (c *storingServiceClient) UploadTask(ctx context.Context, in *UploadTaskReq
 	return out, nil
 }
 
+func (c *storingServiceClient) UploadTaskStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadTaskStreamRequest, UploadTaskStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StoringService_ServiceDesc.Streams[0], StoringService_UploadTaskStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadTaskStreamRequest, UploadTaskStreamResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StoringService_UploadTaskStreamClient = grpc.ClientStreamingClient[UploadTaskStreamRequest, UploadTaskStreamResponse]
+
+func (c *storingServiceClient) ImportArchive(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportArchiveRequest, ImportArchiveResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StoringService_ServiceDesc.Streams[1], StoringService_ImportArchive_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportArchiveRequest, ImportArchiveResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StoringService_ImportArchiveClient = grpc.ClientStreamingClient[ImportArchiveRequest, ImportArchiveResponse]
+
 func (c *storingServiceClient) GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetTaskResponse)
@@ -61,6 +113,36 @@ func (c *storingServiceClient) GetTask(ctx context.Context, in *GetTaskRequest,
 	return out, nil
 }
 
+func (c *storingServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, StoringService_ListTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storingServiceClient) GetSubmission(ctx context.Context, in *GetSubmissionRequest, opts ...grpc.CallOption) (*GetSubmissionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSubmissionResponse)
+	err := c.cc.Invoke(ctx, StoringService_GetSubmission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storingServiceClient) GetAnalysisInput(ctx context.Context, in *GetAnalysisInputRequest, opts ...grpc.CallOption) (*GetAnalysisInputResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAnalysisInputResponse)
+	err := c.cc.Invoke(ctx, StoringService_GetAnalysisInput_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storingServiceClient) GetFileContent(ctx context.Context, in *GetFileContentRequest, opts ...grpc.CallOption) (*GetFileContentResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetFileContentResponse)
@@ -71,13 +153,118 @@ func (c *storingServiceClient) GetFileContent(ctx context.Context, in *GetFileCo
 	return out, nil
 }
 
+func (c *storingServiceClient) DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*DeleteTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTaskResponse)
+	err := c.cc.Invoke(ctx, StoringService_DeleteTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storingServiceClient) EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EraseUserDataResponse)
+	err := c.cc.Invoke(ctx, StoringService_EraseUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storingServiceClient) RunRetention(ctx context.Context, in *RunRetentionRequest, opts ...grpc.CallOption) (*RunRetentionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunRetentionResponse)
+	err := c.cc.Invoke(ctx, StoringService_RunRetention_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storingServiceClient) RunReconciliation(ctx context.Context, in *RunReconciliationRequest, opts ...grpc.CallOption) (*RunReconciliationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunReconciliationResponse)
+	err := c.cc.Invoke(ctx, StoringService_RunReconciliation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storingServiceClient) RotateEncryptionKeys(ctx context.Context, in *RotateEncryptionKeysRequest, opts ...grpc.CallOption) (*RotateEncryptionKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateEncryptionKeysResponse)
+	err := c.cc.Invoke(ctx, StoringService_RotateEncryptionKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storingServiceClient) GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuotaUsageResponse)
+	err := c.cc.Invoke(ctx, StoringService_GetQuotaUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storingServiceClient) ReadBlob(ctx context.Context, in *ReadBlobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReadBlobResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StoringService_ServiceDesc.Streams[2], StoringService_ReadBlob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReadBlobRequest, ReadBlobResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StoringService_ReadBlobClient = grpc.ServerStreamingClient[ReadBlobResponse]
+
+func (c *storingServiceClient) WriteBlob(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[WriteBlobRequest, WriteBlobResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StoringService_ServiceDesc.Streams[3], StoringService_WriteBlob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WriteBlobRequest, WriteBlobResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StoringService_WriteBlobClient = grpc.ClientStreamingClient[WriteBlobRequest, WriteBlobResponse]
+
 // StoringServiceServer is the server API for StoringService service.
 // All implementations must embed UnimplementedStoringServiceServer
 // for forward compatibility.
 type StoringServiceServer interface {
 	UploadTask(context.Context, *UploadTaskRequest) (*UploadTaskResponse, error)
+	UploadTaskStream(grpc.ClientStreamingServer[UploadTaskStreamRequest, UploadTaskStreamResponse]) error
+	ImportArchive(grpc.ClientStreamingServer[ImportArchiveRequest, ImportArchiveResponse]) error
 	GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error)
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	GetSubmission(context.Context, *GetSubmissionRequest) (*GetSubmissionResponse, error)
+	GetAnalysisInput(context.Context, *GetAnalysisInputRequest) (*GetAnalysisInputResponse, error)
 	GetFileContent(context.Context, *GetFileContentRequest) (*GetFileContentResponse, error)
+	DeleteTask(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error)
+	EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error)
+	RunRetention(context.Context, *RunRetentionRequest) (*RunRetentionResponse, error)
+	RunReconciliation(context.Context, *RunReconciliationRequest) (*RunReconciliationResponse, error)
+	RotateEncryptionKeys(context.Context, *RotateEncryptionKeysRequest) (*RotateEncryptionKeysResponse, error)
+	GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error)
+	ReadBlob(*ReadBlobRequest, grpc.ServerStreamingServer[ReadBlobResponse]) error
+	WriteBlob(grpc.ClientStreamingServer[WriteBlobRequest, WriteBlobResponse]) error
 	mustEmbedUnimplementedStoringServiceServer()
 }
 
@@ -91,12 +278,51 @@ type UnimplementedStoringServiceServer struct{}
 func (UnimplementedStoringServiceServer) UploadTask(context.Context, *UploadTaskRequest) (*UploadTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UploadTask not implemented")
 }
+func (UnimplementedStoringServiceServer) UploadTaskStream(grpc.ClientStreamingServer[UploadTaskStreamRequest, UploadTaskStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadTaskStream not implemented")
+}
+func (UnimplementedStoringServiceServer) ImportArchive(grpc.ClientStreamingServer[ImportArchiveRequest, ImportArchiveResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportArchive not implemented")
+}
 func (UnimplementedStoringServiceServer) GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
 }
+func (UnimplementedStoringServiceServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedStoringServiceServer) GetSubmission(context.Context, *GetSubmissionRequest) (*GetSubmissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubmission not implemented")
+}
+func (UnimplementedStoringServiceServer) GetAnalysisInput(context.Context, *GetAnalysisInputRequest) (*GetAnalysisInputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnalysisInput not implemented")
+}
 func (UnimplementedStoringServiceServer) GetFileContent(context.Context, *GetFileContentRequest) (*GetFileContentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFileContent not implemented")
 }
+func (UnimplementedStoringServiceServer) DeleteTask(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
+}
+func (UnimplementedStoringServiceServer) EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUserData not implemented")
+}
+func (UnimplementedStoringServiceServer) RunRetention(context.Context, *RunRetentionRequest) (*RunRetentionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunRetention not implemented")
+}
+func (UnimplementedStoringServiceServer) RunReconciliation(context.Context, *RunReconciliationRequest) (*RunReconciliationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunReconciliation not implemented")
+}
+func (UnimplementedStoringServiceServer) RotateEncryptionKeys(context.Context, *RotateEncryptionKeysRequest) (*RotateEncryptionKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateEncryptionKeys not implemented")
+}
+func (UnimplementedStoringServiceServer) GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuotaUsage not implemented")
+}
+func (UnimplementedStoringServiceServer) ReadBlob(*ReadBlobRequest, grpc.ServerStreamingServer[ReadBlobResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ReadBlob not implemented")
+}
+func (UnimplementedStoringServiceServer) WriteBlob(grpc.ClientStreamingServer[WriteBlobRequest, WriteBlobResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WriteBlob not implemented")
+}
 func (UnimplementedStoringServiceServer) mustEmbedUnimplementedStoringServiceServer() {}
 func (UnimplementedStoringServiceServer) testEmbeddedByValue()                        {}
 
@@ -136,6 +362,20 @@ func _StoringService_UploadTask_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StoringService_UploadTaskStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StoringServiceServer).UploadTaskStream(&grpc.GenericServerStream[UploadTaskStreamRequest, UploadTaskStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StoringService_UploadTaskStreamServer = grpc.ClientStreamingServer[UploadTaskStreamRequest, UploadTaskStreamResponse]
+
+func _StoringService_ImportArchive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StoringServiceServer).ImportArchive(&grpc.GenericServerStream[ImportArchiveRequest, ImportArchiveResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StoringService_ImportArchiveServer = grpc.ClientStreamingServer[ImportArchiveRequest, ImportArchiveResponse]
+
 func _StoringService_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTaskRequest)
 	if err := dec(in); err != nil {
@@ -154,6 +394,60 @@ func _StoringService_GetTask_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StoringService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoringServiceServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoringService_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoringServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoringService_GetSubmission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubmissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoringServiceServer).GetSubmission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoringService_GetSubmission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoringServiceServer).GetSubmission(ctx, req.(*GetSubmissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoringService_GetAnalysisInput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAnalysisInputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoringServiceServer).GetAnalysisInput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoringService_GetAnalysisInput_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoringServiceServer).GetAnalysisInput(ctx, req.(*GetAnalysisInputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StoringService_GetFileContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetFileContentRequest)
 	if err := dec(in); err != nil {
@@ -172,6 +466,132 @@ func _StoringService_GetFileContent_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StoringService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoringServiceServer).DeleteTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoringService_DeleteTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoringServiceServer).DeleteTask(ctx, req.(*DeleteTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoringService_EraseUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoringServiceServer).EraseUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoringService_EraseUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoringServiceServer).EraseUserData(ctx, req.(*EraseUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoringService_RunRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunRetentionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoringServiceServer).RunRetention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoringService_RunRetention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoringServiceServer).RunRetention(ctx, req.(*RunRetentionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoringService_RunReconciliation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunReconciliationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoringServiceServer).RunReconciliation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoringService_RunReconciliation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoringServiceServer).RunReconciliation(ctx, req.(*RunReconciliationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoringService_RotateEncryptionKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateEncryptionKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoringServiceServer).RotateEncryptionKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoringService_RotateEncryptionKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoringServiceServer).RotateEncryptionKeys(ctx, req.(*RotateEncryptionKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoringService_GetQuotaUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuotaUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoringServiceServer).GetQuotaUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoringService_GetQuotaUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoringServiceServer).GetQuotaUsage(ctx, req.(*GetQuotaUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoringService_ReadBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadBlobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StoringServiceServer).ReadBlob(m, &grpc.GenericServerStream[ReadBlobRequest, ReadBlobResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StoringService_ReadBlobServer = grpc.ServerStreamingServer[ReadBlobResponse]
+
+func _StoringService_WriteBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StoringServiceServer).WriteBlob(&grpc.GenericServerStream[WriteBlobRequest, WriteBlobResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StoringService_WriteBlobServer = grpc.ClientStreamingServer[WriteBlobRequest, WriteBlobResponse]
+
 // StoringService_ServiceDesc is the grpc.ServiceDesc for StoringService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -187,11 +607,68 @@ var StoringService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTask",
 			Handler:    _StoringService_GetTask_Handler,
 		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _StoringService_ListTasks_Handler,
+		},
+		{
+			MethodName: "GetSubmission",
+			Handler:    _StoringService_GetSubmission_Handler,
+		},
+		{
+			MethodName: "GetAnalysisInput",
+			Handler:    _StoringService_GetAnalysisInput_Handler,
+		},
 		{
 			MethodName: "GetFileContent",
 			Handler:    _StoringService_GetFileContent_Handler,
 		},
+		{
+			MethodName: "DeleteTask",
+			Handler:    _StoringService_DeleteTask_Handler,
+		},
+		{
+			MethodName: "EraseUserData",
+			Handler:    _StoringService_EraseUserData_Handler,
+		},
+		{
+			MethodName: "RunRetention",
+			Handler:    _StoringService_RunRetention_Handler,
+		},
+		{
+			MethodName: "RunReconciliation",
+			Handler:    _StoringService_RunReconciliation_Handler,
+		},
+		{
+			MethodName: "RotateEncryptionKeys",
+			Handler:    _StoringService_RotateEncryptionKeys_Handler,
+		},
+		{
+			MethodName: "GetQuotaUsage",
+			Handler:    _StoringService_GetQuotaUsage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadTaskStream",
+			Handler:       _StoringService_UploadTaskStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ImportArchive",
+			Handler:       _StoringService_ImportArchive_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ReadBlob",
+			Handler:       _StoringService_ReadBlob_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WriteBlob",
+			Handler:       _StoringService_WriteBlob_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/storing_service.proto",
 }
@@ -9,11 +9,17 @@ import (
 	"os/signal"
 	"storing-service/internal/config"
 	"storing-service/internal/infrastucture/analysis"
+	"storing-service/internal/infrastucture/clamd"
+	"storing-service/internal/infrastucture/envelope"
+	"storing-service/internal/infrastucture/fsstore"
 	"storing-service/internal/infrastucture/minio"
 	"storing-service/internal/infrastucture/pgdb"
+	"storing-service/internal/infrastucture/scanstub"
+	"storing-service/internal/infrastucture/urlsign"
 	"storing-service/internal/transport"
 	"storing-service/internal/usecase"
 	pb "storing-service/pkg/api"
+	"storing-service/pkg/identity"
 	"storing-service/pkg/logger"
 	"syscall"
 
@@ -42,20 +48,69 @@ func main() {
 	defer db.Close()
 	appLogger.Info("database init successfully")
 
-	fileStorage, err := minio.NewClient(ctx, &cfg.Minio)
+	signer, err := urlsign.NewSigner(cfg.Storage.PublicURL, cfg.Storage.URLSecret)
 	if err != nil {
-		appLogger.Fatal("minio init failed", zap.Error(err))
+		appLogger.Fatal("url signer init failed", zap.Error(err))
 	}
-	appLogger.Info("minio file storage init successfully")
 
-	analysisClient, err := analysis.NewClient(ctx, cfg.Analysis.URL)
+	var backend envelope.Backend
+	switch cfg.Storage.Backend {
+	case config.StorageBackendFS:
+		backend, err = fsstore.NewStore(&cfg.Storage, signer)
+		if err != nil {
+			appLogger.Fatal("filesystem storage init failed", zap.Error(err))
+		}
+		appLogger.Info("filesystem file storage init successfully", zap.String("root", cfg.Storage.FSRoot))
+	default:
+		backend, err = minio.NewClient(ctx, &cfg.Minio)
+		if err != nil {
+			appLogger.Fatal("minio init failed", zap.Error(err))
+		}
+		appLogger.Info("minio file storage init successfully")
+	}
+
+	fileStorage := backend.(usecase.BlobStore)
+	if cfg.Encryption.Mode != config.EncryptionNone {
+		fileStorage, err = envelope.NewStore(backend, &cfg.Encryption, signer)
+		if err != nil {
+			appLogger.Fatal("file encryption init failed", zap.Error(err))
+		}
+		appLogger.Info("file encryption enabled",
+			zap.String("mode", cfg.Encryption.Mode),
+			zap.String("active_key", cfg.Encryption.ActiveKeyId))
+	}
+
+	var scanner usecase.Scanner
+	switch cfg.Scanner.Backend {
+	case config.ScannerClamd:
+		clamdClient := clamd.NewClient(&cfg.Scanner)
+		// clamd может запускаться дольше сервиса (загрузка баз сигнатур), поэтому недоступность не фатальна:
+		// файлы остаются pending и проверяются сверкой с хранилищем
+		if err := clamdClient.Ping(ctx); err != nil {
+			appLogger.Warn("clamd is not available yet", zap.String("address", cfg.Scanner.ClamdAddress), zap.Error(err))
+		}
+		scanner = clamdClient
+	case config.ScannerStub:
+		scanner = scanstub.NewScanner()
+	}
+
+	var quarantine usecase.BlobStore
+	if scanner != nil {
+		quarantine, err = newQuarantineStore(ctx, cfg, signer)
+		if err != nil {
+			appLogger.Fatal("quarantine storage init failed", zap.Error(err))
+		}
+		appLogger.Info("malware scanning enabled", zap.String("scanner", cfg.Scanner.Backend))
+	}
+
+	analysisClient, err := analysis.NewClient(ctx, cfg.Analysis.URL, cfg.Identity.Secret)
 	if err != nil {
 		appLogger.Fatal("analysis init failed", zap.Error(err))
 	}
 	defer analysisClient.Close()
 
 	pgrepo := pgdb.NewStoringRepository(db, appLogger)
-	service := usecase.NewStoringService(pgrepo, fileStorage, cfg.Minio.Bucket, analysisClient, appLogger)
+	service := usecase.NewStoringService(pgrepo, fileStorage, analysisClient, scanner, quarantine, &cfg.Upload, &cfg.Deletion, &cfg.Retention, &cfg.Reconciliation, &cfg.Quota, appLogger)
 	handler := transport.NewStoringHandler(service, appLogger)
 
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.App.GrpcPort))
@@ -63,7 +118,20 @@ func main() {
 		appLogger.Fatal("grpc listen failed", zap.Error(err))
 	}
 
-	grpcServer := grpc.NewServer()
+	workerCtx, stopWorker := context.WithCancel(ctx)
+	defer stopWorker()
+	go service.RunDeletionWorker(workerCtx)
+	go service.RunRetentionWorker(workerCtx)
+	go service.RunReconciliationWorker(workerCtx)
+
+	// ReadBlob и WriteBlob вызываются по подписанным ссылкам без клиента и тенанта: ключ объекта
+	// уже содержит каталог тенанта
+	verifier := identity.NewVerifier(cfg.Identity.Secret,
+		pb.StoringService_ReadBlob_FullMethodName,
+		pb.StoringService_WriteBlob_FullMethodName)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(verifier.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(verifier.StreamServerInterceptor))
 	pb.RegisterStoringServiceServer(grpcServer, handler)
 
 	go func() {
@@ -80,6 +148,35 @@ func main() {
 	appLogger.Info("shutting down server")
 
 	grpcServer.GracefulStop()
+	stopWorker()
 
 	appLogger.Info("server exited")
 }
+
+// newQuarantineStore создает хранилище зараженных файлов: отдельный bucket MinIO или отдельный каталог.
+// Файлы в карантине шифруются так же, как в основном хранилище.
+func newQuarantineStore(ctx context.Context, cfg *config.Config, signer *urlsign.Signer) (usecase.BlobStore, error) {
+	var backend envelope.Backend
+	if cfg.Storage.Backend == config.StorageBackendFS {
+		storage := cfg.Storage
+		storage.FSRoot = cfg.Scanner.QuarantineFSRoot
+		store, err := fsstore.NewStore(&storage, signer)
+		if err != nil {
+			return nil, err
+		}
+		backend = store
+	} else {
+		minioCfg := cfg.Minio
+		minioCfg.Bucket = cfg.Scanner.QuarantineBucket
+		client, err := minio.NewClient(ctx, &minioCfg)
+		if err != nil {
+			return nil, err
+		}
+		backend = client
+	}
+
+	if cfg.Encryption.Mode == config.EncryptionNone {
+		return backend.(usecase.BlobStore), nil
+	}
+	return envelope.NewStore(backend, &cfg.Encryption, signer)
+}
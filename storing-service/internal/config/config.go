@@ -1,16 +1,36 @@
 package config
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"storing-service/pkg/tenant"
+	"strconv"
+	"strings"
+	"time"
 )
 
 var (
-	dbUserEmptyError = errors.New("DB User is Empty")
-	dbNameEmptyError = errors.New("DB Name is Empty")
+	dbUserEmptyError        = errors.New("DB User is Empty")
+	dbNameEmptyError        = errors.New("DB Name is Empty")
+	urlSecretEmptyError     = errors.New("STORAGE_URL_SECRET is required for fs storage backend and encryption")
+	unknownBackendError     = errors.New("STORAGE_BACKEND must be minio or fs")
+	unknownEncryptionError  = errors.New("ENCRYPTION_MODE must be none, client or sse-c")
+	ssecBackendError        = errors.New("ENCRYPTION_MODE=sse-c requires STORAGE_BACKEND=minio")
+	ssecTLSError            = errors.New("ENCRYPTION_MODE=sse-c requires MINIO_USE_SSL=true")
+	activeKeyMissingError   = errors.New("ENCRYPTION_ACTIVE_KEY must name one of ENCRYPTION_KEYS")
+	unknownStrayActionError = errors.New("RECONCILIATION_STRAY_ACTION must be quarantine or delete")
+	unknownScannerError     = errors.New("SCANNER_BACKEND must be none, clamd or stub")
+	quarantineRootError     = errors.New("QUARANTINE_FS_ROOT must not be inside STORAGE_FS_ROOT")
+	identitySecretError     = errors.New("IDENTITY_SECRET of at least 32 characters is required")
 )
 
+// minIdentitySecretLength - минимальная длина IDENTITY_SECRET
+const minIdentitySecretLength = 32
+
 type AppConfig struct {
 	GrpcPort string
 }
@@ -30,22 +50,151 @@ type MinioConfig struct {
 	AccessKey        string
 	SecretKey        string
 	Bucket           string
+	// TLS до MinIO, обязателен для SSE-C
+	UseSSL bool
+}
+
+const (
+	StorageBackendMinio = "minio"
+	StorageBackendFS    = "fs"
+)
+
+type StorageConfig struct {
+	// Хранилище файлов: minio (MinIO/S3) или fs (локальная файловая система)
+	Backend string
+	// Каталог с файлами для fs
+	FSRoot string
+	// Ключ HMAC для подписи ссылок fs на api-gateway
+	URLSecret string
+	// Внешний адрес api-gateway, на который ведут подписанные ссылки fs
+	PublicURL string
+}
+
+const (
+	EncryptionNone   = "none"
+	EncryptionClient = "client"
+	EncryptionSSEC   = "sse-c"
+)
+
+type EncryptionConfig struct {
+	// none, client (AES-GCM в storing-service) или sse-c (шифрует MinIO ключом объекта)
+	Mode string
+	// Мастер-ключи (32 байта) по идентификатору, ими шифруются ключи объектов
+	Keys map[string][]byte
+	// Мастер-ключ для новых объектов; остальные нужны для чтения и ротации
+	ActiveKeyId string
 }
 
 type LoggerConfig struct {
 	Level string
 }
 
+// IdentityConfig - общий секрет api-gateway и сервисов, которым подписывается клиент запроса
+type IdentityConfig struct {
+	Secret []byte
+}
+
 type AnalysisConfig struct {
 	URL string
 }
 
+type UploadConfig struct {
+	// Максимальный заявленный размер файла в байтах
+	MaxSize int64
+	// Допустимые типы содержимого по расширению файла, например ".pdf" -> ["application/pdf"]
+	AllowedTypes map[string][]string
+	// Допустимые типы тенантов из TENANTS_FILE, заменяют AllowedTypes для тенанта
+	TenantAllowedTypes map[string]map[string][]string
+	// Максимальный размер ZIP-архива при массовом импорте и число файлов в нем
+	ImportMaxSize    int64
+	ImportMaxEntries int64
+	// Схема ключей объектов новых задач, например "{course}/{assignment}/{task_id}{ext}".
+	// Файлы одного каталога схемы составляют корпус сравнения.
+	KeyLayout string
+}
+
+type DeletionConfig struct {
+	// Сколько удаленная задача хранится до окончательного удаления, 0 - удалять сразу
+	GracePeriod time.Duration
+	// Период запуска фоновой очистки и доставки событий в analysis-service
+	PurgeInterval time.Duration
+}
+
+type RetentionConfig struct {
+	// Срок хранения задач курсов без собственной политики, 0 - хранить бессрочно
+	DefaultPeriod time.Duration
+	// Сроки хранения по course_id, 0 - хранить задачи курса бессрочно
+	CoursePeriods map[string]time.Duration
+	// Сроки хранения тенантов из TENANTS_FILE, заменяют DefaultPeriod для тенанта
+	TenantPeriods map[string]time.Duration
+	// Период запуска планировщика
+	Interval time.Duration
+	// Плановые запуски только формируют отчет, ничего не удаляя
+	DryRun bool
+}
+
+const (
+	StrayActionQuarantine = "quarantine"
+	StrayActionDelete     = "delete"
+)
+
+type ReconciliationConfig struct {
+	// Период сверки задач с хранилищем, 0 - только по запросу
+	Interval time.Duration
+	// Сколько ждать загрузки по presigned-ссылке; должно быть больше срока действия ссылки (1 час)
+	UploadTimeout time.Duration
+	// Что делать с объектами без задачи: quarantine (перенести в quarantine/) или delete
+	StrayAction string
+	// Плановые запуски только формируют отчет, ничего не меняя
+	DryRun bool
+}
+
+const (
+	ScannerNone  = "none"
+	ScannerClamd = "clamd"
+	ScannerStub  = "stub"
+)
+
+type ScannerConfig struct {
+	// Проверка файлов на вредоносное содержимое: none, clamd или stub (встроенная заглушка, находит тестовую
+	// строку EICAR)
+	Backend string
+	// Адрес clamd: host:port или путь к unix-сокету
+	ClamdAddress string
+	// Максимальное время проверки одного файла
+	Timeout time.Duration
+	// Куда переносятся зараженные файлы: bucket MinIO или каталог для STORAGE_BACKEND=fs
+	QuarantineBucket string
+	QuarantineFSRoot string
+}
+
+// QuotaConfig - квоты загрузок; 0 - без ограничения. Максимальный размер файла - UploadConfig.MaxSize.
+type QuotaConfig struct {
+	// Окно, за которое считается число загрузок пользователя
+	Window time.Duration
+	// Максимум загрузок пользователя за окно
+	MaxUploads int64
+	// Максимальный объем файлов пользователя
+	MaxUserBytes int64
+	// Максимальный объем файлов задания
+	MaxAssignmentBytes int64
+}
+
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	Minio    MinioConfig
-	Logger   LoggerConfig
-	Analysis AnalysisConfig
+	App            AppConfig
+	Database       DatabaseConfig
+	Minio          MinioConfig
+	Storage        StorageConfig
+	Encryption     EncryptionConfig
+	Logger         LoggerConfig
+	Identity       IdentityConfig
+	Analysis       AnalysisConfig
+	Upload         UploadConfig
+	Deletion       DeletionConfig
+	Retention      RetentionConfig
+	Reconciliation ReconciliationConfig
+	Quota          QuotaConfig
+	Scanner        ScannerConfig
 }
 
 func LoadConfig() (*Config, error) {
@@ -66,6 +215,17 @@ func LoadConfig() (*Config, error) {
 			AccessKey:        getEnv("MINIO_ACCESS_KEY", "user"),
 			SecretKey:        getEnv("MINIO_SECRET_KEY", "password"),
 			Bucket:           getEnv("MINIO_BUCKET", "tasks"),
+			UseSSL:           getEnvBool("MINIO_USE_SSL", false),
+		},
+		Storage: StorageConfig{
+			Backend:   strings.ToLower(getEnv("STORAGE_BACKEND", StorageBackendMinio)),
+			FSRoot:    getEnv("STORAGE_FS_ROOT", "./data/blobs"),
+			URLSecret: getEnv("STORAGE_URL_SECRET", ""),
+			PublicURL: getEnv("STORAGE_PUBLIC_URL", "http://localhost:8080"),
+		},
+		Encryption: EncryptionConfig{
+			Mode:        strings.ToLower(getEnv("ENCRYPTION_MODE", EncryptionNone)),
+			ActiveKeyId: getEnv("ENCRYPTION_ACTIVE_KEY", ""),
 		},
 		Logger: LoggerConfig{
 			Level: getEnv("LOG_LEVEL", "prod"),
@@ -73,6 +233,42 @@ func LoadConfig() (*Config, error) {
 		Analysis: AnalysisConfig{
 			URL: getEnv("ANALYSIS_SERVICE_URL", "localhost:9000"),
 		},
+		Upload: UploadConfig{
+			MaxSize:          getEnvInt64("UPLOAD_MAX_SIZE", 50<<20),
+			AllowedTypes:     parseAllowedTypes(getEnv("UPLOAD_ALLOWED_TYPES", ".txt:text/plain,.md:text/plain,.pdf:application/pdf,.docx:application/zip")),
+			ImportMaxSize:    getEnvInt64("IMPORT_MAX_SIZE", 512<<20),
+			ImportMaxEntries: getEnvInt64("IMPORT_MAX_ENTRIES", 1000),
+			KeyLayout:        getEnv("STORAGE_KEY_LAYOUT", "{task_id}{ext}"),
+		},
+		Deletion: DeletionConfig{
+			GracePeriod:   getEnvDuration("TASK_DELETE_GRACE_PERIOD", 24*time.Hour),
+			PurgeInterval: getEnvDuration("TASK_PURGE_INTERVAL", time.Minute),
+		},
+		Retention: RetentionConfig{
+			DefaultPeriod: getEnvDuration("RETENTION_DEFAULT_PERIOD", 0),
+			CoursePeriods: parseCoursePeriods(getEnv("RETENTION_COURSE_PERIODS", "")),
+			Interval:      getEnvDuration("RETENTION_INTERVAL", time.Hour),
+			DryRun:        getEnvBool("RETENTION_DRY_RUN", false),
+		},
+		Reconciliation: ReconciliationConfig{
+			Interval:      getEnvDuration("RECONCILIATION_INTERVAL", 24*time.Hour),
+			UploadTimeout: getEnvDuration("RECONCILIATION_UPLOAD_TIMEOUT", 2*time.Hour),
+			StrayAction:   strings.ToLower(getEnv("RECONCILIATION_STRAY_ACTION", StrayActionQuarantine)),
+			DryRun:        getEnvBool("RECONCILIATION_DRY_RUN", false),
+		},
+		Quota: QuotaConfig{
+			Window:             getEnvDuration("QUOTA_WINDOW", time.Hour),
+			MaxUploads:         getEnvInt64("QUOTA_MAX_UPLOADS", 100),
+			MaxUserBytes:       getEnvInt64("QUOTA_MAX_USER_BYTES", 1<<30),
+			MaxAssignmentBytes: getEnvInt64("QUOTA_MAX_ASSIGNMENT_BYTES", 0),
+		},
+		Scanner: ScannerConfig{
+			Backend:          strings.ToLower(getEnv("SCANNER_BACKEND", ScannerNone)),
+			ClamdAddress:     getEnv("CLAMD_ADDRESS", "localhost:3310"),
+			Timeout:          getEnvDuration("SCANNER_TIMEOUT", time.Minute),
+			QuarantineBucket: getEnv("QUARANTINE_BUCKET", "tasks-quarantine"),
+			QuarantineFSRoot: getEnv("QUARANTINE_FS_ROOT", "./data/quarantine"),
+		},
 	}
 
 	err := makeDbUrl(c)
@@ -80,6 +276,36 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	c.Identity.Secret = []byte(getEnv("IDENTITY_SECRET", ""))
+	if len(c.Identity.Secret) < minIdentitySecretLength {
+		return nil, identitySecretError
+	}
+
+	c.Encryption.Keys, err = parseMasterKeys(getEnv("ENCRYPTION_KEYS", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateStorage(&c.Storage, &c.Minio, &c.Encryption); err != nil {
+		return nil, err
+	}
+
+	if err := loadTenants(getEnv("TENANTS_FILE", ""), &c.Upload, &c.Retention); err != nil {
+		return nil, err
+	}
+
+	if err := validateKeyLayout(c.Upload.KeyLayout); err != nil {
+		return nil, err
+	}
+
+	if c.Reconciliation.StrayAction != StrayActionQuarantine && c.Reconciliation.StrayAction != StrayActionDelete {
+		return nil, unknownStrayActionError
+	}
+
+	if err := validateScanner(&c.Scanner, &c.Storage); err != nil {
+		return nil, err
+	}
+
 	return c, nil
 }
 
@@ -90,6 +316,119 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+func getEnvInt64(key string, fallback int64) int64 {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+			return n
+		}
+	}
+	return fallback
+}
+
+// getEnvDuration разбирает длительность вида "24h" или "90s", отрицательные значения игнорируются
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+// parseCoursePeriods разбирает список вида "algorithms-2024:8760h,databases:4380h".
+// Срок отделяется последним двоеточием, поэтому course_id может содержать двоеточия.
+func parseCoursePeriods(value string) map[string]time.Duration {
+	periods := make(map[string]time.Duration)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		i := strings.LastIndex(entry, ":")
+		if i <= 0 {
+			continue
+		}
+		period, err := time.ParseDuration(entry[i+1:])
+		if err != nil || period < 0 {
+			continue
+		}
+		periods[entry[:i]] = period
+	}
+	return periods
+}
+
+// tenantSettings - настройки тенанта в TENANTS_FILE. Файл общий для сервисов: storing-service читает
+// срок хранения и допустимые типы файлов, analysis-service - порог плагиата.
+type tenantSettings struct {
+	RetentionPeriod *string             `json:"retention_period"`
+	AllowedTypes    map[string][]string `json:"allowed_types"`
+}
+
+// loadTenants читает настройки тенантов из JSON-файла вида {"law": {"retention_period": "8760h",
+// "allowed_types": {".pdf": ["application/pdf"]}}}. Не заданные поля берутся из общих настроек.
+func loadTenants(path string, upload *UploadConfig, retention *RetentionConfig) error {
+	upload.TenantAllowedTypes = make(map[string]map[string][]string)
+	retention.TenantPeriods = make(map[string]time.Duration)
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read TENANTS_FILE: %w", err)
+	}
+	var tenants map[string]tenantSettings
+	if err := json.Unmarshal(data, &tenants); err != nil {
+		return fmt.Errorf("invalid TENANTS_FILE: %w", err)
+	}
+
+	for id, settings := range tenants {
+		if !tenant.Valid(id) {
+			return fmt.Errorf("TENANTS_FILE has invalid tenant id %q", id)
+		}
+
+		if settings.RetentionPeriod != nil {
+			period, err := time.ParseDuration(*settings.RetentionPeriod)
+			if err != nil || period < 0 {
+				return fmt.Errorf("tenant %s has invalid retention_period %q", id, *settings.RetentionPeriod)
+			}
+			retention.TenantPeriods[id] = period
+		}
+
+		if settings.AllowedTypes != nil {
+			allowed := make(map[string][]string, len(settings.AllowedTypes))
+			for ext, contentTypes := range settings.AllowedTypes {
+				ext = strings.ToLower(ext)
+				for _, contentType := range contentTypes {
+					allowed[ext] = append(allowed[ext], strings.ToLower(contentType))
+				}
+			}
+			upload.TenantAllowedTypes[id] = allowed
+		}
+	}
+	return nil
+}
+
+// parseAllowedTypes разбирает список вида ".txt:text/plain,.docx:application/zip"
+func parseAllowedTypes(value string) map[string][]string {
+	allowed := make(map[string][]string)
+	for _, entry := range strings.Split(value, ",") {
+		ext, contentType, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || ext == "" || contentType == "" {
+			continue
+		}
+		ext = strings.ToLower(ext)
+		allowed[ext] = append(allowed[ext], strings.ToLower(contentType))
+	}
+	return allowed
+}
+
 func makeDbUrl(cfg *Config) error {
 	if cfg.Database.URL == "" {
 		if cfg.Database.User == "" {
@@ -108,3 +447,99 @@ func makeDbUrl(cfg *Config) error {
 	}
 	return nil
 }
+
+// validateKeyLayout проверяет схему ключей: имя файла - ровно {task_id}{ext} (analysis-service
+// извлекает из него идентификатор задачи), каталоги задаются {course}, {assignment} и постоянным текстом
+func validateKeyLayout(layout string) error {
+	segments := strings.Split(layout, "/")
+	if segments[len(segments)-1] != "{task_id}{ext}" {
+		return fmt.Errorf("STORAGE_KEY_LAYOUT must end with {task_id}{ext}")
+	}
+
+	for i, segment := range segments[:len(segments)-1] {
+		if segment == "" || strings.HasPrefix(segment, ".") || (i == 0 && (segment == "quarantine" || segment == "tenants")) {
+			return fmt.Errorf("STORAGE_KEY_LAYOUT has invalid path segment %q", segment)
+		}
+		rest := strings.NewReplacer("{course}", "", "{assignment}", "").Replace(segment)
+		if strings.ContainsAny(rest, "{}") {
+			return fmt.Errorf("STORAGE_KEY_LAYOUT segment %q has unknown placeholder, only {course} and {assignment} are allowed in directories", segment)
+		}
+	}
+	return nil
+}
+
+func validateStorage(cfg *StorageConfig, minio *MinioConfig, encryption *EncryptionConfig) error {
+	if cfg.Backend != StorageBackendMinio && cfg.Backend != StorageBackendFS {
+		return unknownBackendError
+	}
+
+	switch encryption.Mode {
+	case EncryptionNone:
+	case EncryptionClient, EncryptionSSEC:
+		if encryption.Mode == EncryptionSSEC && cfg.Backend != StorageBackendMinio {
+			return ssecBackendError
+		}
+		// MinIO принимает ключи SSE-C только по TLS, без него ключ ушел бы по сети открытым текстом
+		if encryption.Mode == EncryptionSSEC && !minio.UseSSL {
+			return ssecTLSError
+		}
+		if _, ok := encryption.Keys[encryption.ActiveKeyId]; !ok {
+			return activeKeyMissingError
+		}
+	default:
+		return unknownEncryptionError
+	}
+
+	// Без прямого доступа клиента к хранилищу ссылки ведут на api-gateway и подписываются
+	if (cfg.Backend == StorageBackendFS || encryption.Mode != EncryptionNone) && cfg.URLSecret == "" {
+		return urlSecretEmptyError
+	}
+	return nil
+}
+
+func validateScanner(cfg *ScannerConfig, storage *StorageConfig) error {
+	switch cfg.Backend {
+	case ScannerNone, ScannerClamd, ScannerStub:
+	default:
+		return unknownScannerError
+	}
+
+	// Иначе зараженные файлы попали бы в листинг хранилища (сверка, корпус analysis-service)
+	if cfg.Backend != ScannerNone && storage.Backend == StorageBackendFS {
+		root, err := filepath.Abs(storage.FSRoot)
+		if err != nil {
+			return err
+		}
+		quarantine, err := filepath.Abs(cfg.QuarantineFSRoot)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, quarantine)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return quarantineRootError
+		}
+	}
+	return nil
+}
+
+// parseMasterKeys разбирает список вида "2024-01:base64,2024-07:base64", ключи - 32 байта в base64.
+// Ошибка в ключе не игнорируется: файлы, зашифрованные без нужного ключа, не прочитать.
+func parseMasterKeys(value string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid ENCRYPTION_KEYS entry, expected id:base64")
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("encryption key %s must be 32 bytes in base64", id)
+		}
+		keys[id] = key
+	}
+	return keys, nil
+}
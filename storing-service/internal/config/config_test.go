@@ -0,0 +1,58 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateStorage(t *testing.T) {
+	keys := map[string][]byte{"k1": make([]byte, 32)}
+	tests := []struct {
+		name      string
+		backend   string
+		useSSL    bool
+		mode      string
+		urlSecret string
+		wantErr   error
+	}{
+		{name: "minio without encryption", backend: StorageBackendMinio, mode: EncryptionNone},
+		{name: "client encryption", backend: StorageBackendFS, mode: EncryptionClient, urlSecret: "secret"},
+		{name: "sse-c over tls", backend: StorageBackendMinio, useSSL: true, mode: EncryptionSSEC, urlSecret: "secret"},
+		{name: "sse-c without tls", backend: StorageBackendMinio, mode: EncryptionSSEC, urlSecret: "secret", wantErr: ssecTLSError},
+		{name: "sse-c on fs", backend: StorageBackendFS, useSSL: true, mode: EncryptionSSEC, urlSecret: "secret", wantErr: ssecBackendError},
+		{name: "fs without url secret", backend: StorageBackendFS, mode: EncryptionNone, wantErr: urlSecretEmptyError},
+		{name: "unknown backend", backend: "s3", mode: EncryptionNone, wantErr: unknownBackendError},
+		{name: "unknown mode", backend: StorageBackendMinio, mode: "rot13", wantErr: unknownEncryptionError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStorage(
+				&StorageConfig{Backend: tt.backend, URLSecret: tt.urlSecret},
+				&MinioConfig{UseSSL: tt.useSSL},
+				&EncryptionConfig{Mode: tt.mode, Keys: keys, ActiveKeyId: "k1"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateStorage() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfigRejectsSSECWithoutTLS(t *testing.T) {
+	t.Setenv("IDENTITY_SECRET", "0123456789abcdef0123456789abcdef")
+	t.Setenv("STORAGE_BACKEND", StorageBackendMinio)
+	t.Setenv("STORAGE_URL_SECRET", "secret")
+	t.Setenv("ENCRYPTION_MODE", EncryptionSSEC)
+	t.Setenv("ENCRYPTION_KEYS", "k1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=")
+	t.Setenv("ENCRYPTION_ACTIVE_KEY", "k1")
+	t.Setenv("MINIO_USE_SSL", "false")
+
+	if _, err := LoadConfig(); !errors.Is(err, ssecTLSError) {
+		t.Fatalf("LoadConfig() = %v, want %v", err, ssecTLSError)
+	}
+
+	t.Setenv("MINIO_USE_SSL", "true")
+	if _, err := LoadConfig(); errors.Is(err, ssecTLSError) {
+		t.Fatalf("LoadConfig() with MINIO_USE_SSL=true = %v", err)
+	}
+}
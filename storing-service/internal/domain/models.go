@@ -5,17 +5,260 @@ import (
 	"time"
 )
 
+type TaskStatus string
+
+const (
+	// TaskPending - файл еще не загружен или не проверен
+	TaskPending  TaskStatus = "pending"
+	TaskVerified TaskStatus = "verified"
+	TaskRejected TaskStatus = "rejected"
+	// TaskUnverified - задача создана до появления проверки загрузки
+	TaskUnverified TaskStatus = "unverified"
+	// TaskExpired - файл так и не был загружен по presigned-ссылке
+	TaskExpired TaskStatus = "expired"
+)
+
 type Task struct {
-	Id         uuid.UUID `db:"id"`
-	Filename   string    `db:"filename"`
-	Url        string    `db:"url"`
-	UploadedBy uuid.UUID `db:"uploaded_by"`
-	CreatedAt  time.Time `db:"created_at"`
+	Id             uuid.UUID         `db:"id"`
+	Filename       string            `db:"filename"`
+	Url            string            `db:"url"`
+	UploadForm     map[string]string `db:"-"`
+	UploadedBy     uuid.UUID         `db:"uploaded_by"`
+	CourseId       string            `db:"course_id"`
+	AssignmentId   string            `db:"assignment_id"`
+	Status         TaskStatus        `db:"status"`
+	StatusReason   string            `db:"status_reason"`
+	ExpectedSize   int64             `db:"expected_size"`
+	ExpectedSha256 string            `db:"expected_sha256"`
+	ContentSha256  string            `db:"content_sha256"`
+	ContentType    string            `db:"content_type"`
+	ObjectKey      string            `db:"object_key"`
+	SubmissionId   uuid.UUID         `db:"submission_id"`
+	Version        int               `db:"version"`
+	CreatedAt      time.Time         `db:"created_at"`
 }
 
 type TaskMetadata struct {
-	Id         uuid.UUID `db:"id"`
-	Filename   string    `db:"filename"`
-	UploadedBy uuid.UUID `db:"uploaded_by"`
-	CreatedAt  time.Time `db:"created_at"`
+	Id             uuid.UUID  `db:"id"`
+	Filename       string     `db:"filename"`
+	UploadedBy     uuid.UUID  `db:"uploaded_by"`
+	CourseId       string     `db:"course_id"`
+	AssignmentId   string     `db:"assignment_id"`
+	Status         TaskStatus `db:"status"`
+	StatusReason   string     `db:"status_reason"`
+	ExpectedSize   int64      `db:"expected_size"`
+	ExpectedSha256 string     `db:"expected_sha256"`
+	ContentSha256  string     `db:"content_sha256"`
+	ContentType    string     `db:"content_type"`
+	ObjectKey      string     `db:"object_key"`
+	// uuid.Nil и 0 для задач без assignment_id
+	SubmissionId uuid.UUID `db:"submission_id"`
+	Version      int       `db:"version"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// AnalysisInput - параметры анализа задачи: предыдущие версии посылки исключаются из сравнения,
+// а побайтовый дубликат чужой работы сразу дает отчет без сравнения
+type AnalysisInput struct {
+	Task           *TaskMetadata
+	ExcludeTaskIds []string
+	DuplicateOf    *TaskMetadata
+}
+
+// Submission - посылка: все версии работы студента по заданию курса.
+// Versions упорядочены по возрастанию номера, удаленные версии не входят.
+type Submission struct {
+	Id           uuid.UUID
+	UploadedBy   uuid.UUID
+	CourseId     string
+	AssignmentId string
+	CreatedAt    time.Time
+	Versions     []*TaskMetadata
+}
+
+type SortOrder string
+
+const (
+	OrderDesc SortOrder = "desc"
+	OrderAsc  SortOrder = "asc"
+)
+
+// TaskFilter задает отбор задач для списка. Пустые поля не ограничивают выборку.
+type TaskFilter struct {
+	UploadedBy   uuid.UUID
+	CourseId     string
+	AssignmentId string
+	Status       TaskStatus
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	// Подстрока имени файла без учета регистра
+	Filename string
+	Ids      []uuid.UUID
+	// Ограничение доступа клиента, nil - без ограничений
+	Scope *AccessScope
+}
+
+// AccessScope - задачи, доступные клиенту: задачи автора UploadedBy и задачи курсов CourseIds
+type AccessScope struct {
+	UploadedBy uuid.UUID
+	CourseIds  []string
+}
+
+type TaskEventType string
+
+const (
+	// TaskEventDeleted - задача окончательно удалена, analysis-service должен удалить ее отчеты
+	TaskEventDeleted TaskEventType = "task.deleted"
+	// TaskEventErased - задача удалена по запросу автора, ссылки на нее в чужих отчетах обезличиваются
+	TaskEventErased TaskEventType = "task.erased"
+)
+
+// TaskEvent - событие задачи, ожидающее доставки в analysis-service
+type TaskEvent struct {
+	Id        uuid.UUID
+	TaskId    uuid.UUID
+	Type      TaskEventType
+	Attempts  int
+	CreatedAt time.Time
+}
+
+// RetentionRun - запуск политики хранения. В режиме DryRun задачи не удаляются,
+// а только перечисляются в Items.
+type RetentionRun struct {
+	Id         uuid.UUID
+	DryRun     bool
+	Expired    int
+	Purged     int
+	Failed     int
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Items      []RetentionItem
+}
+
+// TaskObject - задача, включая помеченные удаленными, для сверки с хранилищем
+type TaskObject struct {
+	Task    *TaskMetadata
+	Deleted bool
+}
+
+type ReconciliationKind string
+
+const (
+	// ReconciliationExpiredUpload - файл задачи не загружен за отведенное время, задача помечается expired
+	ReconciliationExpiredUpload ReconciliationKind = "expired_upload"
+	// ReconciliationResumedUpload - файл загружен, но не проверен (проверка не дождалась загрузки)
+	ReconciliationResumedUpload ReconciliationKind = "resumed_upload"
+	// ReconciliationMissingObject - у проверенной задачи нет файла в хранилище
+	ReconciliationMissingObject ReconciliationKind = "missing_object"
+	// ReconciliationStrayObject - объект хранилища без задачи
+	ReconciliationStrayObject ReconciliationKind = "stray_object"
+)
+
+// ReconciliationRun - сверка таблицы tasks с хранилищем. В режиме DryRun ничего не меняется,
+// расхождения только перечисляются в Items.
+type ReconciliationRun struct {
+	DryRun      bool
+	StrayAction string
+	// Число расхождений каждого вида
+	ExpiredUploads int
+	ResumedUploads int
+	MissingObjects int
+	StrayObjects   int
+	Failed         int
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	Items          []ReconciliationItem
+}
+
+// ReconciliationItem - расхождение; TaskId пуст для объектов без задачи
+type ReconciliationItem struct {
+	Kind      ReconciliationKind
+	TaskId    uuid.UUID
+	ObjectKey string
+	Error     string
+}
+
+// ScanResult - результат проверки файла на вредоносное содержимое
+type ScanResult struct {
+	Infected bool
+	// Имя найденной сигнатуры, например Eicar-Test-Signature
+	Signature string
+}
+
+// QuotaUsage - использование квот пользователем и (или) заданием. Лимит 0 - без ограничения.
+// User или Assignment равны nil, если не запрашивались.
+type QuotaUsage struct {
+	Window             time.Duration
+	MaxFileSize        int64
+	MaxUploads         int64
+	MaxUserBytes       int64
+	MaxAssignmentBytes int64
+	User               *UserUsage
+	Assignment         *AssignmentUsage
+}
+
+// UserUsage - загрузки пользователя за окно квоты и объем его файлов
+type UserUsage struct {
+	UploadedBy uuid.UUID
+	Uploads    int64
+	Bytes      int64
+}
+
+// AssignmentUsage - объем файлов задания
+type AssignmentUsage struct {
+	AssignmentId string
+	Bytes        int64
+}
+
+// KeyRotation - итог перешифрования ключей файлов активным мастер-ключом
+type KeyRotation struct {
+	Checked   int
+	Rewrapped int
+	Failed    int
+}
+
+// RetentionItem - задача с истекшим сроком хранения
+type RetentionItem struct {
+	TaskId        uuid.UUID
+	CourseId      string
+	TaskCreatedAt time.Time
+	RetainUntil   time.Time
+	// Пустая, если задача удалена (или была бы удалена в DryRun)
+	Error string
+}
+
+type ImportStatus string
+
+const (
+	// ImportImported - задача создана и поставлена в очередь на анализ
+	ImportImported ImportStatus = "imported"
+	// ImportRejected - задача создана, но файл не прошел проверку
+	ImportRejected ImportStatus = "rejected"
+	ImportFailed   ImportStatus = "failed"
+	// ImportSkipped - служебный файл архива (папки, __MACOSX, скрытые файлы)
+	ImportSkipped ImportStatus = "skipped"
+)
+
+// ImportEntry - результат импорта одного файла архива
+type ImportEntry struct {
+	Path       string
+	UploadedBy uuid.UUID
+	// uuid.Nil, если задача не создана
+	TaskId uuid.UUID
+	Status ImportStatus
+	Error  string
+}
+
+// TaskCursor - позиция keyset-пагинации по (created_at, id)
+type TaskCursor struct {
+	CreatedAt time.Time
+	Id        uuid.UUID
+}
+
+// BlobInfo - сведения об объекте в хранилище файлов
+type BlobInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModifiedAt  time.Time
 }
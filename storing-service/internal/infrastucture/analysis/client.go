@@ -5,6 +5,7 @@ import (
 	"context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"storing-service/pkg/identity"
 )
 
 type Client struct {
@@ -12,8 +13,12 @@ type Client struct {
 	client analysispb.AnalysisServiceClient
 }
 
-func NewClient(ctx context.Context, endpoint string) (*Client, error) {
-	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewClient(ctx context.Context, endpoint string, identitySecret []byte) (*Client, error) {
+	// Вызовы подписываются внутренним клиентом в тенанте контекста: analysis-service ограничивает
+	// тенантом отчеты и корпус, а доступ пользователя к задаче уже проверен storing-service
+	conn, err := grpc.NewClient(endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(identity.SystemClientInterceptor(identitySecret, "storing-service")))
 	if err != nil {
 		return nil, err
 	}
@@ -24,10 +29,16 @@ func NewClient(ctx context.Context, endpoint string) (*Client, error) {
 	}, nil
 }
 
-func (c *Client) AnalyseTask(ctx context.Context, taskId, objectKey string) (bool, error) {
+func (c *Client) AnalyseTask(ctx context.Context, taskId, objectKey, assignmentId, uploadedBy, courseId, duplicateOf, duplicateOfKey string, excludeTaskIds []string) (bool, error) {
 	req := analysispb.AnalyzeTaskRequest{
-		TaskId:    taskId,
-		ObjectKey: objectKey,
+		TaskId:               taskId,
+		ObjectKey:            objectKey,
+		AssignmentId:         assignmentId,
+		UploadedBy:           uploadedBy,
+		CourseId:             courseId,
+		DuplicateOf:          duplicateOf,
+		DuplicateOfObjectKey: duplicateOfKey,
+		ExcludeTaskIds:       excludeTaskIds,
 	}
 
 	resp, err := c.client.AnalyseTask(ctx, &req)
@@ -38,6 +49,16 @@ func (c *Client) AnalyseTask(ctx context.Context, taskId, objectKey string) (boo
 	return resp.Status, nil
 }
 
+func (c *Client) PurgeTask(ctx context.Context, taskId string, anonymiseReferences bool) error {
+	req := analysispb.PurgeTaskRequest{
+		TaskId:              taskId,
+		AnonymiseReferences: anonymiseReferences,
+	}
+
+	_, err := c.client.PurgeTask(ctx, &req)
+	return err
+}
+
 func (c *Client) GetReport(ctx context.Context, taskId string) (*analysispb.GetReportResponse, error) {
 	req := analysispb.GetReportRequest{
 		TaskId: taskId,
@@ -0,0 +1,144 @@
+package clamd
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"storing-service/internal/config"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"strings"
+	"time"
+)
+
+// chunkSize - размер части потока INSTREAM
+const chunkSize = 64 << 10
+
+// Client проверяет файлы демоном ClamAV по протоколу clamd (команда INSTREAM).
+// Соединение открывается на каждую проверку.
+type Client struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+func NewClient(cfg *config.ScannerConfig) *Client {
+	network := "tcp"
+	if strings.HasPrefix(cfg.ClamdAddress, "/") {
+		network = "unix"
+	}
+
+	return &Client{
+		network: network,
+		address: cfg.ClamdAddress,
+		timeout: cfg.Timeout,
+	}
+}
+
+// Scan передает содержимое в clamd частями и разбирает ответ вида "stream: OK" или "stream: <сигнатура> FOUND"
+func (c *Client) Scan(ctx context.Context, content io.Reader) (*domain.ScanResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %v: %w", err, errdefs.ErrUnavailable)
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, fmt.Errorf("failed to set clamd deadline: %v: %w", err, errdefs.ErrUnavailable)
+	}
+
+	// Если clamd прервал прием (например, превышен StreamMaxLength), причина есть в ответе,
+	// поэтому ошибка записи не возвращается сразу
+	writeErr := writeStream(conn, content)
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		if writeErr != nil {
+			err = writeErr
+		}
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return nil, fmt.Errorf("failed to scan file with clamd: %v: %w", err, errdefs.ErrUnavailable)
+	}
+
+	return parseReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+func writeStream(conn net.Conn, content io.Reader) error {
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return err
+	}
+
+	// Часть - длина (4 байта, big-endian) и данные
+	buf := make([]byte, 4+chunkSize)
+	for {
+		n, err := io.ReadFull(content, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf, uint32(n))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Часть нулевой длины - конец потока
+	_, err := conn.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+func parseReply(reply string) (*domain.ScanResult, error) {
+	result := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case result == "OK":
+		return &domain.ScanResult{}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return &domain.ScanResult{
+			Infected:  true,
+			Signature: strings.TrimSuffix(result, " FOUND"),
+		}, nil
+	default:
+		return nil, fmt.Errorf("clamd error: %s: %w", result, errdefs.ErrUnavailable)
+	}
+}
+
+// Ping проверяет, что clamd отвечает
+func (c *Client) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to clamd: %v: %w", err, errdefs.ErrUnavailable)
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	if _, err := conn.Write([]byte("zPING\x00")); err != nil {
+		return fmt.Errorf("failed to ping clamd: %v: %w", err, errdefs.ErrUnavailable)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil || strings.TrimRight(reply, "\x00") != "PONG" {
+		return fmt.Errorf("unexpected clamd ping reply %q: %w", reply, errdefs.ErrUnavailable)
+	}
+	return nil
+}
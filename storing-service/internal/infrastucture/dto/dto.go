@@ -2,16 +2,132 @@ package dto
 
 import (
 	"github.com/google/uuid"
+	"storing-service/internal/domain"
 	"time"
 )
 
 type CreateTaskDTO struct {
-	Id         uuid.UUID
-	FileName   string
-	UploadedBy uuid.UUID
-	CreatedAt  time.Time
+	Id             uuid.UUID
+	FileName       string
+	ObjectKey      string
+	UploadedBy     uuid.UUID
+	CourseId       string
+	AssignmentId   string
+	ExpectedSize   int64
+	ExpectedSha256 string
+	// Размер уже сохраненного файла (загрузка через сервис), 0 - файл загружается по presigned-ссылке
+	ContentSize int64
+	CreatedAt   time.Time
+	// Квоты, проверяемые в одной транзакции с созданием задачи; nil - без проверки
+	Quota *TaskQuotaDTO
+}
+
+// TaskQuotaDTO - лимиты загрузок автора и объема файлов автора и задания; нулевой лимит не проверяется
+type TaskQuotaDTO struct {
+	Window             time.Duration
+	MaxUploads         int64
+	MaxUserBytes       int64
+	MaxAssignmentBytes int64
 }
 
 type GetTaskDTO struct {
 	Id uuid.UUID
 }
+
+type GetSubmissionDTO struct {
+	Id uuid.UUID
+}
+
+// ListSubmissionTasksDTO выбирает неудаленные версии посылки по возрастанию номера
+type ListSubmissionTasksDTO struct {
+	SubmissionId uuid.UUID
+}
+
+type UpdateTaskStatusDTO struct {
+	Id            uuid.UUID
+	Status        domain.TaskStatus
+	StatusReason  string
+	ContentType   string
+	ContentSha256 string
+	ContentSize   int64
+}
+
+type FindDuplicateTaskDTO struct {
+	Id            uuid.UUID
+	UploadedBy    uuid.UUID
+	ContentSha256 string
+}
+
+type SoftDeleteTaskDTO struct {
+	Id        uuid.UUID
+	DeletedAt time.Time
+}
+
+type ListPurgeableTasksDTO struct {
+	// Задачи, удаленные не позже этого момента
+	DeletedBefore time.Time
+	Limit         int
+}
+
+type PurgeTaskDTO struct {
+	Id uuid.UUID
+	// Событие, записываемое вместе с удалением задачи
+	EventId   uuid.UUID
+	EventType domain.TaskEventType
+	CreatedAt time.Time
+}
+
+// ListUserTasksDTO выбирает все задачи автора, включая помеченные удаленными
+type ListUserTasksDTO struct {
+	UploadedBy uuid.UUID
+	Limit      int
+}
+
+type ListPendingEventsDTO struct {
+	Limit int
+}
+
+type MarkEventDTO struct {
+	Id uuid.UUID
+	// Пустая ошибка - событие доставлено
+	Error string
+}
+
+// ListExpiredTasksDTO выбирает задачи курса CourseId (пустой - любого курса, кроме ExcludeCourses),
+// созданные раньше CreatedBefore
+type ListExpiredTasksDTO struct {
+	CourseId       string
+	ExcludeCourses []string
+	CreatedBefore  time.Time
+	Limit          int
+}
+
+type ListTasksDTO struct {
+	Filter domain.TaskFilter
+	Order  domain.SortOrder
+	// nil - первая страница
+	After *domain.TaskCursor
+	Limit int
+}
+
+// ListTaskObjectsDTO выбирает все задачи, включая помеченные удаленными, по возрастанию id
+type ListTaskObjectsDTO struct {
+	AfterId uuid.UUID
+	Limit   int
+}
+
+// ExpireUploadDTO помечает задачу expired, если она все еще ожидает загрузки
+type ExpireUploadDTO struct {
+	Id     uuid.UUID
+	Reason string
+}
+
+// GetUserUsageDTO считает загрузки пользователя начиная с UploadedSince и объем его файлов
+type GetUserUsageDTO struct {
+	UploadedBy    uuid.UUID
+	UploadedSince time.Time
+}
+
+type GetAssignmentUsageDTO struct {
+	AssignmentId string
+}
@@ -0,0 +1,172 @@
+package envelope
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"storing-service/internal/config"
+	"storing-service/internal/errdefs"
+)
+
+const (
+	// magic - начало заголовка зашифрованного объекта
+	magic = "APE1"
+	// dataKeySize - ключ объекта AES-256
+	dataKeySize = 32
+	// noncePrefixSize - случайная часть nonce частей объекта, остальные 4 байта - номер части
+	noncePrefixSize = 8
+)
+
+// errNotEncrypted - объект записан до включения шифрования и хранится открытым
+var errNotEncrypted = errors.New("object is not encrypted")
+
+// Keyring шифрует ключи объектов мастер-ключами. Новые ключи шифруются активным мастер-ключом,
+// остальные мастер-ключи нужны для чтения объектов, записанных до ротации.
+type Keyring struct {
+	keys     map[string][]byte
+	activeId string
+}
+
+func NewKeyring(cfg *config.EncryptionConfig) *Keyring {
+	return &Keyring{keys: cfg.Keys, activeId: cfg.ActiveKeyId}
+}
+
+// header - заголовок объекта: идентификатор мастер-ключа, зашифрованный им ключ объекта
+// и случайная часть nonce
+type header struct {
+	keyId       string
+	wrappedKey  []byte
+	noncePrefix []byte
+}
+
+// newDataKey создает ключ объекта и заголовок с ним, зашифрованным активным мастер-ключом
+func (k *Keyring) newDataKey() ([]byte, *header, error) {
+	dataKey := make([]byte, dataKeySize)
+	noncePrefix := make([]byte, noncePrefixSize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, err
+	}
+	if _, err := rand.Read(noncePrefix); err != nil {
+		return nil, nil, err
+	}
+
+	h, err := k.wrap(dataKey, noncePrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dataKey, h, nil
+}
+
+// wrap шифрует ключ объекта активным мастер-ключом
+func (k *Keyring) wrap(dataKey, noncePrefix []byte) (*header, error) {
+	aead, err := newGCM(k.keys[k.activeId])
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return &header{
+		keyId:       k.activeId,
+		wrappedKey:  aead.Seal(nonce, nonce, dataKey, []byte(k.activeId)),
+		noncePrefix: noncePrefix,
+	}, nil
+}
+
+// unwrap расшифровывает ключ объекта мастер-ключом из заголовка
+func (k *Keyring) unwrap(h *header) ([]byte, error) {
+	master, ok := k.keys[h.keyId]
+	if !ok {
+		return nil, fmt.Errorf("master key %s is not configured: %w", h.keyId, errdefs.ErrUnavailable)
+	}
+
+	aead, err := newGCM(master)
+	if err != nil {
+		return nil, err
+	}
+	if len(h.wrappedKey) < aead.NonceSize() {
+		return nil, fmt.Errorf("invalid wrapped data key: %w", errdefs.ErrUnavailable)
+	}
+
+	nonce, sealed := h.wrappedKey[:aead.NonceSize()], h.wrappedKey[aead.NonceSize():]
+	dataKey, err := aead.Open(nil, nonce, sealed, []byte(h.keyId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key with master key %s: %w", h.keyId, errdefs.ErrUnavailable)
+	}
+	return dataKey, nil
+}
+
+// encode: magic | len(keyId) uint8 | keyId | len(wrappedKey) uint16 | wrappedKey | noncePrefix
+func (h *header) encode() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(magic)
+	buf.WriteByte(byte(len(h.keyId)))
+	buf.WriteString(h.keyId)
+	binary.Write(&buf, binary.BigEndian, uint16(len(h.wrappedKey)))
+	buf.Write(h.wrappedKey)
+	buf.Write(h.noncePrefix)
+	return buf.Bytes()
+}
+
+// readHeader читает заголовок из начала объекта. Для открытого объекта возвращает errNotEncrypted,
+// ничего не прочитав из r.
+func readHeader(r *bufio.Reader) (*header, error) {
+	prefix, err := r.Peek(len(magic))
+	if err != nil || string(prefix) != magic {
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		return nil, errNotEncrypted
+	}
+	r.Discard(len(magic))
+
+	keyIdLen, err := r.ReadByte()
+	if err != nil {
+		return nil, corrupted(err)
+	}
+	keyId := make([]byte, keyIdLen)
+	if _, err := io.ReadFull(r, keyId); err != nil {
+		return nil, corrupted(err)
+	}
+
+	var wrappedLen uint16
+	if err := binary.Read(r, binary.BigEndian, &wrappedLen); err != nil {
+		return nil, corrupted(err)
+	}
+	wrappedKey := make([]byte, wrappedLen)
+	if _, err := io.ReadFull(r, wrappedKey); err != nil {
+		return nil, corrupted(err)
+	}
+
+	noncePrefix := make([]byte, noncePrefixSize)
+	if _, err := io.ReadFull(r, noncePrefix); err != nil {
+		return nil, corrupted(err)
+	}
+
+	return &header{keyId: string(keyId), wrappedKey: wrappedKey, noncePrefix: noncePrefix}, nil
+}
+
+func (h *header) size() int64 {
+	return int64(len(magic) + 1 + len(h.keyId) + 2 + len(h.wrappedKey) + noncePrefixSize)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func corrupted(err error) error {
+	return fmt.Errorf("%w: encrypted object is corrupted: %v", errdefs.ErrUnavailable, err)
+}
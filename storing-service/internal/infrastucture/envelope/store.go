@@ -0,0 +1,360 @@
+package envelope
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"storing-service/internal/config"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"storing-service/internal/infrastucture/urlsign"
+	"strings"
+	"time"
+)
+
+// keysPrefix - префикс объектов с заголовками ключей в режиме SSE-C. Метаданные объекта SSE-C
+// нельзя прочитать без его ключа, поэтому заголовок хранится отдельным объектом.
+const keysPrefix = ".keys/"
+
+// Backend - хранилище, поверх которого шифруются файлы
+type Backend interface {
+	Put(ctx context.Context, objectKey string, reader io.Reader, contentType string) (int64, error)
+	Get(ctx context.Context, objectKey string) (io.ReadCloser, error)
+	Stat(ctx context.Context, objectKey string) (*domain.BlobInfo, error)
+	List(ctx context.Context, prefix string) ([]domain.BlobInfo, error)
+	Delete(ctx context.Context, objectKey string) error
+}
+
+// SSECBackend - хранилище, которое шифрует объекты само ключом из запроса (MinIO SSE-C)
+type SSECBackend interface {
+	Backend
+	PutSSEC(ctx context.Context, objectKey string, reader io.Reader, contentType string, key []byte) (int64, error)
+	GetSSEC(ctx context.Context, objectKey string, key []byte) (io.ReadCloser, error)
+	StatSSEC(ctx context.Context, objectKey string, key []byte) (*domain.BlobInfo, error)
+}
+
+// Store шифрует файлы конвертным шифрованием: у каждого объекта свой ключ, зашифрованный
+// мастер-ключом. В режиме client объект шифруется AES-GCM здесь и хранится с заголовком,
+// в режиме sse-c ключ объекта передается MinIO (SSE-C). Объекты, записанные до включения
+// шифрования, читаются как есть. Клиент не может читать и писать зашифрованные объекты напрямую,
+// поэтому ссылки ведут на api-gateway.
+type Store struct {
+	backend Backend
+	ssec    SSECBackend
+	keyring *Keyring
+	signer  *urlsign.Signer
+}
+
+func NewStore(backend Backend, cfg *config.EncryptionConfig, signer *urlsign.Signer) (*Store, error) {
+	s := &Store{
+		backend: backend,
+		keyring: NewKeyring(cfg),
+		signer:  signer,
+	}
+
+	if cfg.Mode == config.EncryptionSSEC {
+		ssec, ok := backend.(SSECBackend)
+		if !ok {
+			return nil, fmt.Errorf("storage backend does not support SSE-C")
+		}
+		s.ssec = ssec
+	}
+	return s, nil
+}
+
+// Put шифрует объект новым ключом и возвращает размер открытого текста
+func (s *Store) Put(ctx context.Context, objectKey string, reader io.Reader, contentType string) (int64, error) {
+	dataKey, h, err := s.keyring.newDataKey()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	counter := &countingReader{reader: reader}
+	if s.ssec != nil {
+		if _, err := s.ssec.PutSSEC(ctx, objectKey, counter, contentType, dataKey); err != nil {
+			return 0, err
+		}
+		// Без заголовка объект не расшифровать, поэтому при ошибке он удаляется
+		if _, err := s.backend.Put(ctx, keysPrefix+objectKey, bytes.NewReader(h.encode()), "application/octet-stream"); err != nil {
+			if rmErr := s.backend.Delete(context.WithoutCancel(ctx), objectKey); rmErr != nil {
+				return 0, fmt.Errorf("%w (object without key header left: %v)", err, rmErr)
+			}
+			return 0, err
+		}
+		return counter.n, nil
+	}
+
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return 0, err
+	}
+	encrypted := io.MultiReader(bytes.NewReader(h.encode()), newEncryptReader(counter, aead, h.noncePrefix))
+	if _, err := s.backend.Put(ctx, objectKey, encrypted, contentType); err != nil {
+		return 0, err
+	}
+	return counter.n, nil
+}
+
+func (s *Store) Get(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	if s.ssec != nil {
+		h, err := s.keyHeader(ctx, objectKey)
+		if errors.Is(err, errNotEncrypted) {
+			return s.backend.Get(ctx, objectKey)
+		}
+		if err != nil {
+			return nil, err
+		}
+		dataKey, err := s.keyring.unwrap(h)
+		if err != nil {
+			return nil, err
+		}
+		return s.ssec.GetSSEC(ctx, objectKey, dataKey)
+	}
+
+	obj, err := s.backend.Get(ctx, objectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	r := bufio.NewReaderSize(obj, chunkSize)
+	h, err := readHeader(r)
+	if errors.Is(err, errNotEncrypted) {
+		return &bufferedReadCloser{Reader: r, Closer: obj}, nil
+	}
+	if err != nil {
+		obj.Close()
+		return nil, err
+	}
+
+	dataKey, err := s.keyring.unwrap(h)
+	if err != nil {
+		obj.Close()
+		return nil, err
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		obj.Close()
+		return nil, err
+	}
+	return newDecryptReader(r, aead, h.noncePrefix, obj), nil
+}
+
+// Stat возвращает сведения об объекте с размером открытого текста
+func (s *Store) Stat(ctx context.Context, objectKey string) (*domain.BlobInfo, error) {
+	if s.ssec != nil {
+		h, err := s.keyHeader(ctx, objectKey)
+		if errors.Is(err, errNotEncrypted) {
+			return s.backend.Stat(ctx, objectKey)
+		}
+		if err != nil {
+			return nil, err
+		}
+		dataKey, err := s.keyring.unwrap(h)
+		if err != nil {
+			return nil, err
+		}
+		return s.ssec.StatSSEC(ctx, objectKey, dataKey)
+	}
+
+	info, err := s.backend.Stat(ctx, objectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := s.backend.Get(ctx, objectKey)
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+
+	h, err := readHeader(bufio.NewReader(obj))
+	if errors.Is(err, errNotEncrypted) {
+		return info, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info.Size = plaintextSize(info.Size - h.size())
+	return info, nil
+}
+
+// List возвращает объекты с размером в хранилище (для client - вместе с заголовком и тегами GCM)
+func (s *Store) List(ctx context.Context, prefix string) ([]domain.BlobInfo, error) {
+	blobs, err := s.backend.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := blobs[:0]
+	for _, blob := range blobs {
+		if !strings.HasPrefix(blob.Key, keysPrefix) {
+			filtered = append(filtered, blob)
+		}
+	}
+	return filtered, nil
+}
+
+func (s *Store) Delete(ctx context.Context, objectKey string) error {
+	if err := s.backend.Delete(ctx, objectKey); err != nil {
+		return err
+	}
+	if s.ssec != nil {
+		return s.backend.Delete(ctx, keysPrefix+objectKey)
+	}
+	return nil
+}
+
+// PresignGet возвращает подписанную ссылку на скачивание через api-gateway
+func (s *Store) PresignGet(ctx context.Context, objectKey string, expiry time.Duration) (*url.URL, error) {
+	return s.signer.PresignGet(objectKey, expiry), nil
+}
+
+// PresignPost возвращает подписанную ссылку на загрузку через api-gateway
+func (s *Store) PresignPost(ctx context.Context, objectKey string, size int64, sha256 []byte, expiry time.Duration) (*url.URL, map[string]string, error) {
+	u, formData := s.signer.PresignPost(objectKey, size, sha256, expiry)
+	return u, formData, nil
+}
+
+// VerifySignedURL проверяет ссылку, выданную PresignGet или PresignPost
+func (s *Store) VerifySignedURL(method, objectKey string, query url.Values) error {
+	return s.signer.VerifySignedURL(method, objectKey, query)
+}
+
+// Rewrap перешифровывает ключ объекта активным мастер-ключом, а открытый объект шифрует.
+// Возвращает false, если объект уже зашифрован активным ключом. Содержимое объекта не перешифровывается,
+// но в режиме client объект перезаписывается, так как заголовок хранится в нем самом.
+func (s *Store) Rewrap(ctx context.Context, objectKey string) (bool, error) {
+	if s.ssec != nil {
+		h, err := s.keyHeader(ctx, objectKey)
+		if errors.Is(err, errNotEncrypted) {
+			return true, s.encryptPlain(ctx, objectKey)
+		}
+		if err != nil {
+			return false, err
+		}
+		if h.keyId == s.keyring.activeId {
+			return false, nil
+		}
+
+		rewrapped, err := s.rewrapHeader(h)
+		if err != nil {
+			return false, err
+		}
+		_, err = s.backend.Put(ctx, keysPrefix+objectKey, bytes.NewReader(rewrapped.encode()), "application/octet-stream")
+		return err == nil, err
+	}
+
+	obj, err := s.backend.Get(ctx, objectKey)
+	if err != nil {
+		return false, err
+	}
+	defer obj.Close()
+
+	r := bufio.NewReaderSize(obj, chunkSize)
+	h, err := readHeader(r)
+	if errors.Is(err, errNotEncrypted) {
+		return true, s.encryptPlain(ctx, objectKey)
+	}
+	if err != nil {
+		return false, err
+	}
+	if h.keyId == s.keyring.activeId {
+		return false, nil
+	}
+
+	rewrapped, err := s.rewrapHeader(h)
+	if err != nil {
+		return false, err
+	}
+
+	// Части объекта не зависят от мастер-ключа: меняется только заголовок. Объект перезаписывается
+	// из временной копии, а не из потока чтения того же объекта.
+	err = withTempCopy(r, func(body io.Reader) error {
+		_, err := s.backend.Put(ctx, objectKey, io.MultiReader(bytes.NewReader(rewrapped.encode()), body), "")
+		return err
+	})
+	return err == nil, err
+}
+
+func (s *Store) rewrapHeader(h *header) (*header, error) {
+	dataKey, err := s.keyring.unwrap(h)
+	if err != nil {
+		return nil, err
+	}
+	return s.keyring.wrap(dataKey, h.noncePrefix)
+}
+
+// encryptPlain шифрует объект, записанный до включения шифрования
+func (s *Store) encryptPlain(ctx context.Context, objectKey string) error {
+	info, err := s.backend.Stat(ctx, objectKey)
+	if err != nil {
+		return err
+	}
+	obj, err := s.backend.Get(ctx, objectKey)
+	if err != nil {
+		return err
+	}
+	defer obj.Close()
+
+	return withTempCopy(obj, func(body io.Reader) error {
+		_, err := s.Put(ctx, objectKey, body, info.ContentType)
+		return err
+	})
+}
+
+// keyHeader читает заголовок ключа объекта SSE-C
+func (s *Store) keyHeader(ctx context.Context, objectKey string) (*header, error) {
+	obj, err := s.backend.Get(ctx, keysPrefix+objectKey)
+	if errors.Is(err, errdefs.ErrNotFound) {
+		return nil, errNotEncrypted
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+
+	return readHeader(bufio.NewReader(obj))
+}
+
+// withTempCopy копирует src во временный файл и передает его в fn
+func withTempCopy(src io.Reader, fn func(io.Reader) error) error {
+	tmp, err := os.CreateTemp("", "rewrap-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", errdefs.ErrUnavailable)
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	if _, err := io.Copy(tmp, src); err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return fn(tmp)
+}
+
+type bufferedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// countingReader считает прочитанные байты открытого текста
+type countingReader struct {
+	reader io.Reader
+	n      int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,221 @@
+package fsstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"storing-service/internal/config"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"storing-service/internal/infrastucture/urlsign"
+	"strings"
+	"time"
+)
+
+// tempPrefix - префикс незавершенных загрузок, такие файлы не видны через List
+const tempPrefix = ".upload-"
+
+// Store хранит файлы в локальном каталоге. Вместо presigned-ссылок хранилища выдает ссылки
+// на api-gateway, подписанные signer; подпись проверяется в storing-service.
+type Store struct {
+	root   string
+	signer *urlsign.Signer
+}
+
+func NewStore(cfg *config.StorageConfig, signer *urlsign.Signer) (*Store, error) {
+	if err := os.MkdirAll(cfg.FSRoot, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage root %s: %w", cfg.FSRoot, err)
+	}
+
+	return &Store{
+		root:   cfg.FSRoot,
+		signer: signer,
+	}, nil
+}
+
+// path возвращает путь файла объекта. Ключ не может выходить за пределы каталога хранилища.
+func (s *Store) path(objectKey string) (string, error) {
+	if !fs.ValidPath(objectKey) || objectKey == "." || strings.Contains(objectKey, `\`) {
+		return "", fmt.Errorf("invalid object key %q: %w", objectKey, errdefs.ErrInvalidArgument)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(objectKey)), nil
+}
+
+func (s *Store) Stat(ctx context.Context, objectKey string) (*domain.BlobInfo, error) {
+	p, err := s.path(objectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, handleFSError(err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("object %s: %w", objectKey, errdefs.ErrNotFound)
+	}
+	return toBlobInfo(objectKey, info), nil
+}
+
+func (s *Store) Get(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	p, err := s.path(objectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(p)
+	if err != nil {
+		return nil, handleFSError(err)
+	}
+	return file, nil
+}
+
+// Put записывает поток во временный файл рядом с объектом и переименовывает его,
+// поэтому читатели никогда не видят частично записанный объект
+func (s *Store) Put(ctx context.Context, objectKey string, reader io.Reader, contentType string) (int64, error) {
+	p, err := s.path(objectKey)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return 0, handleFSError(err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), tempPrefix+"*")
+	if err != nil {
+		return 0, handleFSError(err)
+	}
+	defer os.Remove(tmp.Name())
+
+	size, err := io.Copy(tmp, &contextReader{ctx: ctx, reader: reader})
+	if err != nil {
+		tmp.Close()
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		return 0, err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return 0, handleFSError(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, handleFSError(err)
+	}
+
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return 0, handleFSError(err)
+	}
+	return size, nil
+}
+
+func (s *Store) List(ctx context.Context, prefix string) ([]domain.BlobInfo, error) {
+	var blobs []domain.BlobInfo
+	err := filepath.WalkDir(s.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), tempPrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			// Файл удален во время обхода
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		blobs = append(blobs, *toBlobInfo(key, info))
+		return nil
+	})
+	if err != nil {
+		return nil, handleFSError(err)
+	}
+	return blobs, nil
+}
+
+// Delete удаляет объект; отсутствие объекта ошибкой не считается
+func (s *Store) Delete(ctx context.Context, objectKey string) error {
+	p, err := s.path(objectKey)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return handleFSError(err)
+	}
+	return nil
+}
+
+// PresignGet возвращает подписанную ссылку на скачивание через api-gateway
+func (s *Store) PresignGet(ctx context.Context, objectKey string, expiry time.Duration) (*url.URL, error) {
+	if _, err := s.path(objectKey); err != nil {
+		return nil, err
+	}
+	return s.signer.PresignGet(objectKey, expiry), nil
+}
+
+// PresignPost возвращает подписанную ссылку на загрузку через api-gateway
+func (s *Store) PresignPost(ctx context.Context, objectKey string, size int64, sha256 []byte, expiry time.Duration) (*url.URL, map[string]string, error) {
+	if _, err := s.path(objectKey); err != nil {
+		return nil, nil, err
+	}
+	u, formData := s.signer.PresignPost(objectKey, size, sha256, expiry)
+	return u, formData, nil
+}
+
+// VerifySignedURL проверяет ссылку, выданную PresignGet или PresignPost
+func (s *Store) VerifySignedURL(method, objectKey string, query url.Values) error {
+	return s.signer.VerifySignedURL(method, objectKey, query)
+}
+
+func toBlobInfo(objectKey string, info fs.FileInfo) *domain.BlobInfo {
+	return &domain.BlobInfo{
+		Key:         objectKey,
+		Size:        info.Size(),
+		ContentType: mime.TypeByExtension(path.Ext(objectKey)),
+		ModifiedAt:  info.ModTime(),
+	}
+}
+
+func handleFSError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %v", errdefs.ErrNotFound, err)
+	}
+	return fmt.Errorf("%w: %v", errdefs.ErrUnavailable, err)
+}
+
+// contextReader прерывает запись файла при отмене контекста
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.reader.Read(p)
+}
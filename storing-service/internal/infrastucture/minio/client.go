@@ -3,16 +3,24 @@ package minio
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"storing-service/internal/config"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
 	"strings"
 	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
 )
 
+// streamPartSize - размер части multipart-загрузки потока неизвестной длины.
+// Без явного значения minio-go выбирает часть из расчета на объект в 5 ТиБ и буферизует ее в памяти.
+const streamPartSize = 16 << 20
+
 type Client struct {
 	internalClient   *minio.Client
 	externalEndpoint string
@@ -33,7 +41,7 @@ func NewClient(ctx context.Context, cfg *config.MinioConfig) (*Client, error) {
 	for i := 0; i < maxRetries; i++ {
 		internalClient, err = minio.New(internalEndpoint, &minio.Options{
 			Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
-			Secure: false,
+			Secure: cfg.UseSSL,
 		})
 		if err == nil {
 			break
@@ -78,25 +86,40 @@ func NewClient(ctx context.Context, cfg *config.MinioConfig) (*Client, error) {
 	return nil, fmt.Errorf("failed to check/create bucket after %d retries: %w", maxRetries, err)
 }
 
-// PresignedPutObject генерирует presigned URL через internal client с Host header для external endpoint
-func (c *Client) PresignedPutObject(ctx context.Context, objectKey string, expiry time.Duration) (*url.URL, error) {
-	headers := make(http.Header)
-	headers.Set("Host", c.externalEndpoint)
+// PresignPost генерирует presigned POST policy, которая допускает загрузку только файла
+// заданного размера с заданной контрольной суммой SHA-256
+func (c *Client) PresignPost(ctx context.Context, objectKey string, size int64, sha256 []byte, expiry time.Duration) (*url.URL, map[string]string, error) {
+	policy := minio.NewPostPolicy()
+	if err := policy.SetBucket(c.bucket); err != nil {
+		return nil, nil, err
+	}
+	if err := policy.SetKey(objectKey); err != nil {
+		return nil, nil, err
+	}
+	if err := policy.SetExpires(time.Now().UTC().Add(expiry)); err != nil {
+		return nil, nil, err
+	}
+	if err := policy.SetContentLengthRange(size, size); err != nil {
+		return nil, nil, err
+	}
+	if err := policy.SetChecksum(minio.NewChecksum(minio.ChecksumSHA256, sha256)); err != nil {
+		return nil, nil, err
+	}
 
-	presignedURL, err := c.internalClient.PresignHeader(ctx, http.MethodPut, c.bucket, objectKey, expiry, nil, headers)
+	presignedURL, formData, err := c.internalClient.PresignedPostPolicy(ctx, policy)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return c.replaceHost(presignedURL), nil
+	return c.replaceHost(presignedURL), formData, nil
 }
 
-// PresignedGetObject генерирует presigned URL через internal client с Host header для external endpoint
-func (c *Client) PresignedGetObject(ctx context.Context, objectKey string, expiry time.Duration, reqParams url.Values) (*url.URL, error) {
+// PresignGet генерирует presigned URL через internal client с Host header для external endpoint
+func (c *Client) PresignGet(ctx context.Context, objectKey string, expiry time.Duration) (*url.URL, error) {
 	headers := make(http.Header)
 	headers.Set("Host", c.externalEndpoint)
 
-	presignedURL, err := c.internalClient.PresignHeader(ctx, http.MethodGet, c.bucket, objectKey, expiry, reqParams, headers)
+	presignedURL, err := c.internalClient.PresignHeader(ctx, http.MethodGet, c.bucket, objectKey, expiry, nil, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -116,12 +139,124 @@ func (c *Client) replaceHost(u *url.URL) *url.URL {
 	return &newURL
 }
 
-// StatObject использует internal client для проверки существования файла
-func (c *Client) StatObject(ctx context.Context, objectKey string, opts minio.StatObjectOptions) (minio.ObjectInfo, error) {
-	return c.internalClient.StatObject(ctx, c.bucket, objectKey, opts)
+// Stat возвращает сведения об объекте, errdefs.ErrNotFound - если его нет
+func (c *Client) Stat(ctx context.Context, objectKey string) (*domain.BlobInfo, error) {
+	info, err := c.internalClient.StatObject(ctx, c.bucket, objectKey, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, handleMinioError(err)
+	}
+	return toBlobInfo(info), nil
+}
+
+// Get открывает объект на чтение. Наличие объекта проверяется сразу, а не при первом чтении.
+func (c *Client) Get(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	obj, err := c.internalClient.GetObject(ctx, c.bucket, objectKey, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, handleMinioError(err)
+	}
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		return nil, handleMinioError(err)
+	}
+	return obj, nil
+}
+
+// Put загружает поток неизвестной длины частями по streamPartSize и возвращает размер объекта
+func (c *Client) Put(ctx context.Context, objectKey string, reader io.Reader, contentType string) (int64, error) {
+	info, err := c.internalClient.PutObject(ctx, c.bucket, objectKey, reader, -1, minio.PutObjectOptions{
+		ContentType: contentType,
+		PartSize:    streamPartSize,
+	})
+	if err != nil {
+		return 0, handleMinioError(err)
+	}
+	return info.Size, nil
+}
+
+// List возвращает все объекты bucket с заданным префиксом
+func (c *Client) List(ctx context.Context, prefix string) ([]domain.BlobInfo, error) {
+	var blobs []domain.BlobInfo
+	for object := range c.internalClient.ListObjects(ctx, c.bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	}) {
+		if object.Err != nil {
+			return nil, handleMinioError(object.Err)
+		}
+		blobs = append(blobs, *toBlobInfo(object))
+	}
+	return blobs, nil
+}
+
+// Delete удаляет объект; отсутствие объекта ошибкой не считается
+func (c *Client) Delete(ctx context.Context, objectKey string) error {
+	return handleMinioError(c.internalClient.RemoveObject(ctx, c.bucket, objectKey, minio.RemoveObjectOptions{}))
 }
 
-// GetObject использует internal client для чтения файла
-func (c *Client) GetObject(ctx context.Context, objectKey string, opts minio.GetObjectOptions) (*minio.Object, error) {
-	return c.internalClient.GetObject(ctx, c.bucket, objectKey, opts)
+// PutSSEC загружает объект, который MinIO шифрует ключом key (SSE-C). Требует TLS до MinIO.
+func (c *Client) PutSSEC(ctx context.Context, objectKey string, reader io.Reader, contentType string, key []byte) (int64, error) {
+	sse, err := encrypt.NewSSEC(key)
+	if err != nil {
+		return 0, err
+	}
+	info, err := c.internalClient.PutObject(ctx, c.bucket, objectKey, reader, -1, minio.PutObjectOptions{
+		ContentType:          contentType,
+		PartSize:             streamPartSize,
+		ServerSideEncryption: sse,
+	})
+	if err != nil {
+		return 0, handleMinioError(err)
+	}
+	return info.Size, nil
+}
+
+// GetSSEC открывает объект, зашифрованный ключом key (SSE-C)
+func (c *Client) GetSSEC(ctx context.Context, objectKey string, key []byte) (io.ReadCloser, error) {
+	sse, err := encrypt.NewSSEC(key)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := c.internalClient.GetObject(ctx, c.bucket, objectKey, minio.GetObjectOptions{ServerSideEncryption: sse})
+	if err != nil {
+		return nil, handleMinioError(err)
+	}
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		return nil, handleMinioError(err)
+	}
+	return obj, nil
+}
+
+// StatSSEC возвращает сведения об объекте, зашифрованном ключом key (SSE-C)
+func (c *Client) StatSSEC(ctx context.Context, objectKey string, key []byte) (*domain.BlobInfo, error) {
+	sse, err := encrypt.NewSSEC(key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := c.internalClient.StatObject(ctx, c.bucket, objectKey, minio.StatObjectOptions{ServerSideEncryption: sse})
+	if err != nil {
+		return nil, handleMinioError(err)
+	}
+	return toBlobInfo(info), nil
+}
+
+func toBlobInfo(info minio.ObjectInfo) *domain.BlobInfo {
+	return &domain.BlobInfo{
+		Key:         info.Key,
+		Size:        info.Size,
+		ContentType: info.ContentType,
+		ModifiedAt:  info.LastModified,
+	}
+}
+
+func handleMinioError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey", "NotFound":
+		return fmt.Errorf("%w: %v", errdefs.ErrNotFound, err)
+	default:
+		return fmt.Errorf("%w: %v", errdefs.ErrUnavailable, err)
+	}
 }
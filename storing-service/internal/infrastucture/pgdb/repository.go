@@ -2,24 +2,203 @@ package pgdb
 
 import (
 	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
 	"storing-service/internal/infrastucture/dto"
+	"storing-service/pkg/tenant"
+	"strings"
+	"time"
 )
 
+// Все запросы, кроме listTenantsQuery, ограничены тенантом из контекста: задачи, посылки, события
+// и отчеты о хранении одного тенанта не видны другому, даже по известному идентификатору.
 const (
+	registerTenantQuery = `
+INSERT INTO tenants (id)
+VALUES ($1)
+ON CONFLICT (id) DO NOTHING`
+
+	listTenantsQuery = `
+SELECT id
+FROM tenants
+ORDER BY id`
+
 	createTaskQuery = `
-INSERT INTO tasks (id, filename, uploaded_by, created_at) 
-VALUES ($1, $2, $3, $4)
-RETURNING id`
+INSERT INTO tasks (id, filename, uploaded_by, course_id, assignment_id, expected_size, expected_sha256, created_at, submission_id, version, object_key, tenant_id, content_size)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING id, status`
+
+	// Блокировка квоты автора или задания до конца транзакции: подсчет использования и создание
+	// задачи выполняются под ней, поэтому параллельные загрузки не превышают лимит
+	lockQuotaQuery = `
+SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`
+
+	// Строка посылки блокируется до конца транзакции, поэтому номера версий выдаются последовательно
+	upsertSubmissionQuery = `
+INSERT INTO submissions (id, uploaded_by, course_id, assignment_id, created_at, tenant_id)
+VALUES (gen_random_uuid(), $1, $2, $3, $4, $5)
+ON CONFLICT (tenant_id, uploaded_by, course_id, assignment_id) DO UPDATE SET last_version = submissions.last_version + 1
+RETURNING id, last_version`
+
+	getSubmissionQuery = `
+SELECT id, uploaded_by, course_id, assignment_id, created_at
+FROM submissions
+WHERE id = $1 AND tenant_id = $2`
+
+	deleteEmptySubmissionQuery = `
+DELETE FROM submissions s
+WHERE s.id = $1 AND s.tenant_id = $2 AND NOT EXISTS (SELECT 1 FROM tasks t WHERE t.submission_id = s.id)`
+
+	taskColumns = `id, filename, uploaded_by, course_id, assignment_id, status, status_reason, expected_size, expected_sha256, content_sha256, content_type, object_key, submission_id, COALESCE(version, 0), created_at`
+
+	listSubmissionTasksQuery = `
+SELECT ` + taskColumns + `
+FROM tasks
+WHERE submission_id = $1 AND tenant_id = $2 AND deleted_at IS NULL
+ORDER BY version`
 
 	getTaskQuery = `
-SELECT filename, uploaded_by, created_at
+SELECT ` + taskColumns + `
 FROM tasks
-WHERE id = $1`
+WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`
+
+	updateTaskStatusQuery = `
+UPDATE tasks
+SET status = $2, status_reason = $3, content_type = $4, content_sha256 = $5, content_size = $6
+WHERE id = $1 AND tenant_id = $7`
+
+	// Оригиналом считается самая ранняя проверенная задача другого пользователя тенанта с тем же содержимым
+	findDuplicateTaskQuery = `
+SELECT ` + taskColumns + `
+FROM tasks
+WHERE tenant_id = $4 AND content_sha256 = $1 AND id <> $2 AND uploaded_by <> $3 AND status = 'verified' AND deleted_at IS NULL
+ORDER BY created_at, id
+LIMIT 1`
+
+	// Направление сортировки и сравнение с курсором подставляются в зависимости от порядка
+	listTasksQuery = `
+SELECT ` + taskColumns + `
+FROM tasks
+WHERE tenant_id = $15
+  AND deleted_at IS NULL
+  AND ($1::uuid IS NULL OR uploaded_by = $1)
+  AND ($2 = '' OR course_id = $2)
+  AND ($3 = '' OR assignment_id = $3)
+  AND ($4 = '' OR status = $4)
+  AND ($5::timestamp IS NULL OR created_at >= $5)
+  AND ($6::timestamp IS NULL OR created_at < $6)
+  AND ($7 = '' OR filename ILIKE '%%' || $7 || '%%')
+  AND ($8::timestamp IS NULL OR (created_at, id) %s ($8, $9))
+  AND ($11::uuid[] IS NULL OR id = ANY($11))
+  AND (NOT $12 OR uploaded_by = $13 OR course_id = ANY($14))
+ORDER BY created_at %s, id %s
+LIMIT $10`
+
+	// Для политики курса $3 пуст, для политики по умолчанию $2 пуст, а $3 - курсы с собственной политикой
+	listExpiredTasksQuery = `
+SELECT ` + taskColumns + `
+FROM tasks
+WHERE tenant_id = $5
+  AND deleted_at IS NULL
+  AND created_at < $1
+  AND ($2 = '' OR course_id = $2)
+  AND NOT (course_id = ANY($3))
+ORDER BY created_at, id
+LIMIT $4`
+
+	listTaskObjectsQuery = `
+SELECT ` + taskColumns + `, deleted_at IS NOT NULL
+FROM tasks
+WHERE tenant_id = $3 AND id > $1
+ORDER BY id
+LIMIT $2`
+
+	// Задача, проверка которой успела начаться, не меняется
+	expireUploadQuery = `
+UPDATE tasks
+SET status = 'expired', status_reason = $2
+WHERE id = $1 AND tenant_id = $3 AND status = 'pending' AND deleted_at IS NULL`
+
+	// Объем считается по неудаленным задачам, файл которых хранится или ожидается: для pending -
+	// по заявленному размеру (presigned-ссылка не пропустит больший файл) или по размеру файла,
+	// уже сохраненного при загрузке через сервис, для остальных - по фактическому
+	usageBytes = `
+COALESCE(SUM(CASE WHEN status = 'pending' THEN GREATEST(expected_size, content_size) ELSE content_size END)
+    FILTER (WHERE deleted_at IS NULL AND status IN ('pending', 'verified', 'unverified')), 0)`
+
+	// Загрузки считаются вместе с удаленными задачами, чтобы удаление не обходило лимит
+	getUserUsageQuery = `
+SELECT COUNT(*) FILTER (WHERE created_at >= $2), ` + usageBytes + `
+FROM tasks
+WHERE tenant_id = $3 AND uploaded_by = $1`
+
+	getAssignmentUsageQuery = `
+SELECT ` + usageBytes + `
+FROM tasks
+WHERE tenant_id = $2 AND assignment_id = $1`
+
+	createRetentionRunQuery = `
+INSERT INTO retention_runs (id, dry_run, expired, purged, failed, started_at, finished_at, tenant_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	createRetentionRunItemQuery = `
+INSERT INTO retention_run_items (run_id, task_id, course_id, task_created_at, retain_until, error)
+VALUES ($1, $2, $3, $4, $5, $6)`
+
+	softDeleteTaskQuery = `
+UPDATE tasks
+SET deleted_at = $2
+WHERE id = $1 AND tenant_id = $3 AND deleted_at IS NULL`
+
+	listUserTasksQuery = `
+SELECT ` + taskColumns + `
+FROM tasks
+WHERE tenant_id = $3 AND uploaded_by = $1
+ORDER BY created_at, id
+LIMIT $2`
+
+	listPurgeableTasksQuery = `
+SELECT ` + taskColumns + `
+FROM tasks
+WHERE tenant_id = $3 AND deleted_at IS NOT NULL AND deleted_at <= $1
+ORDER BY deleted_at, id
+LIMIT $2`
+
+	deleteTaskQuery = `
+DELETE FROM tasks
+WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NOT NULL
+RETURNING submission_id`
+
+	createTaskEventQuery = `
+INSERT INTO task_events (id, task_id, type, created_at, tenant_id)
+VALUES ($1, $2, $3, $4, $5)`
+
+	listPendingEventsQuery = `
+SELECT id, task_id, type, attempts, created_at
+FROM task_events
+WHERE tenant_id = $2 AND delivered_at IS NULL
+ORDER BY created_at, id
+LIMIT $1`
+
+	markEventDeliveredQuery = `
+UPDATE task_events
+SET delivered_at = now(), attempts = attempts + 1, last_error = ''
+WHERE id = $1 AND tenant_id = $2`
+
+	markEventFailedQuery = `
+UPDATE task_events
+SET attempts = attempts + 1, last_error = $2
+WHERE id = $1 AND tenant_id = $3`
 )
 
+// likeEscaper экранирует спецсимволы шаблона LIKE в пользовательском вводе
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type StoringRepository struct {
 	db     *pgxpool.Pool
 	logger *zap.Logger
@@ -32,16 +211,71 @@ func NewStoringRepository(db *pgxpool.Pool, logger *zap.Logger) *StoringReposito
 	}
 }
 
+// CreateTask создает задачу. Задача с assignment_id становится новой версией посылки автора
+// по этому заданию; посылка создается при первой версии.
 func (r *StoringRepository) CreateTask(ctx context.Context, dto *dto.CreateTaskDTO) (*domain.TaskMetadata, error) {
 	r.logger.Debug("executing create task query",
 		zap.String("task_id", dto.Id.String()),
 		zap.String("filename", dto.FileName))
 
-	err := r.db.QueryRow(ctx, createTaskQuery,
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.logger.Error("failed to begin transaction", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	tenantId := tenant.FromContext(ctx)
+	if _, err := tx.Exec(ctx, registerTenantQuery, tenantId); err != nil {
+		r.logger.Error("register tenant query failed",
+			zap.String("tenant_id", tenantId),
+			zap.Error(err))
+		return nil, handleDBError(err)
+	}
+
+	if dto.Quota != nil {
+		if err := r.checkTaskQuota(ctx, tx, tenantId, dto); err != nil {
+			return nil, err
+		}
+	}
+
+	var submissionId uuid.UUID
+	var version int
+	if dto.AssignmentId != "" {
+		err = tx.QueryRow(ctx, upsertSubmissionQuery,
+			dto.UploadedBy,
+			dto.CourseId,
+			dto.AssignmentId,
+			dto.CreatedAt,
+			tenantId).Scan(&submissionId, &version)
+		if err != nil {
+			r.logger.Error("upsert submission query failed",
+				zap.String("task_id", dto.Id.String()),
+				zap.Error(err))
+			return nil, handleDBError(err)
+		}
+	}
+
+	var versionArg *int
+	if version > 0 {
+		versionArg = &version
+	}
+
+	var status domain.TaskStatus
+	err = tx.QueryRow(ctx, createTaskQuery,
 		dto.Id,
 		dto.FileName,
 		dto.UploadedBy,
-		dto.CreatedAt).Scan(&dto.Id)
+		dto.CourseId,
+		dto.AssignmentId,
+		dto.ExpectedSize,
+		dto.ExpectedSha256,
+		dto.CreatedAt,
+		nullableUUID(submissionId),
+		versionArg,
+		dto.ObjectKey,
+		tenantId,
+		dto.ContentSize).Scan(&dto.Id, &status)
 
 	if err != nil {
 		r.logger.Error("create task query failed",
@@ -50,27 +284,140 @@ func (r *StoringRepository) CreateTask(ctx context.Context, dto *dto.CreateTaskD
 		return nil, handleDBError(err)
 	}
 
-	r.logger.Debug("task created in database", zap.String("task_id", dto.Id.String()))
+	if err := tx.Commit(ctx); err != nil {
+		r.logger.Error("failed to commit task creation",
+			zap.String("task_id", dto.Id.String()),
+			zap.Error(err))
+		return nil, handleDBError(err)
+	}
+
+	r.logger.Debug("task created in database",
+		zap.String("task_id", dto.Id.String()),
+		zap.String("submission_id", submissionId.String()),
+		zap.Int("version", version))
 
 	return &domain.TaskMetadata{
-		Id:         dto.Id,
-		Filename:   dto.FileName,
-		UploadedBy: dto.UploadedBy,
-		CreatedAt:  dto.CreatedAt,
+		Id:             dto.Id,
+		Filename:       dto.FileName,
+		UploadedBy:     dto.UploadedBy,
+		CourseId:       dto.CourseId,
+		AssignmentId:   dto.AssignmentId,
+		Status:         status,
+		ExpectedSize:   dto.ExpectedSize,
+		ExpectedSha256: dto.ExpectedSha256,
+		ObjectKey:      dto.ObjectKey,
+		SubmissionId:   submissionId,
+		Version:        version,
+		CreatedAt:      dto.CreatedAt,
 	}, nil
 }
 
-func (r *StoringRepository) GetTask(ctx context.Context, dto *dto.GetTaskDTO) (*domain.TaskMetadata, error) {
-	r.logger.Debug("executing get task query", zap.String("task_id", dto.Id.String()))
+// checkTaskQuota проверяет квоты новой задачи в транзакции ее создания. Блокировки берутся всегда
+// в порядке автор, затем задание, поэтому параллельные транзакции не блокируют друг друга взаимно.
+func (r *StoringRepository) checkTaskQuota(ctx context.Context, tx pgx.Tx, tenantId string, dto *dto.CreateTaskDTO) error {
+	quota := dto.Quota
+	size := max(dto.ExpectedSize, dto.ContentSize)
 
-	task := &domain.TaskMetadata{}
-	err := r.db.QueryRow(ctx, getTaskQuery, dto.Id).Scan(
-		&task.Filename,
-		&task.UploadedBy,
-		&task.CreatedAt,
+	if quota.MaxUploads > 0 || quota.MaxUserBytes > 0 {
+		if _, err := tx.Exec(ctx, lockQuotaQuery, "quota:user:"+tenantId+":"+dto.UploadedBy.String()); err != nil {
+			r.logger.Error("lock user quota query failed",
+				zap.String("uploaded_by", dto.UploadedBy.String()),
+				zap.Error(err))
+			return handleDBError(err)
+		}
+
+		var uploads, bytes int64
+		err := tx.QueryRow(ctx, getUserUsageQuery, dto.UploadedBy, dto.CreatedAt.Add(-quota.Window), tenantId).Scan(&uploads, &bytes)
+		if err != nil {
+			r.logger.Error("get user usage query failed",
+				zap.String("uploaded_by", dto.UploadedBy.String()),
+				zap.Error(err))
+			return handleDBError(err)
+		}
+
+		if quota.MaxUploads > 0 && uploads >= quota.MaxUploads {
+			return fmt.Errorf("at most %d uploads per %s allowed: %w", quota.MaxUploads, quota.Window, errdefs.ErrQuotaExceeded)
+		}
+		if quota.MaxUserBytes > 0 && bytes+size > quota.MaxUserBytes {
+			return fmt.Errorf("user storage quota of %d bytes exceeded: %w", quota.MaxUserBytes, errdefs.ErrQuotaExceeded)
+		}
+	}
+
+	if quota.MaxAssignmentBytes > 0 && dto.AssignmentId != "" {
+		if _, err := tx.Exec(ctx, lockQuotaQuery, "quota:assignment:"+tenantId+":"+dto.AssignmentId); err != nil {
+			r.logger.Error("lock assignment quota query failed",
+				zap.String("assignment_id", dto.AssignmentId),
+				zap.Error(err))
+			return handleDBError(err)
+		}
+
+		var bytes int64
+		if err := tx.QueryRow(ctx, getAssignmentUsageQuery, dto.AssignmentId, tenantId).Scan(&bytes); err != nil {
+			r.logger.Error("get assignment usage query failed",
+				zap.String("assignment_id", dto.AssignmentId),
+				zap.Error(err))
+			return handleDBError(err)
+		}
+
+		if bytes+size > quota.MaxAssignmentBytes {
+			return fmt.Errorf("assignment storage quota of %d bytes exceeded: %w", quota.MaxAssignmentBytes, errdefs.ErrQuotaExceeded)
+		}
+	}
+
+	return nil
+}
+
+func (r *StoringRepository) GetSubmission(ctx context.Context, dto *dto.GetSubmissionDTO) (*domain.Submission, error) {
+	r.logger.Debug("executing get submission query", zap.String("submission_id", dto.Id.String()))
+
+	submission := &domain.Submission{}
+	err := r.db.QueryRow(ctx, getSubmissionQuery, dto.Id, tenant.FromContext(ctx)).Scan(
+		&submission.Id,
+		&submission.UploadedBy,
+		&submission.CourseId,
+		&submission.AssignmentId,
+		&submission.CreatedAt,
 	)
-	task.Id = dto.Id
+	if err != nil {
+		r.logger.Error("get submission query failed",
+			zap.String("submission_id", dto.Id.String()),
+			zap.Error(err))
+		return nil, handleDBError(err)
+	}
 
+	return submission, nil
+}
+
+func (r *StoringRepository) ListSubmissionTasks(ctx context.Context, dto *dto.ListSubmissionTasksDTO) ([]*domain.TaskMetadata, error) {
+	r.logger.Debug("executing list submission tasks query", zap.String("submission_id", dto.SubmissionId.String()))
+
+	rows, err := r.db.Query(ctx, listSubmissionTasksQuery, dto.SubmissionId, tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("list submission tasks query failed", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	var tasks []*domain.TaskMetadata
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, handleDBError(err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	r.logger.Debug("submission tasks retrieved from database", zap.Int("count", len(tasks)))
+	return tasks, nil
+}
+
+func (r *StoringRepository) GetTask(ctx context.Context, dto *dto.GetTaskDTO) (*domain.TaskMetadata, error) {
+	r.logger.Debug("executing get task query", zap.String("task_id", dto.Id.String()))
+
+	task, err := scanTask(r.db.QueryRow(ctx, getTaskQuery, dto.Id, tenant.FromContext(ctx)))
 	if err != nil {
 		r.logger.Error("get task query failed",
 			zap.String("task_id", dto.Id.String()),
@@ -82,3 +429,509 @@ func (r *StoringRepository) GetTask(ctx context.Context, dto *dto.GetTaskDTO) (*
 
 	return task, nil
 }
+
+func (r *StoringRepository) UpdateTaskStatus(ctx context.Context, dto *dto.UpdateTaskStatusDTO) error {
+	r.logger.Debug("executing update task status query",
+		zap.String("task_id", dto.Id.String()),
+		zap.String("status", string(dto.Status)))
+
+	tag, err := r.db.Exec(ctx, updateTaskStatusQuery,
+		dto.Id,
+		dto.Status,
+		dto.StatusReason,
+		dto.ContentType,
+		dto.ContentSha256,
+		dto.ContentSize,
+		tenant.FromContext(ctx))
+
+	if err != nil {
+		r.logger.Error("update task status query failed",
+			zap.String("task_id", dto.Id.String()),
+			zap.Error(err))
+		return handleDBError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return handleDBError(pgx.ErrNoRows)
+	}
+
+	r.logger.Debug("task status updated", zap.String("task_id", dto.Id.String()))
+	return nil
+}
+
+func (r *StoringRepository) FindDuplicateTask(ctx context.Context, dto *dto.FindDuplicateTaskDTO) (*domain.TaskMetadata, error) {
+	r.logger.Debug("executing find duplicate task query",
+		zap.String("task_id", dto.Id.String()),
+		zap.String("content_sha256", dto.ContentSha256))
+
+	task, err := scanTask(r.db.QueryRow(ctx, findDuplicateTaskQuery, dto.ContentSha256, dto.Id, dto.UploadedBy, tenant.FromContext(ctx)))
+	if err != nil {
+		return nil, handleDBError(err)
+	}
+
+	r.logger.Debug("duplicate task found",
+		zap.String("task_id", dto.Id.String()),
+		zap.String("original_task_id", task.Id.String()))
+	return task, nil
+}
+
+func (r *StoringRepository) ListTasks(ctx context.Context, dto *dto.ListTasksDTO) ([]*domain.TaskMetadata, error) {
+	r.logger.Debug("executing list tasks query",
+		zap.String("order", string(dto.Order)),
+		zap.Int("limit", dto.Limit))
+
+	cmp, order := "<", "DESC"
+	if dto.Order == domain.OrderAsc {
+		cmp, order = ">", "ASC"
+	}
+	query := fmt.Sprintf(listTasksQuery, cmp, order, order)
+
+	var afterCreatedAt *time.Time
+	var afterId uuid.UUID
+	if dto.After != nil {
+		afterCreatedAt = &dto.After.CreatedAt
+		afterId = dto.After.Id
+	}
+
+	scope := dto.Filter.Scope
+	if scope == nil {
+		scope = &domain.AccessScope{}
+	}
+
+	rows, err := r.db.Query(ctx, query,
+		nullableUUID(dto.Filter.UploadedBy),
+		dto.Filter.CourseId,
+		dto.Filter.AssignmentId,
+		string(dto.Filter.Status),
+		dto.Filter.CreatedFrom,
+		dto.Filter.CreatedTo,
+		likeEscaper.Replace(dto.Filter.Filename),
+		afterCreatedAt,
+		afterId,
+		dto.Limit,
+		dto.Filter.Ids,
+		dto.Filter.Scope != nil,
+		nullableUUID(scope.UploadedBy),
+		nonNilStrings(scope.CourseIds),
+		tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("list tasks query failed", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	var tasks []*domain.TaskMetadata
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, handleDBError(err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	r.logger.Debug("tasks retrieved from database", zap.Int("count", len(tasks)))
+	return tasks, nil
+}
+
+func (r *StoringRepository) ListExpiredTasks(ctx context.Context, dto *dto.ListExpiredTasksDTO) ([]*domain.TaskMetadata, error) {
+	r.logger.Debug("executing list expired tasks query",
+		zap.String("course_id", dto.CourseId),
+		zap.Time("created_before", dto.CreatedBefore),
+		zap.Int("limit", dto.Limit))
+
+	exclude := dto.ExcludeCourses
+	if exclude == nil {
+		exclude = []string{}
+	}
+
+	rows, err := r.db.Query(ctx, listExpiredTasksQuery, dto.CreatedBefore, dto.CourseId, exclude, dto.Limit, tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("list expired tasks query failed", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	var tasks []*domain.TaskMetadata
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, handleDBError(err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	r.logger.Debug("expired tasks retrieved from database", zap.Int("count", len(tasks)))
+	return tasks, nil
+}
+
+func (r *StoringRepository) ListTaskObjects(ctx context.Context, dto *dto.ListTaskObjectsDTO) ([]*domain.TaskObject, error) {
+	r.logger.Debug("executing list task objects query",
+		zap.String("after_id", dto.AfterId.String()),
+		zap.Int("limit", dto.Limit))
+
+	rows, err := r.db.Query(ctx, listTaskObjectsQuery, dto.AfterId, dto.Limit, tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("list task objects query failed", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	var objects []*domain.TaskObject
+	for rows.Next() {
+		object := &domain.TaskObject{}
+		object.Task, err = scanTask(rows, &object.Deleted)
+		if err != nil {
+			return nil, handleDBError(err)
+		}
+		objects = append(objects, object)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	r.logger.Debug("task objects retrieved from database", zap.Int("count", len(objects)))
+	return objects, nil
+}
+
+// ExpireUpload помечает задачу expired. Если задача уже не ожидает загрузки, возвращается ErrNotFound.
+func (r *StoringRepository) ExpireUpload(ctx context.Context, dto *dto.ExpireUploadDTO) error {
+	r.logger.Debug("executing expire upload query", zap.String("task_id", dto.Id.String()))
+
+	tag, err := r.db.Exec(ctx, expireUploadQuery, dto.Id, dto.Reason, tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("expire upload query failed",
+			zap.String("task_id", dto.Id.String()),
+			zap.Error(err))
+		return handleDBError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return handleDBError(pgx.ErrNoRows)
+	}
+
+	r.logger.Debug("task upload expired", zap.String("task_id", dto.Id.String()))
+	return nil
+}
+
+// CreateRetentionRun сохраняет запуск политики хранения вместе со списком задач
+func (r *StoringRepository) GetUserUsage(ctx context.Context, dto *dto.GetUserUsageDTO) (*domain.UserUsage, error) {
+	r.logger.Debug("executing get user usage query", zap.String("uploaded_by", dto.UploadedBy.String()))
+
+	usage := &domain.UserUsage{UploadedBy: dto.UploadedBy}
+	err := r.db.QueryRow(ctx, getUserUsageQuery, dto.UploadedBy, dto.UploadedSince, tenant.FromContext(ctx)).Scan(&usage.Uploads, &usage.Bytes)
+	if err != nil {
+		r.logger.Error("get user usage query failed",
+			zap.String("uploaded_by", dto.UploadedBy.String()),
+			zap.Error(err))
+		return nil, handleDBError(err)
+	}
+
+	return usage, nil
+}
+
+func (r *StoringRepository) GetAssignmentUsage(ctx context.Context, dto *dto.GetAssignmentUsageDTO) (*domain.AssignmentUsage, error) {
+	r.logger.Debug("executing get assignment usage query", zap.String("assignment_id", dto.AssignmentId))
+
+	usage := &domain.AssignmentUsage{AssignmentId: dto.AssignmentId}
+	err := r.db.QueryRow(ctx, getAssignmentUsageQuery, dto.AssignmentId, tenant.FromContext(ctx)).Scan(&usage.Bytes)
+	if err != nil {
+		r.logger.Error("get assignment usage query failed",
+			zap.String("assignment_id", dto.AssignmentId),
+			zap.Error(err))
+		return nil, handleDBError(err)
+	}
+
+	return usage, nil
+}
+
+func (r *StoringRepository) CreateRetentionRun(ctx context.Context, run *domain.RetentionRun) error {
+	r.logger.Debug("executing create retention run query",
+		zap.String("run_id", run.Id.String()),
+		zap.Bool("dry_run", run.DryRun),
+		zap.Int("items", len(run.Items)))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.logger.Error("failed to begin transaction", zap.Error(err))
+		return handleDBError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, createRetentionRunQuery,
+		run.Id,
+		run.DryRun,
+		run.Expired,
+		run.Purged,
+		run.Failed,
+		run.StartedAt,
+		run.FinishedAt,
+		tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("create retention run query failed",
+			zap.String("run_id", run.Id.String()),
+			zap.Error(err))
+		return handleDBError(err)
+	}
+
+	batch := &pgx.Batch{}
+	for _, item := range run.Items {
+		batch.Queue(createRetentionRunItemQuery,
+			run.Id,
+			item.TaskId,
+			item.CourseId,
+			item.TaskCreatedAt,
+			item.RetainUntil,
+			item.Error)
+	}
+	if batch.Len() > 0 {
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			r.logger.Error("create retention run items query failed",
+				zap.String("run_id", run.Id.String()),
+				zap.Error(err))
+			return handleDBError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.logger.Error("failed to commit retention run",
+			zap.String("run_id", run.Id.String()),
+			zap.Error(err))
+		return handleDBError(err)
+	}
+
+	r.logger.Debug("retention run saved", zap.String("run_id", run.Id.String()))
+	return nil
+}
+
+// SoftDeleteTask помечает задачу удаленной. Уже удаленная задача считается не найденной.
+func (r *StoringRepository) SoftDeleteTask(ctx context.Context, dto *dto.SoftDeleteTaskDTO) error {
+	r.logger.Debug("executing soft delete task query", zap.String("task_id", dto.Id.String()))
+
+	tag, err := r.db.Exec(ctx, softDeleteTaskQuery, dto.Id, dto.DeletedAt, tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("soft delete task query failed",
+			zap.String("task_id", dto.Id.String()),
+			zap.Error(err))
+		return handleDBError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return handleDBError(pgx.ErrNoRows)
+	}
+
+	r.logger.Debug("task marked as deleted", zap.String("task_id", dto.Id.String()))
+	return nil
+}
+
+func (r *StoringRepository) ListUserTasks(ctx context.Context, dto *dto.ListUserTasksDTO) ([]*domain.TaskMetadata, error) {
+	r.logger.Debug("executing list user tasks query",
+		zap.String("uploaded_by", dto.UploadedBy.String()),
+		zap.Int("limit", dto.Limit))
+
+	rows, err := r.db.Query(ctx, listUserTasksQuery, dto.UploadedBy, dto.Limit, tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("list user tasks query failed", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	var tasks []*domain.TaskMetadata
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, handleDBError(err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	r.logger.Debug("user tasks retrieved from database", zap.Int("count", len(tasks)))
+	return tasks, nil
+}
+
+func (r *StoringRepository) ListPurgeableTasks(ctx context.Context, dto *dto.ListPurgeableTasksDTO) ([]*domain.TaskMetadata, error) {
+	r.logger.Debug("executing list purgeable tasks query",
+		zap.Time("deleted_before", dto.DeletedBefore),
+		zap.Int("limit", dto.Limit))
+
+	rows, err := r.db.Query(ctx, listPurgeableTasksQuery, dto.DeletedBefore, dto.Limit, tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("list purgeable tasks query failed", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	var tasks []*domain.TaskMetadata
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, handleDBError(err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	r.logger.Debug("purgeable tasks retrieved from database", zap.Int("count", len(tasks)))
+	return tasks, nil
+}
+
+// PurgeTask окончательно удаляет помеченную задачу и в той же транзакции записывает событие удаления
+func (r *StoringRepository) PurgeTask(ctx context.Context, dto *dto.PurgeTaskDTO) error {
+	r.logger.Debug("executing purge task queries", zap.String("task_id", dto.Id.String()))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.logger.Error("failed to begin transaction", zap.Error(err))
+		return handleDBError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	tenantId := tenant.FromContext(ctx)
+	var submissionId uuid.NullUUID
+	if err := tx.QueryRow(ctx, deleteTaskQuery, dto.Id, tenantId).Scan(&submissionId); err != nil {
+		r.logger.Error("delete task query failed",
+			zap.String("task_id", dto.Id.String()),
+			zap.Error(err))
+		return handleDBError(err)
+	}
+
+	// Посылка без версий удаляется вместе с последней версией
+	if submissionId.Valid {
+		if _, err := tx.Exec(ctx, deleteEmptySubmissionQuery, submissionId.UUID, tenantId); err != nil {
+			r.logger.Error("delete empty submission query failed",
+				zap.String("submission_id", submissionId.UUID.String()),
+				zap.Error(err))
+			return handleDBError(err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, createTaskEventQuery, dto.EventId, dto.Id, dto.EventType, dto.CreatedAt, tenantId)
+	if err != nil {
+		r.logger.Error("create task event query failed",
+			zap.String("task_id", dto.Id.String()),
+			zap.Error(err))
+		return handleDBError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.logger.Error("failed to commit task purge",
+			zap.String("task_id", dto.Id.String()),
+			zap.Error(err))
+		return handleDBError(err)
+	}
+
+	r.logger.Debug("task purged from database", zap.String("task_id", dto.Id.String()))
+	return nil
+}
+
+func (r *StoringRepository) ListPendingEvents(ctx context.Context, dto *dto.ListPendingEventsDTO) ([]*domain.TaskEvent, error) {
+	rows, err := r.db.Query(ctx, listPendingEventsQuery, dto.Limit, tenant.FromContext(ctx))
+	if err != nil {
+		r.logger.Error("list pending events query failed", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	var events []*domain.TaskEvent
+	for rows.Next() {
+		event := &domain.TaskEvent{}
+		if err := rows.Scan(&event.Id, &event.TaskId, &event.Type, &event.Attempts, &event.CreatedAt); err != nil {
+			return nil, handleDBError(err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	return events, nil
+}
+
+func (r *StoringRepository) MarkEvent(ctx context.Context, dto *dto.MarkEventDTO) error {
+	var err error
+	if dto.Error == "" {
+		_, err = r.db.Exec(ctx, markEventDeliveredQuery, dto.Id, tenant.FromContext(ctx))
+	} else {
+		_, err = r.db.Exec(ctx, markEventFailedQuery, dto.Id, dto.Error, tenant.FromContext(ctx))
+	}
+	if err != nil {
+		r.logger.Error("mark event query failed",
+			zap.String("event_id", dto.Id.String()),
+			zap.Error(err))
+		return handleDBError(err)
+	}
+
+	return nil
+}
+
+// ListTenants возвращает все тенанты, загружавшие файлы; по ним фоновые воркеры обходят данные тенантов
+func (r *StoringRepository) ListTenants(ctx context.Context) ([]string, error) {
+	rows, err := r.db.Query(ctx, listTenantsQuery)
+	if err != nil {
+		r.logger.Error("list tenants query failed", zap.Error(err))
+		return nil, handleDBError(err)
+	}
+	defer rows.Close()
+
+	var tenants []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, handleDBError(err)
+		}
+		tenants = append(tenants, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleDBError(err)
+	}
+
+	return tenants, nil
+}
+
+// scanTask читает колонки taskColumns, а следующие за ними - в extra
+func scanTask(row pgx.Row, extra ...any) (*domain.TaskMetadata, error) {
+	task := &domain.TaskMetadata{}
+	var submissionId uuid.NullUUID
+	dest := []any{
+		&task.Id,
+		&task.Filename,
+		&task.UploadedBy,
+		&task.CourseId,
+		&task.AssignmentId,
+		&task.Status,
+		&task.StatusReason,
+		&task.ExpectedSize,
+		&task.ExpectedSha256,
+		&task.ContentSha256,
+		&task.ContentType,
+		&task.ObjectKey,
+		&submissionId,
+		&task.Version,
+		&task.CreatedAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	task.SubmissionId = submissionId.UUID
+	return task, nil
+}
+
+func nullableUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
+
+// nonNilStrings заменяет nil пустым массивом, чтобы параметр text[] не передавался как NULL
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,124 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"storing-service/internal/errdefs"
+	"storing-service/internal/infrastucture/dto"
+	"storing-service/pkg/tenant"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// newTestRepository подключается к TEST_DATABASE_URL и применяет миграции; без переменной тест пропускается
+func newTestRepository(t *testing.T) *StoringRepository {
+	t.Helper()
+
+	dbUrl := os.Getenv("TEST_DATABASE_URL")
+	if dbUrl == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	mg, err := migrate.New("file://../../../migrations", dbUrl)
+	if err != nil {
+		t.Fatalf("migrate.New: %v", err)
+	}
+	if err := mg.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatalf("migrate up: %v", err)
+	}
+	mg.Close()
+
+	pool, err := pgxpool.New(context.Background(), dbUrl)
+	if err != nil {
+		t.Fatalf("pgxpool.New: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	return NewStoringRepository(pool, zap.NewNop())
+}
+
+// createConcurrently создает n задач параллельно и возвращает число созданных и отклоненных по квоте
+func createConcurrently(t *testing.T, repo *StoringRepository, ctx context.Context, n int, newTask func() *dto.CreateTaskDTO) (created, rejected int) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < n; i++ {
+		task := newTask()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := repo.CreateTask(ctx, task)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				created++
+			case errors.Is(err, errdefs.ErrQuotaExceeded):
+				rejected++
+			default:
+				t.Errorf("CreateTask: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	return created, rejected
+}
+
+func newQuotaTask(uploadedBy uuid.UUID, assignmentId string, size int64, quota *dto.TaskQuotaDTO) *dto.CreateTaskDTO {
+	id := uuid.New()
+	return &dto.CreateTaskDTO{
+		Id:             id,
+		FileName:       "main.go",
+		ObjectKey:      id.String() + ".go",
+		UploadedBy:     uploadedBy,
+		CourseId:       "cs101",
+		AssignmentId:   assignmentId,
+		ExpectedSize:   size,
+		ExpectedSha256: "",
+		CreatedAt:      time.Now(),
+		Quota:          quota,
+	}
+}
+
+func TestCreateTaskEnforcesUploadQuotaConcurrently(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := tenant.WithTenant(context.Background(), fmt.Sprintf("quota-%d", time.Now().UnixNano()))
+
+	uploadedBy := uuid.New()
+	quota := &dto.TaskQuotaDTO{Window: time.Hour, MaxUploads: 3}
+	created, rejected := createConcurrently(t, repo, ctx, 20, func() *dto.CreateTaskDTO {
+		return newQuotaTask(uploadedBy, "", 10, quota)
+	})
+
+	if created != 3 || rejected != 17 {
+		t.Errorf("created %d, rejected %d; want 3 and 17", created, rejected)
+	}
+}
+
+func TestCreateTaskEnforcesAssignmentBytesConcurrently(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := tenant.WithTenant(context.Background(), fmt.Sprintf("quota-%d", time.Now().UnixNano()))
+
+	// Разные авторы: объем ограничивает только квота задания
+	quota := &dto.TaskQuotaDTO{Window: time.Hour, MaxUserBytes: 1000, MaxAssignmentBytes: 250}
+	created, rejected := createConcurrently(t, repo, ctx, 20, func() *dto.CreateTaskDTO {
+		return newQuotaTask(uuid.New(), "hw1", 100, quota)
+	})
+
+	if created != 2 || rejected != 18 {
+		t.Errorf("created %d, rejected %d; want 2 and 18", created, rejected)
+	}
+}
@@ -0,0 +1,36 @@
+package scanstub
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+)
+
+// eicar - тестовая строка EICAR, которую антивирусы определяют как вирус
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// Scanner - заглушка для тестов и локального запуска без ClamAV: зараженным считается файл,
+// содержащий тестовую строку EICAR
+type Scanner struct{}
+
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+func (s *Scanner) Scan(ctx context.Context, content io.Reader) (*domain.ScanResult, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %v: %w", err, errdefs.ErrUnavailable)
+	}
+
+	if bytes.Contains(data, []byte(eicar)) {
+		return &domain.ScanResult{
+			Infected:  true,
+			Signature: "Eicar-Test-Signature",
+		}, nil
+	}
+	return &domain.ScanResult{}, nil
+}
@@ -0,0 +1,87 @@
+package urlsign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"path"
+	"storing-service/internal/errdefs"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// blobsPath - путь api-gateway, по которому доступны подписанные ссылки
+const blobsPath = "/api/v1/blobs/"
+
+// Signer выдает ссылки на api-gateway, подписанные HMAC-SHA256, вместо presigned-ссылок хранилища.
+// Используется, когда клиент не может обращаться к хранилищу напрямую: локальная файловая система
+// или шифрование файлов на стороне storing-service.
+type Signer struct {
+	publicURL *url.URL
+	secret    []byte
+}
+
+func NewSigner(publicURL, secret string) (*Signer, error) {
+	u, err := url.Parse(strings.TrimSuffix(publicURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage public url: %w", err)
+	}
+	return &Signer{publicURL: u, secret: []byte(secret)}, nil
+}
+
+// PresignGet возвращает подписанную ссылку на скачивание
+func (s *Signer) PresignGet(objectKey string, expiry time.Duration) *url.URL {
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(time.Now().Add(expiry).Unix(), 10))
+	return s.signedURL("GET", objectKey, query)
+}
+
+// PresignPost возвращает подписанную ссылку на загрузку. Размер и SHA-256 входят в подпись
+// и сверяются с загруженным файлом, как условия POST policy в MinIO. Дополнительных полей формы нет.
+func (s *Signer) PresignPost(objectKey string, size int64, sha256 []byte, expiry time.Duration) (*url.URL, map[string]string) {
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(time.Now().Add(expiry).Unix(), 10))
+	query.Set("size", strconv.FormatInt(size, 10))
+	query.Set("sha256", hex.EncodeToString(sha256))
+	return s.signedURL("POST", objectKey, query), map[string]string{}
+}
+
+// VerifySignedURL проверяет подпись и срок действия ссылки, выданной PresignGet или PresignPost
+func (s *Signer) VerifySignedURL(method, objectKey string, query url.Values) error {
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid expires parameter: %w", errdefs.ErrPermissionDenied)
+	}
+
+	signature, err := hex.DecodeString(query.Get("signature"))
+	if err != nil || !hmac.Equal(signature, s.sign(method, objectKey, query)) {
+		return fmt.Errorf("invalid url signature: %w", errdefs.ErrPermissionDenied)
+	}
+
+	if time.Now().Unix() > expires {
+		return fmt.Errorf("url expired: %w", errdefs.ErrPermissionDenied)
+	}
+	return nil
+}
+
+func (s *Signer) signedURL(method, objectKey string, query url.Values) *url.URL {
+	query.Set("signature", hex.EncodeToString(s.sign(method, objectKey, query)))
+
+	u := *s.publicURL
+	u.Path = path.Join(u.Path, blobsPath, objectKey)
+	u.RawQuery = query.Encode()
+	return &u
+}
+
+// sign подписывает метод, ключ и ограничения ссылки
+func (s *Signer) sign(method, objectKey string, query url.Values) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	for _, part := range []string{method, objectKey, query.Get("expires"), query.Get("size"), query.Get("sha256")} {
+		mac.Write([]byte(part))
+		mac.Write([]byte{'\n'})
+	}
+	return mac.Sum(nil)
+}
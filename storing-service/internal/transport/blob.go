@@ -0,0 +1,111 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	pb "storing-service/pkg/api"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// blobChunkSize - размер части файла в одном сообщении ReadBlob
+const blobChunkSize = 64 << 10
+
+func (h *StoringHandler) ReadBlob(request *pb.ReadBlobRequest, stream pb.StoringService_ReadBlobServer) error {
+	h.logger.Info("read blob gRPC request", zap.String("object_key", request.ObjectKey))
+
+	content, info, err := h.svc.ReadSignedBlob(stream.Context(), request.ObjectKey, toQuery(request.Params))
+	if err != nil {
+		h.logger.Warn("read blob failed",
+			zap.String("object_key", request.ObjectKey),
+			zap.Error(err))
+		return mapError(err)
+	}
+	defer content.Close()
+
+	err = stream.Send(&pb.ReadBlobResponse{
+		Payload: &pb.ReadBlobResponse_Info{
+			Info: &pb.BlobInfo{
+				Size:        info.Size,
+				ContentType: info.ContentType,
+				ModifiedAt:  info.ModifiedAt.Format(time.RFC3339),
+			},
+		},
+	})
+
+	buf := make([]byte, blobChunkSize)
+	for err == nil {
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			err = stream.Send(&pb.ReadBlobResponse{
+				Payload: &pb.ReadBlobResponse_Chunk{Chunk: buf[:n]},
+			})
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			h.logger.Error("failed to read blob",
+				zap.String("object_key", request.ObjectKey),
+				zap.Error(readErr))
+			return status.Error(codes.Unavailable, readErr.Error())
+		}
+	}
+	if err != nil {
+		h.logger.Warn("failed to send blob",
+			zap.String("object_key", request.ObjectKey),
+			zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("read blob success",
+		zap.String("object_key", request.ObjectKey),
+		zap.Int64("size", info.Size))
+	return nil
+}
+
+func (h *StoringHandler) WriteBlob(stream pb.StoringService_WriteBlobServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		h.logger.Warn("failed to receive write blob metadata", zap.Error(err))
+		return err
+	}
+
+	meta := first.GetMetadata()
+	if meta == nil {
+		h.logger.Warn("write blob stream started without metadata")
+		return status.Error(codes.InvalidArgument, "first message must contain metadata")
+	}
+
+	h.logger.Info("write blob gRPC request", zap.String("object_key", meta.ObjectKey))
+
+	content := newWriteBlobChunkReader(stream)
+	size, err := h.svc.WriteSignedBlob(stream.Context(), meta.ObjectKey, toQuery(meta.Params), content)
+	if err != nil {
+		h.logger.Warn("write blob failed",
+			zap.String("object_key", meta.ObjectKey),
+			zap.Error(err))
+		if streamErr := content.streamErr(); streamErr != nil {
+			return mapError(streamErr)
+		}
+		return mapError(err)
+	}
+
+	h.logger.Info("write blob success",
+		zap.String("object_key", meta.ObjectKey),
+		zap.Int64("size", size))
+
+	return stream.SendAndClose(&pb.WriteBlobResponse{Size: size})
+}
+
+func toQuery(params map[string]string) url.Values {
+	query := make(url.Values, len(params))
+	for k, v := range params {
+		query.Set(k, v)
+	}
+	return query
+}
@@ -4,18 +4,34 @@ import (
 	"context"
 	"errors"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"go.uber.org/zap"
+	"io"
+	"net/url"
 	"storing-service/internal/domain"
 	"storing-service/internal/errdefs"
 	pb "storing-service/pkg/api"
+	"time"
 )
 
 type StoringService interface {
-	UploadTask(ctx context.Context, filename string, uploadedBy uuid.UUID) (*domain.Task, error)
+	UploadTask(ctx context.Context, filename string, uploadedBy uuid.UUID, courseId, assignmentId string, size int64, sha256 string) (*domain.Task, error)
+	UploadTaskContent(ctx context.Context, filename string, uploadedBy uuid.UUID, courseId, assignmentId string, size int64, sha256 string, content io.Reader) (*domain.Task, int64, error)
+	ImportArchive(ctx context.Context, courseId, assignmentId, namingRule string, manifestCSV []byte, archive io.Reader) ([]domain.ImportEntry, error)
 	GetTask(ctx context.Context, fileId uuid.UUID) (*domain.Task, error)
+	GetSubmission(ctx context.Context, submissionId uuid.UUID) (*domain.Submission, error)
+	GetAnalysisInput(ctx context.Context, fileId uuid.UUID) (*domain.AnalysisInput, error)
 	GetFileContent(ctx context.Context, fileId uuid.UUID) ([]byte, error)
+	ListTasks(ctx context.Context, filter domain.TaskFilter, order domain.SortOrder, cursor string, limit int) ([]*domain.TaskMetadata, string, error)
+	DeleteTask(ctx context.Context, fileId uuid.UUID) (time.Time, error)
+	RunRetention(ctx context.Context, dryRun bool) (*domain.RetentionRun, error)
+	EraseUserData(ctx context.Context, uploadedBy uuid.UUID) (int, error)
+	RunReconciliation(ctx context.Context, dryRun bool) (*domain.ReconciliationRun, error)
+	RotateEncryptionKeys(ctx context.Context) (*domain.KeyRotation, error)
+	GetQuotaUsage(ctx context.Context, uploadedBy uuid.UUID, assignmentId string) (*domain.QuotaUsage, error)
+	ReadSignedBlob(ctx context.Context, objectKey string, query url.Values) (io.ReadCloser, *domain.BlobInfo, error)
+	WriteSignedBlob(ctx context.Context, objectKey string, query url.Values, content io.Reader) (int64, error)
 }
 
 type StoringHandler struct {
@@ -41,10 +57,14 @@ func (h *StoringHandler) UploadTask(ctx context.Context, request *pb.UploadTaskR
 		h.logger.Warn("invalid uploaded_by UUID",
 			zap.String("uploaded_by", request.UploadedBy),
 			zap.Error(err))
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := checkUploader(ctx, request.UploadedBy); err != nil {
+		h.logger.Warn("upload on behalf of another user denied", zap.Error(err))
+		return nil, mapError(err)
 	}
 
-	res, err := h.svc.UploadTask(ctx, request.Filename, uploadedBy)
+	res, err := h.svc.UploadTask(ctx, request.Filename, uploadedBy, request.CourseId, request.AssignmentId, request.Size, request.Sha256)
 	if err != nil {
 		h.logger.Error("upload task failed",
 			zap.String("filename", request.Filename),
@@ -59,9 +79,133 @@ func (h *StoringHandler) UploadTask(ctx context.Context, request *pb.UploadTaskR
 	return &pb.UploadTaskResponse{
 		FileId:    res.Id.String(),
 		UploadUrl: res.Url,
+		FormData:  res.UploadForm,
 	}, nil
 }
 
+func (h *StoringHandler) UploadTaskStream(stream pb.StoringService_UploadTaskStreamServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		h.logger.Warn("failed to receive upload metadata", zap.Error(err))
+		return err
+	}
+
+	meta := first.GetMetadata()
+	if meta == nil {
+		h.logger.Warn("upload stream started without metadata")
+		return status.Error(codes.InvalidArgument, "first message must contain metadata")
+	}
+
+	h.logger.Info("upload task stream gRPC request",
+		zap.String("filename", meta.Filename),
+		zap.String("uploaded_by", meta.UploadedBy))
+
+	uploadedBy, err := uuid.Parse(meta.UploadedBy)
+	if err != nil {
+		h.logger.Warn("invalid uploaded_by UUID",
+			zap.String("uploaded_by", meta.UploadedBy),
+			zap.Error(err))
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := checkUploader(stream.Context(), meta.UploadedBy); err != nil {
+		h.logger.Warn("upload on behalf of another user denied", zap.Error(err))
+		return mapError(err)
+	}
+
+	content := newChunkReader(stream)
+	res, size, err := h.svc.UploadTaskContent(stream.Context(), meta.Filename, uploadedBy, meta.CourseId, meta.AssignmentId, meta.Size, meta.Sha256, content)
+	if err != nil {
+		h.logger.Error("upload task stream failed",
+			zap.String("filename", meta.Filename),
+			zap.Error(err))
+		if streamErr := content.streamErr(); streamErr != nil {
+			return mapError(streamErr)
+		}
+		return mapError(err)
+	}
+
+	h.logger.Info("upload task stream success",
+		zap.String("file_id", res.Id.String()),
+		zap.Int64("size", size),
+		zap.String("status", string(res.Status)))
+
+	return stream.SendAndClose(&pb.UploadTaskStreamResponse{
+		FileId:       res.Id.String(),
+		Size:         size,
+		Status:       string(res.Status),
+		StatusReason: res.StatusReason,
+	})
+}
+
+func (h *StoringHandler) ImportArchive(stream pb.StoringService_ImportArchiveServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		h.logger.Warn("failed to receive import metadata", zap.Error(err))
+		return err
+	}
+
+	meta := first.GetMetadata()
+	if meta == nil {
+		h.logger.Warn("import stream started without metadata")
+		return status.Error(codes.InvalidArgument, "first message must contain metadata")
+	}
+
+	h.logger.Info("import archive gRPC request",
+		zap.String("course_id", meta.CourseId),
+		zap.String("assignment_id", meta.AssignmentId))
+
+	if err := checkCourseAccess(stream.Context(), meta.CourseId); err != nil {
+		h.logger.Warn("import archive denied", zap.Error(err))
+		return mapError(err)
+	}
+
+	content := newImportChunkReader(stream)
+	entries, err := h.svc.ImportArchive(stream.Context(), meta.CourseId, meta.AssignmentId, meta.NamingRule, meta.ManifestCsv, content)
+	if err != nil {
+		h.logger.Error("import archive failed",
+			zap.String("course_id", meta.CourseId),
+			zap.Error(err))
+		if streamErr := content.streamErr(); streamErr != nil {
+			return mapError(streamErr)
+		}
+		return mapError(err)
+	}
+
+	res := &pb.ImportArchiveResponse{Entries: make([]*pb.ImportEntry, 0, len(entries))}
+	for _, entry := range entries {
+		switch entry.Status {
+		case domain.ImportImported:
+			res.Imported++
+		case domain.ImportRejected:
+			res.Rejected++
+		case domain.ImportFailed:
+			res.Failed++
+		case domain.ImportSkipped:
+			res.Skipped++
+		}
+
+		pbEntry := &pb.ImportEntry{
+			Path:   entry.Path,
+			Status: string(entry.Status),
+			Error:  entry.Error,
+		}
+		if entry.UploadedBy != uuid.Nil {
+			pbEntry.UploadedBy = entry.UploadedBy.String()
+		}
+		if entry.TaskId != uuid.Nil {
+			pbEntry.FileId = entry.TaskId.String()
+		}
+		res.Entries = append(res.Entries, pbEntry)
+	}
+
+	h.logger.Info("import archive success",
+		zap.Int32("imported", res.Imported),
+		zap.Int32("rejected", res.Rejected),
+		zap.Int32("failed", res.Failed))
+
+	return stream.SendAndClose(res)
+}
+
 func (h *StoringHandler) GetTask(ctx context.Context, request *pb.GetTaskRequest) (*pb.GetTaskResponse, error) {
 	h.logger.Info("get task gRPC request", zap.String("file_id", request.FileId))
 
@@ -70,7 +214,7 @@ func (h *StoringHandler) GetTask(ctx context.Context, request *pb.GetTaskRequest
 		h.logger.Warn("invalid file_id UUID",
 			zap.String("file_id", request.FileId),
 			zap.Error(err))
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	res, err := h.svc.GetTask(ctx, fileId)
@@ -80,18 +224,206 @@ func (h *StoringHandler) GetTask(ctx context.Context, request *pb.GetTaskRequest
 			zap.Error(err))
 		return nil, mapError(err)
 	}
+	if err := checkTaskAccess(ctx, res.UploadedBy, res.CourseId); err != nil {
+		h.logger.Warn("get task denied",
+			zap.String("file_id", request.FileId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
 
 	h.logger.Info("get task success", zap.String("file_id", request.FileId))
 
 	return &pb.GetTaskResponse{
-		FileId:     res.Id.String(),
-		Filename:   res.Filename,
-		Url:        res.Url,
-		UploadedBy: res.UploadedBy.String(),
-		UploadedAt: res.CreatedAt.String(),
+		FileId:        res.Id.String(),
+		Filename:      res.Filename,
+		Url:           res.Url,
+		UploadedBy:    res.UploadedBy.String(),
+		UploadedAt:    res.CreatedAt.String(),
+		CourseId:      res.CourseId,
+		AssignmentId:  res.AssignmentId,
+		Status:        string(res.Status),
+		StatusReason:  res.StatusReason,
+		Size:          res.ExpectedSize,
+		Sha256:        res.ExpectedSha256,
+		ContentType:   res.ContentType,
+		ContentSha256: res.ContentSha256,
+		SubmissionId:  submissionIdString(res.SubmissionId),
+		Version:       int32(res.Version),
+		ObjectKey:     res.ObjectKey,
 	}, nil
 }
 
+func (h *StoringHandler) ListTasks(ctx context.Context, request *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	h.logger.Info("list tasks gRPC request",
+		zap.String("uploaded_by", request.UploadedBy),
+		zap.String("assignment_id", request.AssignmentId),
+		zap.String("status", request.Status),
+		zap.Int32("limit", request.Limit))
+
+	var uploadedBy uuid.UUID
+	if request.UploadedBy != "" {
+		parsed, err := uuid.Parse(request.UploadedBy)
+		if err != nil {
+			h.logger.Warn("invalid uploaded_by UUID",
+				zap.String("uploaded_by", request.UploadedBy),
+				zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		uploadedBy = parsed
+	}
+
+	createdFrom, err := parseOptionalTime(request.CreatedFrom)
+	if err != nil {
+		h.logger.Warn("invalid created_from", zap.String("created_from", request.CreatedFrom), zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	createdTo, err := parseOptionalTime(request.CreatedTo)
+	if err != nil {
+		h.logger.Warn("invalid created_to", zap.String("created_to", request.CreatedTo), zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var ids []uuid.UUID
+	for _, fileId := range request.FileIds {
+		id, err := uuid.Parse(fileId)
+		if err != nil {
+			h.logger.Warn("invalid file_id UUID",
+				zap.String("file_id", fileId),
+				zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		ids = append(ids, id)
+	}
+
+	scope, err := accessScope(ctx)
+	if err != nil {
+		h.logger.Warn("list tasks denied", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	filter := domain.TaskFilter{
+		Ids:          ids,
+		UploadedBy:   uploadedBy,
+		CourseId:     request.CourseId,
+		AssignmentId: request.AssignmentId,
+		Status:       domain.TaskStatus(request.Status),
+		CreatedFrom:  createdFrom,
+		CreatedTo:    createdTo,
+		Filename:     request.Filename,
+		Scope:        scope,
+	}
+
+	tasks, nextCursor, err := h.svc.ListTasks(ctx, filter, domain.SortOrder(request.Order), request.Cursor, int(request.Limit))
+	if err != nil {
+		h.logger.Error("list tasks failed", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	summaries := make([]*pb.TaskSummary, 0, len(tasks))
+	for _, task := range tasks {
+		summaries = append(summaries, toTaskSummary(task))
+	}
+
+	h.logger.Info("list tasks success", zap.Int("count", len(summaries)))
+
+	return &pb.ListTasksResponse{
+		Tasks:      summaries,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func (h *StoringHandler) GetSubmission(ctx context.Context, request *pb.GetSubmissionRequest) (*pb.GetSubmissionResponse, error) {
+	h.logger.Info("get submission gRPC request", zap.String("submission_id", request.SubmissionId))
+
+	submissionId, err := uuid.Parse(request.SubmissionId)
+	if err != nil {
+		h.logger.Warn("invalid submission_id UUID",
+			zap.String("submission_id", request.SubmissionId),
+			zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	submission, err := h.svc.GetSubmission(ctx, submissionId)
+	if err != nil {
+		h.logger.Error("get submission failed",
+			zap.String("submission_id", request.SubmissionId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+	if err := checkTaskAccess(ctx, submission.UploadedBy, submission.CourseId); err != nil {
+		h.logger.Warn("get submission denied",
+			zap.String("submission_id", request.SubmissionId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	versions := make([]*pb.TaskSummary, 0, len(submission.Versions))
+	for _, task := range submission.Versions {
+		versions = append(versions, toTaskSummary(task))
+	}
+
+	h.logger.Info("get submission success",
+		zap.String("submission_id", request.SubmissionId),
+		zap.Int("versions", len(versions)))
+
+	return &pb.GetSubmissionResponse{
+		SubmissionId: submission.Id.String(),
+		UploadedBy:   submission.UploadedBy.String(),
+		CourseId:     submission.CourseId,
+		AssignmentId: submission.AssignmentId,
+		CreatedAt:    submission.CreatedAt.Format(time.RFC3339),
+		Versions:     versions,
+	}, nil
+}
+
+func (h *StoringHandler) GetAnalysisInput(ctx context.Context, request *pb.GetAnalysisInputRequest) (*pb.GetAnalysisInputResponse, error) {
+	h.logger.Info("get analysis input gRPC request", zap.String("file_id", request.FileId))
+
+	fileId, err := uuid.Parse(request.FileId)
+	if err != nil {
+		h.logger.Warn("invalid file_id UUID",
+			zap.String("file_id", request.FileId),
+			zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.authorizeTask(ctx, fileId); err != nil {
+		h.logger.Warn("get analysis input denied",
+			zap.String("file_id", request.FileId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	input, err := h.svc.GetAnalysisInput(ctx, fileId)
+	if err != nil {
+		h.logger.Error("get analysis input failed",
+			zap.String("file_id", request.FileId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	response := &pb.GetAnalysisInputResponse{
+		FileId:         input.Task.Id.String(),
+		ObjectKey:      input.Task.ObjectKey,
+		AssignmentId:   input.Task.AssignmentId,
+		UploadedBy:     input.Task.UploadedBy.String(),
+		CourseId:       input.Task.CourseId,
+		ExcludeTaskIds: input.ExcludeTaskIds,
+	}
+	if input.DuplicateOf != nil {
+		response.DuplicateOf = input.DuplicateOf.Id.String()
+		response.DuplicateOfObjectKey = input.DuplicateOf.ObjectKey
+	}
+
+	h.logger.Info("get analysis input success",
+		zap.String("file_id", request.FileId),
+		zap.Int("excluded", len(response.ExcludeTaskIds)),
+		zap.String("duplicate_of", response.DuplicateOf))
+
+	return response, nil
+}
+
 func (h *StoringHandler) GetFileContent(ctx context.Context, request *pb.GetFileContentRequest) (*pb.GetFileContentResponse, error) {
 	h.logger.Info("get file content gRPC request", zap.String("file_id", request.FileId))
 
@@ -100,7 +432,14 @@ func (h *StoringHandler) GetFileContent(ctx context.Context, request *pb.GetFile
 		h.logger.Warn("invalid file_id UUID",
 			zap.String("file_id", request.FileId),
 			zap.Error(err))
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.authorizeTask(ctx, fileId); err != nil {
+		h.logger.Warn("get file content denied",
+			zap.String("file_id", request.FileId),
+			zap.Error(err))
+		return nil, mapError(err)
 	}
 
 	content, err := h.svc.GetFileContent(ctx, fileId)
@@ -120,7 +459,250 @@ func (h *StoringHandler) GetFileContent(ctx context.Context, request *pb.GetFile
 	}, nil
 }
 
+func (h *StoringHandler) DeleteTask(ctx context.Context, request *pb.DeleteTaskRequest) (*pb.DeleteTaskResponse, error) {
+	h.logger.Info("delete task gRPC request", zap.String("file_id", request.FileId))
+
+	fileId, err := uuid.Parse(request.FileId)
+	if err != nil {
+		h.logger.Warn("invalid file_id UUID",
+			zap.String("file_id", request.FileId),
+			zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.authorizeTask(ctx, fileId); err != nil {
+		h.logger.Warn("delete task denied",
+			zap.String("file_id", request.FileId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	purgeAt, err := h.svc.DeleteTask(ctx, fileId)
+	if err != nil {
+		h.logger.Error("delete task failed",
+			zap.String("file_id", request.FileId),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	h.logger.Info("delete task success",
+		zap.String("file_id", request.FileId),
+		zap.Time("purge_at", purgeAt))
+
+	return &pb.DeleteTaskResponse{
+		PurgeAt: purgeAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (h *StoringHandler) EraseUserData(ctx context.Context, request *pb.EraseUserDataRequest) (*pb.EraseUserDataResponse, error) {
+	h.logger.Info("erase user data gRPC request", zap.String("uploaded_by", request.UploadedBy))
+
+	uploadedBy, err := uuid.Parse(request.UploadedBy)
+	if err != nil {
+		h.logger.Warn("invalid uploaded_by UUID",
+			zap.String("uploaded_by", request.UploadedBy),
+			zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := checkUserAccess(ctx, uploadedBy); err != nil {
+		h.logger.Warn("erase user data denied",
+			zap.String("uploaded_by", request.UploadedBy),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	erased, err := h.svc.EraseUserData(ctx, uploadedBy)
+	if err != nil {
+		h.logger.Error("erase user data failed",
+			zap.String("uploaded_by", request.UploadedBy),
+			zap.Int("tasks_erased", erased),
+			zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	h.logger.Info("erase user data success",
+		zap.String("uploaded_by", request.UploadedBy),
+		zap.Int("tasks_erased", erased))
+
+	return &pb.EraseUserDataResponse{
+		TasksErased: int32(erased),
+	}, nil
+}
+
+func (h *StoringHandler) RunRetention(ctx context.Context, request *pb.RunRetentionRequest) (*pb.RunRetentionResponse, error) {
+	h.logger.Info("run retention gRPC request", zap.Bool("dry_run", request.DryRun))
+
+	if err := checkAdmin(ctx); err != nil {
+		h.logger.Warn("run retention denied", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	run, err := h.svc.RunRetention(ctx, request.DryRun)
+	if err != nil {
+		h.logger.Error("run retention failed", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	items := make([]*pb.RetentionItem, 0, len(run.Items))
+	for _, item := range run.Items {
+		items = append(items, &pb.RetentionItem{
+			FileId:      item.TaskId.String(),
+			CourseId:    item.CourseId,
+			UploadedAt:  item.TaskCreatedAt.Format(time.RFC3339),
+			RetainUntil: item.RetainUntil.Format(time.RFC3339),
+			Error:       item.Error,
+		})
+	}
+
+	h.logger.Info("run retention success",
+		zap.String("run_id", run.Id.String()),
+		zap.Int("expired", run.Expired))
+
+	return &pb.RunRetentionResponse{
+		RunId:      run.Id.String(),
+		DryRun:     run.DryRun,
+		Expired:    int32(run.Expired),
+		Purged:     int32(run.Purged),
+		Failed:     int32(run.Failed),
+		StartedAt:  run.StartedAt.Format(time.RFC3339),
+		FinishedAt: run.FinishedAt.Format(time.RFC3339),
+		Items:      items,
+	}, nil
+}
+
+func (h *StoringHandler) RunReconciliation(ctx context.Context, request *pb.RunReconciliationRequest) (*pb.RunReconciliationResponse, error) {
+	h.logger.Info("run reconciliation gRPC request", zap.Bool("dry_run", request.DryRun))
+
+	if err := checkAdmin(ctx); err != nil {
+		h.logger.Warn("run reconciliation denied", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	run, err := h.svc.RunReconciliation(ctx, request.DryRun)
+	if err != nil {
+		h.logger.Error("run reconciliation failed", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	items := make([]*pb.ReconciliationItem, 0, len(run.Items))
+	for _, item := range run.Items {
+		fileId := ""
+		if item.TaskId != uuid.Nil {
+			fileId = item.TaskId.String()
+		}
+		items = append(items, &pb.ReconciliationItem{
+			Kind:      string(item.Kind),
+			FileId:    fileId,
+			ObjectKey: item.ObjectKey,
+			Error:     item.Error,
+		})
+	}
+
+	h.logger.Info("run reconciliation success",
+		zap.Int("expired_uploads", run.ExpiredUploads),
+		zap.Int("stray_objects", run.StrayObjects))
+
+	return &pb.RunReconciliationResponse{
+		DryRun:         run.DryRun,
+		StrayAction:    run.StrayAction,
+		ExpiredUploads: int32(run.ExpiredUploads),
+		ResumedUploads: int32(run.ResumedUploads),
+		MissingObjects: int32(run.MissingObjects),
+		StrayObjects:   int32(run.StrayObjects),
+		Failed:         int32(run.Failed),
+		StartedAt:      run.StartedAt.Format(time.RFC3339),
+		FinishedAt:     run.FinishedAt.Format(time.RFC3339),
+		Items:          items,
+	}, nil
+}
+
+func (h *StoringHandler) RotateEncryptionKeys(ctx context.Context, request *pb.RotateEncryptionKeysRequest) (*pb.RotateEncryptionKeysResponse, error) {
+	h.logger.Info("rotate encryption keys gRPC request")
+
+	if err := checkAdmin(ctx); err != nil {
+		h.logger.Warn("rotate encryption keys denied", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	rotation, err := h.svc.RotateEncryptionKeys(ctx)
+	if err != nil {
+		h.logger.Error("rotate encryption keys failed", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	h.logger.Info("rotate encryption keys success",
+		zap.Int("checked", rotation.Checked),
+		zap.Int("rewrapped", rotation.Rewrapped))
+
+	return &pb.RotateEncryptionKeysResponse{
+		Checked:   int32(rotation.Checked),
+		Rewrapped: int32(rotation.Rewrapped),
+		Failed:    int32(rotation.Failed),
+	}, nil
+}
+
+func (h *StoringHandler) GetQuotaUsage(ctx context.Context, request *pb.GetQuotaUsageRequest) (*pb.GetQuotaUsageResponse, error) {
+	h.logger.Info("get quota usage gRPC request",
+		zap.String("uploaded_by", request.UploadedBy),
+		zap.String("assignment_id", request.AssignmentId))
+
+	if err := checkAdmin(ctx); err != nil {
+		h.logger.Warn("get quota usage denied", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	var uploadedBy uuid.UUID
+	if request.UploadedBy != "" {
+		parsed, err := uuid.Parse(request.UploadedBy)
+		if err != nil {
+			h.logger.Warn("invalid uploaded_by UUID",
+				zap.String("uploaded_by", request.UploadedBy),
+				zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		uploadedBy = parsed
+	}
+
+	usage, err := h.svc.GetQuotaUsage(ctx, uploadedBy, request.AssignmentId)
+	if err != nil {
+		h.logger.Error("get quota usage failed", zap.Error(err))
+		return nil, mapError(err)
+	}
+
+	response := &pb.GetQuotaUsageResponse{
+		Window:             usage.Window.String(),
+		MaxFileSize:        usage.MaxFileSize,
+		MaxUploads:         usage.MaxUploads,
+		MaxUserBytes:       usage.MaxUserBytes,
+		MaxAssignmentBytes: usage.MaxAssignmentBytes,
+	}
+	if usage.User != nil {
+		response.User = &pb.UserUsage{
+			UploadedBy: usage.User.UploadedBy.String(),
+			Uploads:    usage.User.Uploads,
+			Bytes:      usage.User.Bytes,
+		}
+	}
+	if usage.Assignment != nil {
+		response.Assignment = &pb.AssignmentUsage{
+			AssignmentId: usage.Assignment.AssignmentId,
+			Bytes:        usage.Assignment.Bytes,
+		}
+	}
+
+	h.logger.Info("get quota usage success",
+		zap.String("uploaded_by", request.UploadedBy),
+		zap.String("assignment_id", request.AssignmentId))
+
+	return response, nil
+}
+
 func mapError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
 	switch {
 	case err == nil:
 		return nil
@@ -132,7 +714,50 @@ func mapError(err error) error {
 		return status.Error(codes.AlreadyExists, err.Error())
 	case errors.Is(err, errdefs.ErrUnavailable):
 		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, errdefs.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, errdefs.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, errdefs.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
 }
+
+func toTaskSummary(task *domain.TaskMetadata) *pb.TaskSummary {
+	return &pb.TaskSummary{
+		FileId:       task.Id.String(),
+		Filename:     task.Filename,
+		UploadedBy:   task.UploadedBy.String(),
+		UploadedAt:   task.CreatedAt.Format(time.RFC3339),
+		CourseId:     task.CourseId,
+		AssignmentId: task.AssignmentId,
+		Status:       string(task.Status),
+		Size:         task.ExpectedSize,
+		ContentType:  task.ContentType,
+		SubmissionId: submissionIdString(task.SubmissionId),
+		Version:      int32(task.Version),
+	}
+}
+
+// submissionIdString возвращает пустую строку для задач вне посылок
+func submissionIdString(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	// Время в БД хранится без часового пояса в локальном времени сервиса
+	t = t.Local()
+	return &t, nil
+}
@@ -0,0 +1,157 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"storing-service/pkg/identity"
+
+	"github.com/google/uuid"
+)
+
+// principal - клиент запроса, подпись которого проверил identity.Verifier
+type principal struct {
+	subject string
+	kind    string
+	role    string
+	courses []string
+}
+
+// principalFromContext возвращает клиента запроса. Вызов без подписанного клиента отклоняется:
+// доступ без ограничений есть только у внутренних вызовов с видом system.
+func principalFromContext(ctx context.Context) (*principal, error) {
+	id, ok := identity.FromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("request has no signed identity: %w", errdefs.ErrUnauthenticated)
+	}
+	return &principal{
+		subject: id.Subject,
+		kind:    id.Kind,
+		role:    id.Role,
+		courses: id.Courses,
+	}, nil
+}
+
+func (p *principal) isAdmin() bool {
+	return p.role == identity.RoleAdmin || p.kind == identity.KindSystem
+}
+
+func (p *principal) isTeacher() bool {
+	return p.role == identity.RoleTeacher || p.isAdmin()
+}
+
+func (p *principal) teaches(courseId string) bool {
+	return courseId != "" && p.isTeacher() && slices.Contains(p.courses, courseId)
+}
+
+// canAccess: своя работа, работа курса преподавателя или любая работа для администратора
+func (p *principal) canAccess(uploadedBy uuid.UUID, courseId string) bool {
+	if p.isAdmin() {
+		return true
+	}
+	if p.kind == identity.KindUser && uploadedBy != uuid.Nil && p.subject == uploadedBy.String() {
+		return true
+	}
+	return p.teaches(courseId)
+}
+
+// checkUploader проверяет, что пользователь загружает файл от своего имени. Сервисы загружают
+// от имени любых пользователей.
+func checkUploader(ctx context.Context, uploadedBy string) error {
+	p, err := principalFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if p.kind != identity.KindUser {
+		return nil
+	}
+
+	if p.subject != uploadedBy {
+		return fmt.Errorf("user %s cannot upload on behalf of %s: %w", p.subject, uploadedBy, errdefs.ErrPermissionDenied)
+	}
+	return nil
+}
+
+// checkTaskAccess проверяет доступ клиента к работе автора uploadedBy в курсе courseId
+func checkTaskAccess(ctx context.Context, uploadedBy uuid.UUID, courseId string) error {
+	p, err := principalFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if p.canAccess(uploadedBy, courseId) {
+		return nil
+	}
+	return fmt.Errorf("%s %s has no access to the task: %w", p.role, p.subject, errdefs.ErrPermissionDenied)
+}
+
+// checkCourseAccess проверяет, что клиент ведет курс или он администратор
+func checkCourseAccess(ctx context.Context, courseId string) error {
+	p, err := principalFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if p.isAdmin() || p.teaches(courseId) {
+		return nil
+	}
+	return fmt.Errorf("%s %s has no access to course %q: %w", p.role, p.subject, courseId, errdefs.ErrPermissionDenied)
+}
+
+// checkUserAccess проверяет, что пользователь работает со своими данными или клиент - администратор
+func checkUserAccess(ctx context.Context, userId uuid.UUID) error {
+	p, err := principalFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if p.isAdmin() || (p.kind == identity.KindUser && p.subject == userId.String()) {
+		return nil
+	}
+	return fmt.Errorf("%s %s has no access to data of user %s: %w", p.role, p.subject, userId, errdefs.ErrPermissionDenied)
+}
+
+// checkAdmin проверяет, что клиент - администратор
+func checkAdmin(ctx context.Context) error {
+	p, err := principalFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if p.isAdmin() {
+		return nil
+	}
+	return fmt.Errorf("%s %s is not an admin: %w", p.role, p.subject, errdefs.ErrPermissionDenied)
+}
+
+// accessScope возвращает ограничение выборки задач клиентом; nil - без ограничений
+func accessScope(ctx context.Context) (*domain.AccessScope, error) {
+	p, err := principalFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p.isAdmin() {
+		return nil, nil
+	}
+
+	scope := &domain.AccessScope{CourseIds: []string{}}
+	if p.kind == identity.KindUser {
+		// subject не UUID - у пользователя не может быть своих задач
+		scope.UploadedBy, _ = uuid.Parse(p.subject)
+	}
+	if p.isTeacher() {
+		scope.CourseIds = p.courses
+	}
+	return scope, nil
+}
+
+// authorizeTask проверяет доступ клиента к задаче до операции, которая не возвращает ее метаданные
+func (h *StoringHandler) authorizeTask(ctx context.Context, fileId uuid.UUID) error {
+	if _, err := principalFromContext(ctx); err != nil {
+		return err
+	}
+
+	task, err := h.svc.GetTask(ctx, fileId)
+	if err != nil {
+		return err
+	}
+	return checkTaskAccess(ctx, task.UploadedBy, task.CourseId)
+}
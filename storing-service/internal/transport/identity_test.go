@@ -0,0 +1,144 @@
+package transport
+
+import (
+	"context"
+	"testing"
+
+	"storing-service/internal/domain"
+	pb "storing-service/pkg/api"
+	"storing-service/pkg/identity"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordingService отдает одну задачу и запоминает вызовы, которые меняют данные или раскрывают файлы;
+// остальные методы не реализованы
+type recordingService struct {
+	StoringService
+	task  *domain.Task
+	calls []string
+}
+
+func (s *recordingService) GetTask(ctx context.Context, fileId uuid.UUID) (*domain.Task, error) {
+	return s.task, nil
+}
+
+func (s *recordingService) UploadTask(ctx context.Context, filename string, uploadedBy uuid.UUID, courseId, assignmentId string, size int64, sha256 string) (*domain.Task, error) {
+	s.calls = append(s.calls, "UploadTask")
+	return s.task, nil
+}
+
+func (s *recordingService) GetFileContent(ctx context.Context, fileId uuid.UUID) ([]byte, error) {
+	s.calls = append(s.calls, "GetFileContent")
+	return []byte("content"), nil
+}
+
+func (s *recordingService) ListTasks(ctx context.Context, filter domain.TaskFilter, order domain.SortOrder, cursor string, limit int) ([]*domain.TaskMetadata, string, error) {
+	s.calls = append(s.calls, "ListTasks")
+	return nil, "", nil
+}
+
+func (s *recordingService) GetAnalysisInput(ctx context.Context, fileId uuid.UUID) (*domain.AnalysisInput, error) {
+	s.calls = append(s.calls, "GetAnalysisInput")
+	return &domain.AnalysisInput{Task: &domain.TaskMetadata{Id: s.task.Id, ObjectKey: s.task.ObjectKey}}, nil
+}
+
+func (s *recordingService) RunRetention(ctx context.Context, dryRun bool) (*domain.RetentionRun, error) {
+	s.calls = append(s.calls, "RunRetention")
+	return &domain.RetentionRun{}, nil
+}
+
+func newTestHandler() (*StoringHandler, *recordingService) {
+	svc := &recordingService{task: &domain.Task{
+		Id:         uuid.New(),
+		UploadedBy: uuid.New(),
+		CourseId:   "cs101",
+		Status:     domain.TaskVerified,
+	}}
+	return NewStoringHandler(svc, zap.NewNop()), svc
+}
+
+func TestHandlerRejectsCallsWithoutIdentity(t *testing.T) {
+	h, svc := newTestHandler()
+	ctx := context.Background()
+	fileId := svc.task.Id.String()
+
+	calls := map[string]func() error{
+		"UploadTask": func() error {
+			_, err := h.UploadTask(ctx, &pb.UploadTaskRequest{Filename: "a.txt", UploadedBy: uuid.NewString()})
+			return err
+		},
+		"GetTask": func() error {
+			_, err := h.GetTask(ctx, &pb.GetTaskRequest{FileId: fileId})
+			return err
+		},
+		"GetFileContent": func() error {
+			_, err := h.GetFileContent(ctx, &pb.GetFileContentRequest{FileId: fileId})
+			return err
+		},
+		"GetAnalysisInput": func() error {
+			_, err := h.GetAnalysisInput(ctx, &pb.GetAnalysisInputRequest{FileId: fileId})
+			return err
+		},
+		"ListTasks": func() error {
+			_, err := h.ListTasks(ctx, &pb.ListTasksRequest{})
+			return err
+		},
+		"RunRetention": func() error {
+			_, err := h.RunRetention(ctx, &pb.RunRetentionRequest{})
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if err := call(); status.Code(err) != codes.Unauthenticated {
+				t.Fatalf("err = %v, want Unauthenticated", err)
+			}
+		})
+	}
+	if len(svc.calls) != 0 {
+		t.Fatalf("service was called without identity: %v", svc.calls)
+	}
+}
+
+func TestHandlerChecksSignedIdentity(t *testing.T) {
+	h, svc := newTestHandler()
+	fileId := svc.task.Id.String()
+
+	student := identity.WithIdentity(context.Background(), identity.Identity{
+		Subject: uuid.NewString(),
+		Kind:    identity.KindUser,
+		Role:    identity.RoleStudent,
+		Tenant:  "default",
+	})
+	if _, err := h.GetFileContent(student, &pb.GetFileContentRequest{FileId: fileId}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("other student: err = %v, want PermissionDenied", err)
+	}
+	if _, err := h.RunRetention(student, &pb.RunRetentionRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("student retention: err = %v, want PermissionDenied", err)
+	}
+
+	teacher := identity.WithIdentity(context.Background(), identity.Identity{
+		Subject: uuid.NewString(),
+		Kind:    identity.KindUser,
+		Role:    identity.RoleTeacher,
+		Tenant:  "default",
+		Courses: []string{"cs101"},
+	})
+	if _, err := h.GetFileContent(teacher, &pb.GetFileContentRequest{FileId: fileId}); err != nil {
+		t.Fatalf("course teacher: %v", err)
+	}
+
+	system := identity.WithIdentity(context.Background(), identity.Identity{
+		Subject: "storing-service",
+		Kind:    identity.KindSystem,
+		Role:    identity.RoleAdmin,
+		Tenant:  "default",
+	})
+	if _, err := h.RunRetention(system, &pb.RunRetentionRequest{}); err != nil {
+		t.Fatalf("system retention: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	pb "storing-service/pkg/api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errUnexpectedMetadata = status.Error(codes.InvalidArgument, "metadata must be sent only in the first message")
+
+// chunkReader представляет части файла из клиентского потока (UploadTaskStream, ImportArchive, WriteBlob)
+// как io.Reader, чтобы файл можно было записать в хранилище без буферизации целиком.
+// Ошибка потока сохраняется, чтобы вернуть клиенту ее, а не ошибку хранилища.
+type chunkReader struct {
+	next func() ([]byte, error)
+	buf  []byte
+	err  error
+}
+
+func newChunkReader(stream pb.StoringService_UploadTaskStreamServer) *chunkReader {
+	return &chunkReader{next: func() ([]byte, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if msg.GetMetadata() != nil {
+			return nil, errUnexpectedMetadata
+		}
+		return msg.GetChunk(), nil
+	}}
+}
+
+func newImportChunkReader(stream pb.StoringService_ImportArchiveServer) *chunkReader {
+	return &chunkReader{next: func() ([]byte, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if msg.GetMetadata() != nil {
+			return nil, errUnexpectedMetadata
+		}
+		return msg.GetChunk(), nil
+	}}
+}
+
+func newWriteBlobChunkReader(stream pb.StoringService_WriteBlobServer) *chunkReader {
+	return &chunkReader{next: func() ([]byte, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if msg.GetMetadata() != nil {
+			return nil, errUnexpectedMetadata
+		}
+		return msg.GetChunk(), nil
+	}}
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		chunk, err := r.next()
+		if err != nil {
+			r.err = err
+			return 0, err
+		}
+		r.buf = chunk
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// streamErr возвращает ошибку чтения потока, если она была (конец потока ошибкой не считается)
+func (r *chunkReader) streamErr() error {
+	if errors.Is(r.err, io.EOF) {
+		return nil
+	}
+	return r.err
+}
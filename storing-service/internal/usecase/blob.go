@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// SignedURLVerifier реализуют хранилища, которые вместо собственных presigned-ссылок выдают
+// подписанные ссылки на api-gateway (локальная файловая система). Файл по такой ссылке
+// передается через ReadSignedBlob и WriteSignedBlob.
+type SignedURLVerifier interface {
+	VerifySignedURL(method, objectKey string, query url.Values) error
+}
+
+// ReadSignedBlob открывает объект по подписанной ссылке PresignGet
+func (s *StoringService) ReadSignedBlob(ctx context.Context, objectKey string, query url.Values) (io.ReadCloser, *domain.BlobInfo, error) {
+	s.logger.Info("reading blob by signed url", zap.String("object_key", objectKey))
+
+	if err := s.verifySignedURL(http.MethodGet, objectKey, query); err != nil {
+		return nil, nil, err
+	}
+
+	info, err := s.blobs.Stat(ctx, objectKey)
+	if err != nil {
+		s.logger.Warn("failed to stat blob",
+			zap.String("object_key", objectKey),
+			zap.Error(err))
+		return nil, nil, err
+	}
+
+	content, err := s.blobs.Get(ctx, objectKey)
+	if err != nil {
+		s.logger.Error("failed to open blob",
+			zap.String("object_key", objectKey),
+			zap.Error(err))
+		return nil, nil, err
+	}
+
+	return content, info, nil
+}
+
+// WriteSignedBlob записывает объект по подписанной ссылке PresignPost. Ссылка действует только для
+// еще не загруженного объекта: повтор запроса не перезаписывает и не удаляет сохраненный файл.
+// Размер и SHA-256 сверяются с подписанными до завершения записи, поэтому несовпадающий файл
+// не попадает под ключ объекта.
+func (s *StoringService) WriteSignedBlob(ctx context.Context, objectKey string, query url.Values, content io.Reader) (int64, error) {
+	s.logger.Info("writing blob by signed url", zap.String("object_key", objectKey))
+
+	if err := s.verifySignedURL(http.MethodPost, objectKey, query); err != nil {
+		return 0, err
+	}
+
+	size, err := strconv.ParseInt(query.Get("size"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size parameter: %w", errdefs.ErrInvalidArgument)
+	}
+	sha256Hex := strings.ToLower(query.Get("sha256"))
+
+	_, err = s.blobs.Stat(ctx, objectKey)
+	if err == nil {
+		s.logger.Warn("signed upload to existing blob rejected", zap.String("object_key", objectKey))
+		return 0, fmt.Errorf("object %s is already uploaded: %w", objectKey, errdefs.ErrAlreadyExists)
+	}
+	if !errors.Is(err, errdefs.ErrNotFound) {
+		s.logger.Error("failed to stat blob",
+			zap.String("object_key", objectKey),
+			zap.Error(err))
+		return 0, err
+	}
+
+	reader := &signedUploadReader{
+		reader:   content,
+		verifier: newUploadVerifier(),
+		size:     size,
+		sha256:   sha256Hex,
+	}
+
+	stored, err := s.blobs.Put(ctx, objectKey, reader, mime.TypeByExtension(path.Ext(objectKey)))
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		if errors.Is(err, errSignedUploadMismatch) {
+			s.logger.Warn("blob does not match signed size or sha256",
+				zap.String("object_key", objectKey),
+				zap.Int64("size", reader.verifier.size),
+				zap.Int64("expected_size", size))
+			return 0, fmt.Errorf("file does not match declared size or sha256: %w", errdefs.ErrInvalidArgument)
+		}
+		s.logger.Error("failed to write blob",
+			zap.String("object_key", objectKey),
+			zap.Error(err))
+		return 0, err
+	}
+
+	s.logger.Info("blob written by signed url",
+		zap.String("object_key", objectKey),
+		zap.Int64("size", stored))
+
+	return stored, nil
+}
+
+var errSignedUploadMismatch = errors.New("upload does not match signed size or sha256")
+
+// signedUploadReader возвращает ошибку вместо конца потока, если файл не совпадает с подписанными
+// размером и SHA-256. Хранилище записывает объект во временный файл и переименовывает его только
+// после успешного чтения всего потока, поэтому несовпадающий файл под ключом объекта не появляется.
+type signedUploadReader struct {
+	reader   io.Reader
+	verifier *uploadVerifier
+	size     int64
+	sha256   string
+}
+
+func (r *signedUploadReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.verifier.Write(p[:n])
+	if r.verifier.size > r.size {
+		return 0, errSignedUploadMismatch
+	}
+	if err == io.EOF && (r.verifier.size != r.size || r.verifier.sha256() != r.sha256) {
+		return n, errSignedUploadMismatch
+	}
+	return n, err
+}
+
+func (s *StoringService) verifySignedURL(method, objectKey string, query url.Values) error {
+	verifier, ok := s.blobs.(SignedURLVerifier)
+	if !ok {
+		// Хранилище выдает собственные presigned-ссылки, ссылок на gateway не существует
+		return fmt.Errorf("signed urls are not used by the storage backend: %w", errdefs.ErrNotFound)
+	}
+
+	if err := verifier.VerifySignedURL(method, objectKey, query); err != nil {
+		s.logger.Warn("invalid signed url",
+			zap.String("object_key", objectKey),
+			zap.String("method", method),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
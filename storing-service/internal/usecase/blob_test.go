@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"storing-service/internal/config"
+	"storing-service/internal/errdefs"
+	"storing-service/internal/infrastucture/fsstore"
+	"storing-service/internal/infrastucture/urlsign"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func newSignedBlobService(t *testing.T) (*StoringService, *fsstore.Store, string) {
+	t.Helper()
+
+	signer, err := urlsign.NewSigner("http://localhost:8080", "0123456789abcdef0123456789abcdef")
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	root := t.TempDir()
+	store, err := fsstore.NewStore(&config.StorageConfig{FSRoot: root}, signer)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	svc := NewStoringService(nil, store, nil, nil, nil, &config.UploadConfig{}, nil, nil, nil, nil, zap.NewNop())
+	return svc, store, root
+}
+
+// presignQuery возвращает параметры подписанной ссылки на загрузку content
+func presignQuery(t *testing.T, store *fsstore.Store, objectKey string, content []byte) url.Values {
+	t.Helper()
+
+	sum := sha256.Sum256(content)
+	u, _, err := store.PresignPost(context.Background(), objectKey, int64(len(content)), sum[:], time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPost: %v", err)
+	}
+	return u.Query()
+}
+
+func readBlob(t *testing.T, store *fsstore.Store, objectKey string) []byte {
+	t.Helper()
+
+	obj, err := store.Get(context.Background(), objectKey)
+	if err != nil {
+		t.Fatalf("Get %s: %v", objectKey, err)
+	}
+	defer obj.Close()
+	content, err := io.ReadAll(obj)
+	if err != nil {
+		t.Fatalf("read %s: %v", objectKey, err)
+	}
+	return content
+}
+
+func TestWriteSignedBlobStoresMatchingFile(t *testing.T) {
+	svc, store, _ := newSignedBlobService(t)
+	content := []byte("package main\n")
+	query := presignQuery(t, store, "cs101/hw1/main.go", content)
+
+	size, err := svc.WriteSignedBlob(context.Background(), "cs101/hw1/main.go", query, bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("WriteSignedBlob: %v", err)
+	}
+	if size != int64(len(content)) {
+		t.Errorf("size = %d, want %d", size, len(content))
+	}
+	if got := readBlob(t, store, "cs101/hw1/main.go"); !bytes.Equal(got, content) {
+		t.Errorf("stored %q, want %q", got, content)
+	}
+}
+
+func TestWriteSignedBlobRejectsMismatchWithoutWriting(t *testing.T) {
+	svc, store, root := newSignedBlobService(t)
+	query := presignQuery(t, store, "cs101/hw1/main.go", []byte("package main\n"))
+
+	for name, content := range map[string][]byte{
+		"other content": []byte("package evil\n"),
+		"shorter":       []byte("package"),
+		"longer":        []byte("package main\nfunc main() {}\n"),
+	} {
+		_, err := svc.WriteSignedBlob(context.Background(), "cs101/hw1/main.go", query, bytes.NewReader(content))
+		if !errors.Is(err, errdefs.ErrInvalidArgument) {
+			t.Errorf("%s: err = %v, want ErrInvalidArgument", name, err)
+		}
+	}
+
+	if _, err := store.Stat(context.Background(), "cs101/hw1/main.go"); !errors.Is(err, errdefs.ErrNotFound) {
+		t.Errorf("Stat after mismatched uploads: err = %v, want ErrNotFound", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(root, "cs101", "hw1"))
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("temporary files left: %v", entries)
+	}
+}
+
+func TestWriteSignedBlobRejectsReplay(t *testing.T) {
+	svc, store, _ := newSignedBlobService(t)
+	content := []byte("package main\n")
+	query := presignQuery(t, store, "cs101/hw1/main.go", content)
+
+	if _, err := svc.WriteSignedBlob(context.Background(), "cs101/hw1/main.go", query, bytes.NewReader(content)); err != nil {
+		t.Fatalf("WriteSignedBlob: %v", err)
+	}
+
+	for name, replay := range map[string][]byte{
+		"same content":  content,
+		"other content": []byte("package evil\n"),
+	} {
+		_, err := svc.WriteSignedBlob(context.Background(), "cs101/hw1/main.go", query, bytes.NewReader(replay))
+		if !errors.Is(err, errdefs.ErrAlreadyExists) {
+			t.Errorf("%s: err = %v, want ErrAlreadyExists", name, err)
+		}
+	}
+
+	if got := readBlob(t, store, "cs101/hw1/main.go"); !bytes.Equal(got, content) {
+		t.Errorf("stored %q after replay, want %q", got, content)
+	}
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"storing-service/internal/infrastucture/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// deletionBatchSize - сколько задач и событий обрабатывается за один проход воркера
+const deletionBatchSize = 100
+
+// DeleteTask помечает задачу удаленной и возвращает момент окончательного удаления.
+// Задача сразу пропадает из выдачи, файл и метаданные удаляются после grace-периода,
+// после чего analysis-service получает событие и удаляет данные анализа задачи.
+func (s *StoringService) DeleteTask(ctx context.Context, fileId uuid.UUID) (time.Time, error) {
+	s.logger.Info("deleting task",
+		zap.String("file_id", fileId.String()),
+		zap.Duration("grace_period", s.deletion.GracePeriod))
+
+	task, err := s.repo.GetTask(ctx, &dto.GetTaskDTO{Id: fileId})
+	if err != nil {
+		s.logger.Error("failed to get task for deletion",
+			zap.String("file_id", fileId.String()),
+			zap.Error(err))
+		return time.Time{}, err
+	}
+
+	deletedAt := time.Now()
+	err = s.repo.SoftDeleteTask(ctx, &dto.SoftDeleteTaskDTO{
+		Id:        fileId,
+		DeletedAt: deletedAt,
+	})
+	if err != nil {
+		s.logger.Error("failed to mark task as deleted",
+			zap.String("file_id", fileId.String()),
+			zap.Error(err))
+		return time.Time{}, err
+	}
+
+	purgeAt := deletedAt.Add(s.deletion.GracePeriod)
+	if s.deletion.GracePeriod == 0 {
+		// При ошибке задача останется помеченной и будет удалена воркером
+		if err := s.purgeTask(ctx, task, domain.TaskEventDeleted); err != nil {
+			s.logger.Warn("immediate purge failed, deferring to worker",
+				zap.String("file_id", fileId.String()),
+				zap.Error(err))
+		}
+	}
+
+	s.logger.Info("task deleted",
+		zap.String("file_id", fileId.String()),
+		zap.Time("purge_at", purgeAt))
+	return purgeAt, nil
+}
+
+// RunDeletionWorker периодически удаляет задачи с истекшим grace-периодом
+// и доставляет события удаления в analysis-service во всех тенантах. Блокируется до отмены контекста.
+func (s *StoringService) RunDeletionWorker(ctx context.Context) {
+	interval := s.deletion.PurgeInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	s.logger.Info("deletion worker started",
+		zap.Duration("interval", interval),
+		zap.Duration("grace_period", s.deletion.GracePeriod))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.forEachTenant(ctx, func(ctx context.Context) {
+			s.purgeExpiredTasks(ctx)
+			s.dispatchEvents(ctx)
+		})
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			s.logger.Info("deletion worker stopped")
+			return
+		}
+	}
+}
+
+func (s *StoringService) purgeExpiredTasks(ctx context.Context) {
+	tasks, err := s.repo.ListPurgeableTasks(ctx, &dto.ListPurgeableTasksDTO{
+		DeletedBefore: time.Now().Add(-s.deletion.GracePeriod),
+		Limit:         deletionBatchSize,
+	})
+	if err != nil {
+		s.logger.Error("failed to list purgeable tasks", zap.Error(err))
+		return
+	}
+
+	for _, task := range tasks {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := s.purgeTask(ctx, task, domain.TaskEventDeleted); err != nil {
+			s.logger.Error("failed to purge task",
+				zap.String("task_id", task.Id.String()),
+				zap.Error(err))
+		}
+	}
+}
+
+// purgeTask удаляет файл задачи из хранилища, затем метаданные задачи вместе с записью события eventType.
+// Если удалить метаданные не удалось, повторный проход снова попытается удалить уже отсутствующий файл.
+func (s *StoringService) purgeTask(ctx context.Context, task *domain.TaskMetadata, eventType domain.TaskEventType) error {
+	objectKey := task.ObjectKey
+	s.logger.Debug("removing task object",
+		zap.String("task_id", task.Id.String()),
+		zap.String("object_key", objectKey))
+
+	exists, err := s.checkFileExists(ctx, objectKey)
+	if err != nil {
+		return fmt.Errorf("failed to check file in storage: %w", errdefs.ErrUnavailable)
+	}
+	if exists {
+		if err := s.blobs.Delete(ctx, objectKey); err != nil {
+			return fmt.Errorf("failed to remove file from storage: %w", errdefs.ErrUnavailable)
+		}
+	}
+	// Файл мог быть перенесен в карантин
+	if s.quarantine != nil {
+		if err := s.quarantine.Delete(ctx, objectKey); err != nil {
+			return fmt.Errorf("failed to remove file from quarantine: %w", errdefs.ErrUnavailable)
+		}
+	}
+
+	eventId, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.PurgeTask(ctx, &dto.PurgeTaskDTO{
+		Id:        task.Id,
+		EventId:   eventId,
+		EventType: eventType,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("task purged",
+		zap.String("task_id", task.Id.String()),
+		zap.String("object_key", objectKey))
+	return nil
+}
+
+// dispatchEvents доставляет недоставленные события в порядке их создания.
+// analysis-service обрабатывает удаление идемпотентно, поэтому повторная доставка безопасна.
+func (s *StoringService) dispatchEvents(ctx context.Context) {
+	events, err := s.repo.ListPendingEvents(ctx, &dto.ListPendingEventsDTO{Limit: deletionBatchSize})
+	if err != nil {
+		s.logger.Error("failed to list pending events", zap.Error(err))
+		return
+	}
+
+	for _, event := range events {
+		if ctx.Err() != nil {
+			return
+		}
+
+		mark := &dto.MarkEventDTO{Id: event.Id}
+		if err := s.deliverEvent(ctx, event); err != nil {
+			s.logger.Warn("failed to deliver task event",
+				zap.String("event_id", event.Id.String()),
+				zap.String("task_id", event.TaskId.String()),
+				zap.String("type", string(event.Type)),
+				zap.Int("attempts", event.Attempts+1),
+				zap.Error(err))
+			mark.Error = err.Error()
+		}
+
+		if err := s.repo.MarkEvent(ctx, mark); err != nil {
+			s.logger.Error("failed to save event delivery state",
+				zap.String("event_id", event.Id.String()),
+				zap.Error(err))
+		}
+	}
+}
+
+func (s *StoringService) deliverEvent(ctx context.Context, event *domain.TaskEvent) error {
+	switch event.Type {
+	case domain.TaskEventDeleted:
+		return s.analysisClient.PurgeTask(ctx, event.TaskId.String(), false)
+	case domain.TaskEventErased:
+		return s.analysisClient.PurgeTask(ctx, event.TaskId.String(), true)
+	default:
+		return fmt.Errorf("unknown event type %s: %w", event.Type, errdefs.ErrInvalidArgument)
+	}
+}
+
+// EraseUserData окончательно удаляет все задачи автора, включая помеченные удаленными, без grace-периода.
+// В отчетах других задач ссылки на удаленные работы обезличиваются, а не удаляются.
+// Возвращает число удаленных задач; при ошибке удаление можно повторить.
+func (s *StoringService) EraseUserData(ctx context.Context, uploadedBy uuid.UUID) (int, error) {
+	s.logger.Info("erasing user data", zap.String("uploaded_by", uploadedBy.String()))
+
+	if uploadedBy == uuid.Nil {
+		s.logger.Warn("empty uploaded_by for erasure")
+		return 0, fmt.Errorf("uploaded_by is required: %w", errdefs.ErrInvalidArgument)
+	}
+
+	erased := 0
+	for {
+		tasks, err := s.repo.ListUserTasks(ctx, &dto.ListUserTasksDTO{
+			UploadedBy: uploadedBy,
+			Limit:      deletionBatchSize,
+		})
+		if err != nil {
+			s.logger.Error("failed to list user tasks",
+				zap.String("uploaded_by", uploadedBy.String()),
+				zap.Error(err))
+			return erased, err
+		}
+
+		for _, task := range tasks {
+			err := s.repo.SoftDeleteTask(ctx, &dto.SoftDeleteTaskDTO{
+				Id:        task.Id,
+				DeletedAt: time.Now(),
+			})
+			// Задача уже помечена удаленной через DeleteTask
+			if err != nil && !errors.Is(err, errdefs.ErrNotFound) {
+				return erased, err
+			}
+
+			if err := s.purgeTask(ctx, task, domain.TaskEventErased); err != nil {
+				s.logger.Error("failed to erase task",
+					zap.String("task_id", task.Id.String()),
+					zap.Error(err))
+				return erased, err
+			}
+			erased++
+		}
+
+		if len(tasks) < deletionBatchSize {
+			break
+		}
+	}
+
+	s.logger.Info("user data erased",
+		zap.String("uploaded_by", uploadedBy.String()),
+		zap.Int("tasks_erased", erased))
+	return erased, nil
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"storing-service/internal/infrastucture/dto"
+
+	"go.uber.org/zap"
+)
+
+// findOriginalTask ищет по SHA-256 содержимого более раннюю задачу другого пользователя с тем же файлом.
+// Ошибка поиска не мешает анализу: задача просто проходит полное сравнение.
+func (s *StoringService) findOriginalTask(ctx context.Context, task *domain.TaskMetadata) *domain.TaskMetadata {
+	if task.ContentSha256 == "" {
+		return nil
+	}
+
+	original, err := s.repo.FindDuplicateTask(ctx, &dto.FindDuplicateTaskDTO{
+		Id:            task.Id,
+		UploadedBy:    task.UploadedBy,
+		ContentSha256: task.ContentSha256,
+	})
+	if errors.Is(err, errdefs.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		s.logger.Warn("failed to look up duplicate by content hash",
+			zap.String("task_id", task.Id.String()),
+			zap.Error(err))
+		return nil
+	}
+
+	s.logger.Info("exact duplicate found by content hash",
+		zap.String("task_id", task.Id.String()),
+		zap.String("original_task_id", original.Id.String()))
+	return original
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"storing-service/pkg/tenant"
+
+	"go.uber.org/zap"
+)
+
+// KeyRotator реализуют хранилища с конвертным шифрованием файлов
+type KeyRotator interface {
+	// Rewrap перешифровывает ключ объекта активным мастер-ключом. Возвращает false,
+	// если объект уже зашифрован активным ключом.
+	Rewrap(ctx context.Context, objectKey string) (bool, error)
+}
+
+// RotateEncryptionKeys перешифровывает ключи всех файлов тенанта активным мастер-ключом, а файлы,
+// сохраненные до включения шифрования, шифрует. Ошибка отдельного файла не прерывает ротацию.
+// Старый мастер-ключ можно убрать из конфигурации после ротации во всех тенантах.
+func (s *StoringService) RotateEncryptionKeys(ctx context.Context) (*domain.KeyRotation, error) {
+	s.logger.Info("starting encryption key rotation", zap.String("tenant_id", tenant.FromContext(ctx)))
+
+	rotator, ok := s.blobs.(KeyRotator)
+	if !ok {
+		s.logger.Warn("key rotation requested with encryption disabled")
+		return nil, fmt.Errorf("encryption is disabled: %w", errdefs.ErrInvalidArgument)
+	}
+
+	if !s.rotationMu.TryLock() {
+		s.logger.Warn("key rotation is already in progress")
+		return nil, fmt.Errorf("key rotation is already in progress: %w", errdefs.ErrAlreadyExists)
+	}
+	defer s.rotationMu.Unlock()
+
+	blobs, err := s.listTenantBlobs(ctx)
+	if err != nil {
+		s.logger.Error("failed to list blobs for key rotation", zap.Error(err))
+		return nil, err
+	}
+
+	rotation := &domain.KeyRotation{}
+	for _, blob := range blobs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		rotation.Checked++
+		rewrapped, err := rotator.Rewrap(ctx, blob.Key)
+		if err != nil {
+			s.logger.Warn("failed to rewrap blob key",
+				zap.String("object_key", blob.Key),
+				zap.Error(err))
+			rotation.Failed++
+			continue
+		}
+		if rewrapped {
+			rotation.Rewrapped++
+		}
+	}
+
+	s.logger.Info("encryption key rotation finished",
+		zap.Int("checked", rotation.Checked),
+		zap.Int("rewrapped", rotation.Rewrapped),
+		zap.Int("failed", rotation.Failed))
+
+	return rotation, nil
+}
@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"storing-service/pkg/tenant"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// defaultNamingRule - автор определяется по папке верхнего уровня: <uploaded_by>/work.pdf
+const defaultNamingRule = `^(?P<student>[^/]+)/`
+
+// importedTask - задача, созданная импортом и ожидающая запуска анализа
+type importedTask struct {
+	task      *domain.TaskMetadata
+	objectKey string
+}
+
+// ImportArchive создает по задаче на каждый файл ZIP-архива. Автор файла определяется
+// по манифесту (CSV с колонками path,uploaded_by), а без него - по правилу именования.
+// Ошибка отдельного файла не прерывает импорт и попадает в отчет. Анализ созданных
+// задач запускается в фоне по очереди, чтобы не перегружать analysis-service.
+func (s *StoringService) ImportArchive(ctx context.Context, courseId, assignmentId, namingRule string, manifestCSV []byte, archive io.Reader) ([]domain.ImportEntry, error) {
+	s.logger.Info("starting archive import",
+		zap.String("course_id", courseId),
+		zap.String("assignment_id", assignmentId),
+		zap.String("naming_rule", namingRule),
+		zap.Bool("manifest", len(manifestCSV) > 0))
+
+	resolver, err := newStudentResolver(namingRule, manifestCSV)
+	if err != nil {
+		s.logger.Warn("invalid import naming rule or manifest", zap.Error(err))
+		return nil, err
+	}
+
+	// zip.Reader требует произвольного доступа, поэтому архив сначала записывается во временный файл
+	file, err := os.CreateTemp("", "import-*.zip")
+	if err != nil {
+		s.logger.Error("failed to create temp file for archive", zap.Error(err))
+		return nil, fmt.Errorf("failed to create temp file: %w", errdefs.ErrUnavailable)
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	size, err := io.Copy(file, io.LimitReader(archive, s.cfg.ImportMaxSize+1))
+	if err != nil {
+		s.logger.Error("failed to receive archive", zap.Error(err))
+		return nil, err
+	}
+	if size > s.cfg.ImportMaxSize {
+		s.logger.Warn("archive is too large", zap.Int64("max_size", s.cfg.ImportMaxSize))
+		return nil, fmt.Errorf("archive exceeds %d bytes: %w", s.cfg.ImportMaxSize, errdefs.ErrInvalidArgument)
+	}
+
+	zr, err := zip.NewReader(file, size)
+	if err != nil {
+		s.logger.Warn("invalid zip archive", zap.Error(err))
+		return nil, fmt.Errorf("invalid zip archive: %w", errdefs.ErrInvalidArgument)
+	}
+
+	var files int64
+	for _, f := range zr.File {
+		if !skipArchiveEntry(f) {
+			files++
+		}
+	}
+	if files > s.cfg.ImportMaxEntries {
+		s.logger.Warn("archive has too many files",
+			zap.Int64("files", files),
+			zap.Int64("max_entries", s.cfg.ImportMaxEntries))
+		return nil, fmt.Errorf("archive contains %d files, at most %d allowed: %w", files, s.cfg.ImportMaxEntries, errdefs.ErrInvalidArgument)
+	}
+
+	entries := make([]domain.ImportEntry, 0, len(zr.File))
+	var queued []importedTask
+	for _, f := range zr.File {
+		entry, imported := s.importArchiveEntry(ctx, f, resolver, courseId, assignmentId)
+		entries = append(entries, entry)
+		if imported != nil {
+			queued = append(queued, *imported)
+		}
+	}
+
+	if len(queued) > 0 {
+		go s.analyseImportedTasks(tenant.Detach(ctx), queued)
+	}
+
+	s.logger.Info("archive import completed",
+		zap.Int64("size", size),
+		zap.Int("entries", len(entries)),
+		zap.Int("queued", len(queued)))
+
+	return entries, nil
+}
+
+// importArchiveEntry сохраняет один файл архива. Возвращает задачу для анализа,
+// если файл прошел проверку.
+func (s *StoringService) importArchiveEntry(ctx context.Context, f *zip.File, resolver *studentResolver, courseId, assignmentId string) (domain.ImportEntry, *importedTask) {
+	name := archiveEntryName(f)
+	entry := domain.ImportEntry{Path: name}
+
+	if skipArchiveEntry(f) {
+		entry.Status = domain.ImportSkipped
+		return entry, nil
+	}
+
+	fail := func(err error) (domain.ImportEntry, *importedTask) {
+		s.logger.Warn("failed to import archive entry",
+			zap.String("path", name),
+			zap.Error(err))
+		entry.Status = domain.ImportFailed
+		entry.Error = err.Error()
+		return entry, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fail(err)
+	}
+
+	uploadedBy, err := resolver.resolve(name)
+	if err != nil {
+		return fail(err)
+	}
+	entry.UploadedBy = uploadedBy
+
+	content, err := f.Open()
+	if err != nil {
+		return fail(fmt.Errorf("failed to open archive entry: %w", errdefs.ErrInvalidArgument))
+	}
+	defer content.Close()
+
+	task, objectKey, _, err := s.storeTaskContent(ctx, path.Base(name), uploadedBy, courseId, assignmentId, int64(f.UncompressedSize64), "", content)
+	if err != nil {
+		return fail(err)
+	}
+	entry.TaskId = task.Id
+
+	s.logger.Debug("archive entry imported",
+		zap.String("path", name),
+		zap.String("task_id", task.Id.String()),
+		zap.String("status", string(task.Status)))
+
+	if task.Status != domain.TaskVerified {
+		entry.Status = domain.ImportRejected
+		entry.Error = task.StatusReason
+		return entry, nil
+	}
+	entry.Status = domain.ImportImported
+	return entry, &importedTask{task: task, objectKey: objectKey}
+}
+
+func (s *StoringService) analyseImportedTasks(ctx context.Context, queued []importedTask) {
+	s.logger.Info("starting analysis of imported tasks", zap.Int("count", len(queued)))
+	for _, imported := range queued {
+		s.requestAnalysis(ctx, imported.task, imported.objectKey)
+	}
+}
+
+// archiveEntryName приводит путь файла архива к виду с прямыми слешами
+func archiveEntryName(f *zip.File) string {
+	return strings.TrimPrefix(strings.ReplaceAll(f.Name, `\`, "/"), "/")
+}
+
+// skipArchiveEntry отсекает папки и служебные файлы архиваторов (__MACOSX, .DS_Store и т.п.)
+func skipArchiveEntry(f *zip.File) bool {
+	name := archiveEntryName(f)
+	if f.FileInfo().IsDir() || strings.HasSuffix(name, "/") {
+		return true
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == "__MACOSX" || strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// studentResolver определяет автора файла архива по манифесту или правилу именования
+type studentResolver struct {
+	// путь файла или папки -> uploaded_by
+	manifest map[string]string
+	rule     *regexp.Regexp
+	group    int
+}
+
+func newStudentResolver(namingRule string, manifestCSV []byte) (*studentResolver, error) {
+	if len(manifestCSV) > 0 {
+		manifest, err := parseImportManifest(manifestCSV)
+		if err != nil {
+			return nil, err
+		}
+		return &studentResolver{manifest: manifest}, nil
+	}
+
+	if namingRule == "" {
+		namingRule = defaultNamingRule
+	}
+	rule, err := regexp.Compile(namingRule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid naming rule: %v: %w", err, errdefs.ErrInvalidArgument)
+	}
+
+	// Без именованной группы student используется первая группа
+	group := rule.SubexpIndex("student")
+	if group < 0 {
+		group = 1
+	}
+	if rule.NumSubexp() < group || group == 0 {
+		return nil, fmt.Errorf("naming rule must contain a capturing group: %w", errdefs.ErrInvalidArgument)
+	}
+
+	return &studentResolver{rule: rule, group: group}, nil
+}
+
+// resolve возвращает автора файла. Запись манифеста для папки относится ко всем файлам в ней,
+// приоритет у наиболее точного пути.
+func (r *studentResolver) resolve(name string) (uuid.UUID, error) {
+	var student string
+	if r.manifest != nil {
+		for p := path.Clean(name); p != "." && p != "/"; p = path.Dir(p) {
+			if v, ok := r.manifest[p]; ok {
+				student = v
+				break
+			}
+		}
+		if student == "" {
+			return uuid.Nil, fmt.Errorf("file is not listed in manifest: %w", errdefs.ErrInvalidArgument)
+		}
+	} else {
+		match := r.rule.FindStringSubmatch(name)
+		if match == nil || match[r.group] == "" {
+			return uuid.Nil, fmt.Errorf("file name does not match naming rule: %w", errdefs.ErrInvalidArgument)
+		}
+		student = match[r.group]
+	}
+
+	uploadedBy, err := uuid.Parse(student)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("student id %q is not a valid UUID: %w", student, errdefs.ErrInvalidArgument)
+	}
+	return uploadedBy, nil
+}
+
+// parseImportManifest разбирает CSV с заголовком path,uploaded_by (порядок колонок любой)
+func parseImportManifest(data []byte) (map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest header: %w", errdefs.ErrInvalidArgument)
+	}
+	pathCol, userCol := -1, -1
+	for i, column := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))) {
+		case "path":
+			pathCol = i
+		case "uploaded_by":
+			userCol = i
+		}
+	}
+	if pathCol < 0 || userCol < 0 {
+		return nil, fmt.Errorf("manifest must have path and uploaded_by columns: %w", errdefs.ErrInvalidArgument)
+	}
+
+	manifest := make(map[string]string)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest: %v: %w", err, errdefs.ErrInvalidArgument)
+		}
+
+		p := strings.Trim(path.Clean(strings.ReplaceAll(strings.TrimSpace(record[pathCol]), `\`, "/")), "/")
+		if p == "" || p == "." {
+			continue
+		}
+		manifest[p] = strings.TrimSpace(record[userCol])
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"storing-service/internal/infrastucture/dto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// ListTasks возвращает страницу задач, отсортированных по (created_at, id), и курсор следующей страницы.
+// Пустой курсор в ответе означает, что страница последняя.
+func (s *StoringService) ListTasks(ctx context.Context, filter domain.TaskFilter, order domain.SortOrder, cursor string, limit int) ([]*domain.TaskMetadata, string, error) {
+	s.logger.Info("listing tasks",
+		zap.String("uploaded_by", filter.UploadedBy.String()),
+		zap.String("assignment_id", filter.AssignmentId),
+		zap.String("status", string(filter.Status)),
+		zap.String("order", string(order)),
+		zap.Int("limit", limit))
+
+	if limit < 0 || limit > maxListLimit {
+		s.logger.Warn("invalid list limit", zap.Int("limit", limit))
+		return nil, "", fmt.Errorf("limit must be between 1 and %d: %w", maxListLimit, errdefs.ErrInvalidArgument)
+	}
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+
+	switch order {
+	case "":
+		order = domain.OrderDesc
+	case domain.OrderAsc, domain.OrderDesc:
+	default:
+		s.logger.Warn("invalid list order", zap.String("order", string(order)))
+		return nil, "", fmt.Errorf("order must be asc or desc: %w", errdefs.ErrInvalidArgument)
+	}
+
+	switch filter.Status {
+	case "", domain.TaskPending, domain.TaskVerified, domain.TaskRejected, domain.TaskUnverified, domain.TaskExpired:
+	default:
+		s.logger.Warn("invalid status filter", zap.String("status", string(filter.Status)))
+		return nil, "", fmt.Errorf("unknown task status %s: %w", filter.Status, errdefs.ErrInvalidArgument)
+	}
+
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		s.logger.Warn("invalid created_at range",
+			zap.Time("created_from", *filter.CreatedFrom),
+			zap.Time("created_to", *filter.CreatedTo))
+		return nil, "", fmt.Errorf("created_from must be before created_to: %w", errdefs.ErrInvalidArgument)
+	}
+
+	after, err := decodeTaskCursor(cursor)
+	if err != nil {
+		s.logger.Warn("invalid list cursor", zap.String("cursor", cursor), zap.Error(err))
+		return nil, "", err
+	}
+
+	// Лишняя запись показывает, есть ли следующая страница
+	tasks, err := s.repo.ListTasks(ctx, &dto.ListTasksDTO{
+		Filter: filter,
+		Order:  order,
+		After:  after,
+		Limit:  limit + 1,
+	})
+	if err != nil {
+		s.logger.Error("failed to list tasks", zap.Error(err))
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+		last := tasks[limit-1]
+		nextCursor = encodeTaskCursor(domain.TaskCursor{CreatedAt: last.CreatedAt, Id: last.Id})
+	}
+
+	s.logger.Info("tasks listed",
+		zap.Int("count", len(tasks)),
+		zap.Bool("has_more", nextCursor != ""))
+	return tasks, nextCursor, nil
+}
+
+// encodeTaskCursor кодирует позицию в непрозрачную для клиента строку
+func encodeTaskCursor(c domain.TaskCursor) string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixMicro(), 10) + ":" + c.Id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeTaskCursor(cursor string) (*domain.TaskCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", errdefs.ErrInvalidArgument)
+	}
+
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, fmt.Errorf("malformed cursor: %w", errdefs.ErrInvalidArgument)
+	}
+
+	usec, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", errdefs.ErrInvalidArgument)
+	}
+
+	taskId, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", errdefs.ErrInvalidArgument)
+	}
+
+	// created_at хранится без часового пояса, pgx читает его как UTC
+	return &domain.TaskCursor{CreatedAt: time.UnixMicro(usec).UTC(), Id: taskId}, nil
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"storing-service/internal/infrastucture/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// checkQuota проверяет, что пользователь может загрузить еще один файл размером size:
+// число загрузок за окно QUOTA_WINDOW и объем файлов пользователя и задания не превысят лимиты.
+// Это предварительная проверка, чтобы не принимать файл сверх квоты; окончательно квоты проверяются
+// атомарно с созданием задачи (см. taskQuota).
+func (s *StoringService) checkQuota(ctx context.Context, uploadedBy uuid.UUID, assignmentId string, size int64) error {
+	if s.quota.MaxUploads > 0 || s.quota.MaxUserBytes > 0 {
+		usage, err := s.repo.GetUserUsage(ctx, &dto.GetUserUsageDTO{
+			UploadedBy:    uploadedBy,
+			UploadedSince: time.Now().Add(-s.quota.Window),
+		})
+		if err != nil {
+			s.logger.Error("failed to get user usage",
+				zap.String("uploaded_by", uploadedBy.String()),
+				zap.Error(err))
+			return err
+		}
+
+		if s.quota.MaxUploads > 0 && usage.Uploads >= s.quota.MaxUploads {
+			s.logger.Warn("upload rate quota exceeded",
+				zap.String("uploaded_by", uploadedBy.String()),
+				zap.Int64("uploads", usage.Uploads),
+				zap.Int64("max_uploads", s.quota.MaxUploads))
+			return fmt.Errorf("at most %d uploads per %s allowed: %w", s.quota.MaxUploads, s.quota.Window, errdefs.ErrQuotaExceeded)
+		}
+		if s.quota.MaxUserBytes > 0 && usage.Bytes+size > s.quota.MaxUserBytes {
+			s.logger.Warn("user storage quota exceeded",
+				zap.String("uploaded_by", uploadedBy.String()),
+				zap.Int64("bytes", usage.Bytes),
+				zap.Int64("size", size),
+				zap.Int64("max_bytes", s.quota.MaxUserBytes))
+			return fmt.Errorf("user storage quota of %d bytes exceeded: %w", s.quota.MaxUserBytes, errdefs.ErrQuotaExceeded)
+		}
+	}
+
+	if s.quota.MaxAssignmentBytes > 0 && assignmentId != "" {
+		usage, err := s.repo.GetAssignmentUsage(ctx, &dto.GetAssignmentUsageDTO{AssignmentId: assignmentId})
+		if err != nil {
+			s.logger.Error("failed to get assignment usage",
+				zap.String("assignment_id", assignmentId),
+				zap.Error(err))
+			return err
+		}
+
+		if usage.Bytes+size > s.quota.MaxAssignmentBytes {
+			s.logger.Warn("assignment storage quota exceeded",
+				zap.String("assignment_id", assignmentId),
+				zap.Int64("bytes", usage.Bytes),
+				zap.Int64("size", size),
+				zap.Int64("max_bytes", s.quota.MaxAssignmentBytes))
+			return fmt.Errorf("assignment storage quota of %d bytes exceeded: %w", s.quota.MaxAssignmentBytes, errdefs.ErrQuotaExceeded)
+		}
+	}
+
+	return nil
+}
+
+// taskQuota возвращает квоты, которые репозиторий проверяет в транзакции создания задачи под
+// блокировкой автора и задания, поэтому параллельные загрузки не превышают лимиты
+func (s *StoringService) taskQuota() *dto.TaskQuotaDTO {
+	if s.quota.MaxUploads == 0 && s.quota.MaxUserBytes == 0 && s.quota.MaxAssignmentBytes == 0 {
+		return nil
+	}
+	return &dto.TaskQuotaDTO{
+		Window:             s.quota.Window,
+		MaxUploads:         s.quota.MaxUploads,
+		MaxUserBytes:       s.quota.MaxUserBytes,
+		MaxAssignmentBytes: s.quota.MaxAssignmentBytes,
+	}
+}
+
+func (s *StoringService) logCreateTaskError(id uuid.UUID, err error) {
+	if errors.Is(err, errdefs.ErrQuotaExceeded) {
+		s.logger.Warn("quota exceeded on task creation",
+			zap.String("task_id", id.String()),
+			zap.Error(err))
+		return
+	}
+	s.logger.Error("failed to create task in database",
+		zap.String("task_id", id.String()),
+		zap.Error(err))
+}
+
+// GetQuotaUsage возвращает лимиты и текущее использование квот пользователем и (или) заданием
+func (s *StoringService) GetQuotaUsage(ctx context.Context, uploadedBy uuid.UUID, assignmentId string) (*domain.QuotaUsage, error) {
+	s.logger.Info("getting quota usage",
+		zap.String("uploaded_by", uploadedBy.String()),
+		zap.String("assignment_id", assignmentId))
+
+	if uploadedBy == uuid.Nil && assignmentId == "" {
+		return nil, fmt.Errorf("uploaded_by or assignment_id is required: %w", errdefs.ErrInvalidArgument)
+	}
+
+	result := &domain.QuotaUsage{
+		Window:             s.quota.Window,
+		MaxFileSize:        s.cfg.MaxSize,
+		MaxUploads:         s.quota.MaxUploads,
+		MaxUserBytes:       s.quota.MaxUserBytes,
+		MaxAssignmentBytes: s.quota.MaxAssignmentBytes,
+	}
+
+	if uploadedBy != uuid.Nil {
+		usage, err := s.repo.GetUserUsage(ctx, &dto.GetUserUsageDTO{
+			UploadedBy:    uploadedBy,
+			UploadedSince: time.Now().Add(-s.quota.Window),
+		})
+		if err != nil {
+			s.logger.Error("failed to get user usage",
+				zap.String("uploaded_by", uploadedBy.String()),
+				zap.Error(err))
+			return nil, err
+		}
+		result.User = usage
+	}
+
+	if assignmentId != "" {
+		usage, err := s.repo.GetAssignmentUsage(ctx, &dto.GetAssignmentUsageDTO{AssignmentId: assignmentId})
+		if err != nil {
+			s.logger.Error("failed to get assignment usage",
+				zap.String("assignment_id", assignmentId),
+				zap.Error(err))
+			return nil, err
+		}
+		result.Assignment = usage
+	}
+
+	return result, nil
+}
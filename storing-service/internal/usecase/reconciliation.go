@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"storing-service/internal/config"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"storing-service/internal/infrastucture/dto"
+	"storing-service/pkg/tenant"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// reconciliationBatchSize - сколько задач читается из базы за один запрос
+	reconciliationBatchSize = 1000
+	// quarantinePrefix - объекты, перенесенные из основного пространства ключей; сверка их не трогает
+	quarantinePrefix = "quarantine/"
+)
+
+// RunReconciliation сверяет задачи тенанта запроса с его файлами в хранилище:
+//   - задачи pending, файл которых не загружен за UploadTimeout, помечаются expired;
+//   - задачи pending с загруженным, но непроверенным файлом проверяются заново;
+//   - проверенные задачи без файла попадают в отчет;
+//   - объекты без задачи старше UploadTimeout переносятся в quarantine/ или удаляются.
+//
+// В режиме dryRun расхождения только перечисляются.
+func (s *StoringService) RunReconciliation(ctx context.Context, dryRun bool) (*domain.ReconciliationRun, error) {
+	s.logger.Info("starting reconciliation run",
+		zap.String("tenant_id", tenant.FromContext(ctx)),
+		zap.Bool("dry_run", dryRun))
+
+	if !s.reconciliationMu.TryLock() {
+		s.logger.Warn("reconciliation run is already in progress")
+		return nil, fmt.Errorf("reconciliation run is already in progress: %w", errdefs.ErrAlreadyExists)
+	}
+	defer s.reconciliationMu.Unlock()
+
+	run := &domain.ReconciliationRun{
+		DryRun:      dryRun,
+		StrayAction: s.reconciliation.StrayAction,
+		StartedAt:   time.Now(),
+	}
+	// Загрузки и объекты моложе этого момента могут быть еще не завершены
+	cutoff := run.StartedAt.Add(-s.reconciliation.UploadTimeout)
+
+	// Объекты читаются до задач: объект, записанный после листинга, не может оказаться лишним
+	blobs, err := s.listTenantBlobs(ctx)
+	if err != nil {
+		s.logger.Error("failed to list blobs for reconciliation", zap.Error(err))
+		return nil, err
+	}
+	objects := make(map[string]domain.BlobInfo, len(blobs))
+	for _, blob := range blobs {
+		if !strings.HasPrefix(blob.Key, quarantinePrefix) {
+			objects[blob.Key] = blob
+		}
+	}
+
+	afterId := uuid.Nil
+	for {
+		tasks, err := s.repo.ListTaskObjects(ctx, &dto.ListTaskObjectsDTO{
+			AfterId: afterId,
+			Limit:   reconciliationBatchSize,
+		})
+		if err != nil {
+			s.logger.Error("failed to list tasks for reconciliation", zap.Error(err))
+			return nil, err
+		}
+
+		for _, object := range tasks {
+			objectKey := object.Task.ObjectKey
+			_, exists := objects[objectKey]
+			delete(objects, objectKey)
+
+			if !object.Deleted {
+				s.reconcileTask(ctx, run, object.Task, objectKey, exists, cutoff)
+			}
+		}
+
+		if len(tasks) < reconciliationBatchSize {
+			break
+		}
+		afterId = tasks[len(tasks)-1].Task.Id
+	}
+
+	for _, objectKey := range slices.Sorted(maps.Keys(objects)) {
+		blob := objects[objectKey]
+		if blob.ModifiedAt.After(cutoff) {
+			continue
+		}
+		item := domain.ReconciliationItem{
+			Kind:      domain.ReconciliationStrayObject,
+			ObjectKey: objectKey,
+		}
+		run.StrayObjects++
+
+		if !dryRun {
+			if err := s.removeStrayObject(ctx, objectKey, blob.ContentType); err != nil {
+				s.logger.Error("failed to remove stray object",
+					zap.String("object_key", objectKey),
+					zap.Error(err))
+				item.Error = err.Error()
+				run.Failed++
+			}
+		}
+		run.Items = append(run.Items, item)
+	}
+	run.FinishedAt = time.Now()
+
+	s.logger.Info("reconciliation run finished",
+		zap.Bool("dry_run", dryRun),
+		zap.Int("expired_uploads", run.ExpiredUploads),
+		zap.Int("resumed_uploads", run.ResumedUploads),
+		zap.Int("missing_objects", run.MissingObjects),
+		zap.Int("stray_objects", run.StrayObjects),
+		zap.Int("failed", run.Failed))
+
+	return run, nil
+}
+
+// RunReconciliationWorker запускает сверку во всех тенантах с периодом RECONCILIATION_INTERVAL до отмены ctx
+func (s *StoringService) RunReconciliationWorker(ctx context.Context) {
+	interval := s.reconciliation.Interval
+	if interval <= 0 {
+		s.logger.Info("reconciliation interval is not set, reconciliation worker disabled")
+		return
+	}
+
+	s.logger.Info("reconciliation worker started",
+		zap.Duration("interval", interval),
+		zap.Duration("upload_timeout", s.reconciliation.UploadTimeout),
+		zap.String("stray_action", s.reconciliation.StrayAction),
+		zap.Bool("dry_run", s.reconciliation.DryRun))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.forEachTenant(ctx, func(ctx context.Context) {
+			if _, err := s.RunReconciliation(ctx, s.reconciliation.DryRun); err != nil {
+				s.logger.Error("scheduled reconciliation run failed",
+					zap.String("tenant_id", tenant.FromContext(ctx)),
+					zap.Error(err))
+			}
+		})
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			s.logger.Info("reconciliation worker stopped")
+			return
+		}
+	}
+}
+
+func (s *StoringService) reconcileTask(ctx context.Context, run *domain.ReconciliationRun, task *domain.TaskMetadata, objectKey string, exists bool, cutoff time.Time) {
+	item := domain.ReconciliationItem{
+		TaskId:    task.Id,
+		ObjectKey: objectKey,
+	}
+
+	switch {
+	case task.Status == domain.TaskPending && task.CreatedAt.Before(cutoff):
+		if exists {
+			item.Kind = domain.ReconciliationResumedUpload
+			run.ResumedUploads++
+			if !run.DryRun {
+				if err := s.resumeUpload(ctx, task, objectKey); err != nil {
+					item.Error = err.Error()
+				}
+			}
+		} else {
+			item.Kind = domain.ReconciliationExpiredUpload
+			run.ExpiredUploads++
+			if !run.DryRun {
+				if err := s.expireUpload(ctx, task); err != nil {
+					item.Error = err.Error()
+				}
+			}
+		}
+	case (task.Status == domain.TaskVerified || task.Status == domain.TaskUnverified) && !exists:
+		// Восстановить файл нельзя, расхождение только попадает в отчет
+		s.logger.Warn("task object is missing from storage",
+			zap.String("task_id", task.Id.String()),
+			zap.String("object_key", objectKey))
+		item.Kind = domain.ReconciliationMissingObject
+		run.MissingObjects++
+	default:
+		return
+	}
+
+	if item.Error != "" {
+		run.Failed++
+	}
+	run.Items = append(run.Items, item)
+}
+
+// expireUpload помечает задачу, файл которой не загружен. Задача, проверка которой
+// началась после листинга, не меняется.
+func (s *StoringService) expireUpload(ctx context.Context, task *domain.TaskMetadata) error {
+	err := s.repo.ExpireUpload(ctx, &dto.ExpireUploadDTO{
+		Id:     task.Id,
+		Reason: fmt.Sprintf("file was not uploaded within %s", s.reconciliation.UploadTimeout),
+	})
+	if errors.Is(err, errdefs.ErrNotFound) {
+		s.logger.Debug("task is no longer pending, not expired", zap.String("task_id", task.Id.String()))
+		return nil
+	}
+	if err != nil {
+		s.logger.Error("failed to expire task upload",
+			zap.String("task_id", task.Id.String()),
+			zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("task upload expired", zap.String("task_id", task.Id.String()))
+	return nil
+}
+
+// resumeUpload проверяет файл, загруженный после того, как ожидание загрузки закончилось,
+// и запускает анализ
+func (s *StoringService) resumeUpload(ctx context.Context, task *domain.TaskMetadata, objectKey string) error {
+	status, err := s.verifyStoredObject(ctx, task, objectKey)
+	if err != nil {
+		s.logger.Error("failed to verify late upload",
+			zap.String("task_id", task.Id.String()),
+			zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("late upload verified",
+		zap.String("task_id", task.Id.String()),
+		zap.String("status", string(status)))
+	if status == domain.TaskVerified {
+		go s.requestAnalysis(tenant.Detach(ctx), task, objectKey)
+	}
+	return nil
+}
+
+// removeStrayObject переносит объект без задачи в quarantine/ или удаляет его
+func (s *StoringService) removeStrayObject(ctx context.Context, objectKey, contentType string) error {
+	if s.reconciliation.StrayAction == config.StrayActionQuarantine {
+		obj, err := s.blobs.Get(ctx, objectKey)
+		if err != nil {
+			return err
+		}
+		_, err = s.blobs.Put(ctx, quarantinePrefix+objectKey, obj, contentType)
+		obj.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := s.blobs.Delete(ctx, objectKey); err != nil {
+		return err
+	}
+
+	s.logger.Info("stray object removed",
+		zap.String("object_key", objectKey),
+		zap.String("action", s.reconciliation.StrayAction))
+	return nil
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"storing-service/internal/infrastucture/dto"
+	"storing-service/pkg/tenant"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// retentionBatchSize - максимум задач одной политики за запуск, остаток обрабатывается следующим запуском
+const retentionBatchSize = 500
+
+// retentionPolicy - срок хранения задач курса; пустой courseId - политика по умолчанию
+// для всех курсов тенанта без собственной политики
+type retentionPolicy struct {
+	courseId string
+	period   time.Duration
+}
+
+// RunRetention находит задачи тенанта запроса с истекшим сроком хранения и окончательно удаляет их
+// вместе с отчетами. В режиме dryRun задачи только перечисляются. Каждый запуск сохраняется в журнал retention_runs.
+func (s *StoringService) RunRetention(ctx context.Context, dryRun bool) (*domain.RetentionRun, error) {
+	tenantId := tenant.FromContext(ctx)
+	s.logger.Info("starting retention run",
+		zap.String("tenant_id", tenantId),
+		zap.Bool("dry_run", dryRun))
+
+	if !s.retentionMu.TryLock() {
+		s.logger.Warn("retention run is already in progress")
+		return nil, fmt.Errorf("retention run is already in progress: %w", errdefs.ErrAlreadyExists)
+	}
+	defer s.retentionMu.Unlock()
+
+	runId, err := uuid.NewV7()
+	if err != nil {
+		s.logger.Error("failed to generate retention run UUID", zap.Error(err))
+		return nil, err
+	}
+
+	run := &domain.RetentionRun{
+		Id:        runId,
+		DryRun:    dryRun,
+		StartedAt: time.Now(),
+	}
+
+	for _, policy := range s.retentionPolicies(tenantId) {
+		if err := s.applyRetentionPolicy(ctx, run, policy); err != nil {
+			return nil, err
+		}
+	}
+	run.FinishedAt = time.Now()
+
+	// Журнал сохраняется и при отмене запроса: удаление уже выполнено
+	if err := s.repo.CreateRetentionRun(context.WithoutCancel(ctx), run); err != nil {
+		s.logger.Error("failed to save retention run",
+			zap.String("run_id", runId.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("retention run finished",
+		zap.String("run_id", runId.String()),
+		zap.String("tenant_id", tenantId),
+		zap.Bool("dry_run", dryRun),
+		zap.Int("expired", run.Expired),
+		zap.Int("purged", run.Purged),
+		zap.Int("failed", run.Failed))
+	return run, nil
+}
+
+// RunRetentionWorker запускает политику хранения во всех тенантах раз в RETENTION_INTERVAL.
+// Если ни одна политика не задана, воркер сразу завершается.
+func (s *StoringService) RunRetentionWorker(ctx context.Context) {
+	if !s.retentionConfigured() {
+		s.logger.Info("no retention policies configured, retention worker disabled")
+		return
+	}
+
+	interval := s.retention.Interval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	s.logger.Info("retention worker started",
+		zap.Duration("interval", interval),
+		zap.Duration("default_period", s.retention.DefaultPeriod),
+		zap.Int("course_policies", len(s.retention.CoursePeriods)),
+		zap.Int("tenant_policies", len(s.retention.TenantPeriods)),
+		zap.Bool("dry_run", s.retention.DryRun))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.forEachTenant(ctx, func(ctx context.Context) {
+			if _, err := s.RunRetention(ctx, s.retention.DryRun); err != nil {
+				s.logger.Error("scheduled retention run failed",
+					zap.String("tenant_id", tenant.FromContext(ctx)),
+					zap.Error(err))
+			}
+		})
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			s.logger.Info("retention worker stopped")
+			return
+		}
+	}
+}
+
+// retentionPolicies возвращает действующие политики тенанта: курсы с ненулевым сроком и политику
+// по умолчанию - срок тенанта из TENANTS_FILE или RETENTION_DEFAULT_PERIOD
+func (s *StoringService) retentionPolicies(tenantId string) []retentionPolicy {
+	var policies []retentionPolicy
+	for _, courseId := range slices.Sorted(maps.Keys(s.retention.CoursePeriods)) {
+		if period := s.retention.CoursePeriods[courseId]; period > 0 {
+			policies = append(policies, retentionPolicy{courseId: courseId, period: period})
+		}
+	}
+
+	defaultPeriod := s.retention.DefaultPeriod
+	if period, ok := s.retention.TenantPeriods[tenantId]; ok {
+		defaultPeriod = period
+	}
+	if defaultPeriod > 0 {
+		policies = append(policies, retentionPolicy{period: defaultPeriod})
+	}
+	return policies
+}
+
+// retentionConfigured сообщает, что хотя бы у одного тенанта есть политика хранения
+func (s *StoringService) retentionConfigured() bool {
+	if len(s.retentionPolicies(tenant.Default)) > 0 {
+		return true
+	}
+	for _, period := range s.retention.TenantPeriods {
+		if period > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *StoringService) applyRetentionPolicy(ctx context.Context, run *domain.RetentionRun, policy retentionPolicy) error {
+	listDto := &dto.ListExpiredTasksDTO{
+		CourseId:      policy.courseId,
+		CreatedBefore: run.StartedAt.Add(-policy.period),
+		Limit:         retentionBatchSize,
+	}
+	if policy.courseId == "" {
+		// Курсы с собственной политикой (в том числе бессрочной) не подпадают под политику по умолчанию
+		listDto.ExcludeCourses = slices.Collect(maps.Keys(s.retention.CoursePeriods))
+	}
+
+	tasks, err := s.repo.ListExpiredTasks(ctx, listDto)
+	if err != nil {
+		s.logger.Error("failed to list expired tasks",
+			zap.String("course_id", policy.courseId),
+			zap.Error(err))
+		return err
+	}
+
+	s.logger.Debug("expired tasks found",
+		zap.String("course_id", policy.courseId),
+		zap.Duration("period", policy.period),
+		zap.Int("count", len(tasks)))
+
+	for _, task := range tasks {
+		item := domain.RetentionItem{
+			TaskId:        task.Id,
+			CourseId:      task.CourseId,
+			TaskCreatedAt: task.CreatedAt,
+			RetainUntil:   task.CreatedAt.Add(policy.period),
+		}
+		run.Expired++
+
+		if !run.DryRun {
+			if err := s.expireTask(ctx, task); err != nil {
+				s.logger.Error("failed to purge expired task",
+					zap.String("task_id", task.Id.String()),
+					zap.Error(err))
+				item.Error = err.Error()
+				run.Failed++
+			} else {
+				run.Purged++
+			}
+		}
+
+		run.Items = append(run.Items, item)
+	}
+
+	return nil
+}
+
+// expireTask удаляет задачу без grace-периода: срок хранения уже истек
+func (s *StoringService) expireTask(ctx context.Context, task *domain.TaskMetadata) error {
+	err := s.repo.SoftDeleteTask(ctx, &dto.SoftDeleteTaskDTO{
+		Id:        task.Id,
+		DeletedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.purgeTask(ctx, task, domain.TaskEventDeleted)
+}
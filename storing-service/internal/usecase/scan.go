@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+
+	"go.uber.org/zap"
+)
+
+// Scanner проверяет файл на вредоносное содержимое: ClamAV (clamd) или заглушка для тестов
+type Scanner interface {
+	Scan(ctx context.Context, content io.Reader) (*domain.ScanResult, error)
+}
+
+// scanUpload проверяет файл, прошедший проверку загрузки, сканером. Зараженный файл переносится
+// в карантин, возвращается причина отказа. При ошибке сканера задача остается pending, и сверка
+// с хранилищем проверит файл повторно.
+func (s *StoringService) scanUpload(ctx context.Context, task *domain.TaskMetadata, objectKey string) (string, error) {
+	if s.scanner == nil {
+		return "", nil
+	}
+
+	obj, err := s.blobs.Get(ctx, objectKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to get file from storage: %w", errdefs.ErrUnavailable)
+	}
+	result, err := s.scanner.Scan(ctx, obj)
+	obj.Close()
+	if err != nil {
+		s.logger.Error("failed to scan uploaded file",
+			zap.String("task_id", task.Id.String()),
+			zap.String("object_key", objectKey),
+			zap.Error(err))
+		return "", err
+	}
+
+	if !result.Infected {
+		s.logger.Debug("uploaded file is clean", zap.String("task_id", task.Id.String()))
+		return "", nil
+	}
+
+	s.logger.Warn("malware detected in uploaded file",
+		zap.String("task_id", task.Id.String()),
+		zap.String("object_key", objectKey),
+		zap.String("signature", result.Signature))
+
+	if err := s.quarantineObject(ctx, objectKey); err != nil {
+		s.logger.Error("failed to quarantine infected file",
+			zap.String("task_id", task.Id.String()),
+			zap.String("object_key", objectKey),
+			zap.Error(err))
+		return "", err
+	}
+
+	return fmt.Sprintf("malware detected: %s", result.Signature), nil
+}
+
+// quarantineObject переносит объект в хранилище карантина под тем же ключом
+func (s *StoringService) quarantineObject(ctx context.Context, objectKey string) error {
+	obj, err := s.blobs.Get(ctx, objectKey)
+	if err != nil {
+		return err
+	}
+	_, err = s.quarantine.Put(ctx, objectKey, obj, mime.TypeByExtension(path.Ext(objectKey)))
+	obj.Close()
+	if err != nil {
+		return err
+	}
+
+	if err := s.blobs.Delete(ctx, objectKey); err != nil {
+		return err
+	}
+
+	s.logger.Info("infected file quarantined", zap.String("object_key", objectKey))
+	return nil
+}
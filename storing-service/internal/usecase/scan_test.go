@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"storing-service/internal/config"
+	"storing-service/internal/errdefs"
+	"storing-service/internal/infrastucture/envelope"
+	"storing-service/internal/infrastucture/fsstore"
+	"storing-service/internal/infrastucture/urlsign"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// newEncryptedStore создает хранилище в каталоге root с шифрованием в режиме client
+func newEncryptedStore(t *testing.T, root string, signer *urlsign.Signer) *envelope.Store {
+	t.Helper()
+
+	backend, err := fsstore.NewStore(&config.StorageConfig{FSRoot: root}, signer)
+	if err != nil {
+		t.Fatalf("fsstore.NewStore: %v", err)
+	}
+	store, err := envelope.NewStore(backend, &config.EncryptionConfig{
+		Mode:        config.EncryptionClient,
+		Keys:        map[string][]byte{"k1": bytes.Repeat([]byte{7}, 32)},
+		ActiveKeyId: "k1",
+	}, signer)
+	if err != nil {
+		t.Fatalf("envelope.NewStore: %v", err)
+	}
+	return store
+}
+
+func TestQuarantineObjectKeepsFileEncrypted(t *testing.T) {
+	signer, err := urlsign.NewSigner("http://localhost:8080", "0123456789abcdef0123456789abcdef")
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	blobs := newEncryptedStore(t, t.TempDir(), signer)
+	quarantineRoot := t.TempDir()
+	quarantine := newEncryptedStore(t, quarantineRoot, signer)
+	svc := NewStoringService(nil, blobs, nil, nil, quarantine, &config.UploadConfig{}, nil, nil, nil, nil, zap.NewNop())
+
+	ctx := context.Background()
+	objectKey := "cs101/hw1/infected.txt"
+	content := []byte(strings.Repeat("X5O!P%@AP[4\\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*", 4))
+	if _, err := blobs.Put(ctx, objectKey, bytes.NewReader(content), "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if err := svc.quarantineObject(ctx, objectKey); err != nil {
+		t.Fatalf("quarantineObject: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(quarantineRoot, filepath.FromSlash(objectKey)))
+	if err != nil {
+		t.Fatalf("read quarantined file: %v", err)
+	}
+	if bytes.Contains(raw, content[:32]) {
+		t.Fatal("quarantined file is stored in plaintext")
+	}
+
+	obj, err := quarantine.Get(ctx, objectKey)
+	if err != nil {
+		t.Fatalf("quarantine Get: %v", err)
+	}
+	defer obj.Close()
+	got, err := io.ReadAll(obj)
+	if err != nil || !bytes.Equal(got, content) {
+		t.Fatalf("quarantined content = %q, %v", got, err)
+	}
+
+	if _, err := blobs.Stat(ctx, objectKey); !errors.Is(err, errdefs.ErrNotFound) {
+		t.Fatalf("original object: err = %v, want ErrNotFound", err)
+	}
+}
@@ -2,51 +2,119 @@ package usecase
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"net/url"
 	"path"
+	"storing-service/internal/config"
 	"storing-service/internal/domain"
 	"storing-service/internal/errdefs"
 	"storing-service/internal/infrastucture/dto"
-	minio1 "storing-service/internal/infrastucture/minio"
+	"storing-service/pkg/tenant"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/minio/minio-go/v7"
 	"go.uber.org/zap"
 )
 
 type StoringRepository interface {
 	CreateTask(ctx context.Context, dto *dto.CreateTaskDTO) (*domain.TaskMetadata, error)
 	GetTask(ctx context.Context, dto *dto.GetTaskDTO) (*domain.TaskMetadata, error)
+	UpdateTaskStatus(ctx context.Context, dto *dto.UpdateTaskStatusDTO) error
+	FindDuplicateTask(ctx context.Context, dto *dto.FindDuplicateTaskDTO) (*domain.TaskMetadata, error)
+	ListTasks(ctx context.Context, dto *dto.ListTasksDTO) ([]*domain.TaskMetadata, error)
+	GetSubmission(ctx context.Context, dto *dto.GetSubmissionDTO) (*domain.Submission, error)
+	ListSubmissionTasks(ctx context.Context, dto *dto.ListSubmissionTasksDTO) ([]*domain.TaskMetadata, error)
+	SoftDeleteTask(ctx context.Context, dto *dto.SoftDeleteTaskDTO) error
+	ListUserTasks(ctx context.Context, dto *dto.ListUserTasksDTO) ([]*domain.TaskMetadata, error)
+	ListPurgeableTasks(ctx context.Context, dto *dto.ListPurgeableTasksDTO) ([]*domain.TaskMetadata, error)
+	PurgeTask(ctx context.Context, dto *dto.PurgeTaskDTO) error
+	ListPendingEvents(ctx context.Context, dto *dto.ListPendingEventsDTO) ([]*domain.TaskEvent, error)
+	ListTenants(ctx context.Context) ([]string, error)
+	MarkEvent(ctx context.Context, dto *dto.MarkEventDTO) error
+	ListExpiredTasks(ctx context.Context, dto *dto.ListExpiredTasksDTO) ([]*domain.TaskMetadata, error)
+	CreateRetentionRun(ctx context.Context, run *domain.RetentionRun) error
+	ListTaskObjects(ctx context.Context, dto *dto.ListTaskObjectsDTO) ([]*domain.TaskObject, error)
+	ExpireUpload(ctx context.Context, dto *dto.ExpireUploadDTO) error
+	GetUserUsage(ctx context.Context, dto *dto.GetUserUsageDTO) (*domain.UserUsage, error)
+	GetAssignmentUsage(ctx context.Context, dto *dto.GetAssignmentUsageDTO) (*domain.AssignmentUsage, error)
 }
 
 type AnalysisClient interface {
-	AnalyseTask(ctx context.Context, taskId, objectKey string) (bool, error)
+	AnalyseTask(ctx context.Context, taskId, objectKey, assignmentId, uploadedBy, courseId, duplicateOf, duplicateOfKey string, excludeTaskIds []string) (bool, error)
+	PurgeTask(ctx context.Context, taskId string, anonymiseReferences bool) error
+}
+
+// BlobStore - хранилище файлов задач: MinIO/S3 или локальная файловая система.
+// Stat и Get возвращают errdefs.ErrNotFound, если объекта нет.
+type BlobStore interface {
+	Put(ctx context.Context, objectKey string, reader io.Reader, contentType string) (int64, error)
+	Get(ctx context.Context, objectKey string) (io.ReadCloser, error)
+	Stat(ctx context.Context, objectKey string) (*domain.BlobInfo, error)
+	List(ctx context.Context, prefix string) ([]domain.BlobInfo, error)
+	Delete(ctx context.Context, objectKey string) error
+	PresignGet(ctx context.Context, objectKey string, expiry time.Duration) (*url.URL, error)
+	// PresignPost возвращает ссылку и поля формы для загрузки файла заданного размера и SHA-256
+	PresignPost(ctx context.Context, objectKey string, size int64, sha256 []byte, expiry time.Duration) (*url.URL, map[string]string, error)
 }
 
 type StoringService struct {
 	repo           StoringRepository
-	minio          *minio1.Client
-	bucket         string
+	blobs          BlobStore
 	analysisClient AnalysisClient
-	logger         *zap.Logger
+	// nil, если проверка на вредоносное содержимое выключена
+	scanner        Scanner
+	quarantine     BlobStore
+	cfg            *config.UploadConfig
+	deletion       *config.DeletionConfig
+	retention      *config.RetentionConfig
+	reconciliation *config.ReconciliationConfig
+	quota          *config.QuotaConfig
+	// Одновременно выполняется только один запуск политики хранения
+	retentionMu sync.Mutex
+	// Одновременно выполняется только одна ротация ключей шифрования
+	rotationMu sync.Mutex
+	// Одновременно выполняется только одна сверка с хранилищем
+	reconciliationMu sync.Mutex
+	logger           *zap.Logger
 }
 
-func NewStoringService(repo StoringRepository, minio *minio1.Client, bucket string, analysisClient AnalysisClient, logger *zap.Logger) *StoringService {
+func NewStoringService(repo StoringRepository, blobs BlobStore, analysisClient AnalysisClient, scanner Scanner, quarantine BlobStore, cfg *config.UploadConfig, deletion *config.DeletionConfig, retention *config.RetentionConfig, reconciliation *config.ReconciliationConfig, quota *config.QuotaConfig, logger *zap.Logger) *StoringService {
 	return &StoringService{
 		repo:           repo,
-		minio:          minio,
-		bucket:         bucket,
+		blobs:          blobs,
 		analysisClient: analysisClient,
+		scanner:        scanner,
+		quarantine:     quarantine,
+		cfg:            cfg,
+		deletion:       deletion,
+		retention:      retention,
+		reconciliation: reconciliation,
+		quota:          quota,
 		logger:         logger,
 	}
 }
 
-func (s *StoringService) UploadTask(ctx context.Context, filename string, uploadedBy uuid.UUID) (*domain.Task, error) {
+func (s *StoringService) UploadTask(ctx context.Context, filename string, uploadedBy uuid.UUID, courseId, assignmentId string, size int64, sha256Hex string) (*domain.Task, error) {
 	s.logger.Info("starting upload task",
 		zap.String("filename", filename),
-		zap.String("uploaded_by", uploadedBy.String()))
+		zap.String("uploaded_by", uploadedBy.String()),
+		zap.String("course_id", courseId),
+		zap.String("assignment_id", assignmentId),
+		zap.Int64("size", size))
+
+	if _, err := s.validateUpload(ctx, filename, size, sha256Hex, true); err != nil {
+		return nil, err
+	}
+	if err := s.checkQuota(ctx, uploadedBy, assignmentId, size); err != nil {
+		return nil, err
+	}
+	checksum, _ := hex.DecodeString(sha256Hex)
 
 	id, err := uuid.NewV7()
 	if err != nil {
@@ -54,38 +122,35 @@ func (s *StoringService) UploadTask(ctx context.Context, filename string, upload
 		return nil, fmt.Errorf("failed to generate uuid: %w", errdefs.ErrInvalidArgument)
 	}
 
-	extension := path.Ext(filename)
-	if extension == "" {
-		s.logger.Warn("invalid file extension", zap.String("filename", filename))
-		return nil, fmt.Errorf("invalid file extension: %w", errdefs.ErrInvalidArgument)
-	}
-
+	objectKey := s.objectKey(ctx, id, filename, courseId, assignmentId)
 	dto := &dto.CreateTaskDTO{
-		Id:         id,
-		FileName:   filename,
-		UploadedBy: uploadedBy,
-		CreatedAt:  time.Now(),
+		Id:             id,
+		FileName:       filename,
+		ObjectKey:      objectKey,
+		UploadedBy:     uploadedBy,
+		CourseId:       courseId,
+		AssignmentId:   assignmentId,
+		ExpectedSize:   size,
+		ExpectedSha256: strings.ToLower(sha256Hex),
+		CreatedAt:      time.Now(),
+		Quota:          s.taskQuota(),
 	}
 
 	s.logger.Debug("creating task in database", zap.String("task_id", id.String()))
 	metaData, err := s.repo.CreateTask(ctx, dto)
 	if err != nil {
-		s.logger.Error("failed to create task in database",
-			zap.String("task_id", id.String()),
-			zap.Error(err))
+		s.logCreateTaskError(id, err)
 		return nil, err
 	}
 
 	s.logger.Debug("task created in database", zap.String("task_id", id.String()))
 
-	objectKey := fmt.Sprintf("%s%s", id.String(), extension)
-	s.logger.Debug("generating presigned upload URL",
-		zap.String("object_key", objectKey),
-		zap.String("bucket", s.bucket))
+	s.logger.Debug("generating presigned upload policy",
+		zap.String("object_key", objectKey))
 
-	uploadUrl, err := s.minio.PresignedPutObject(ctx, objectKey, time.Hour)
+	uploadUrl, formData, err := s.blobs.PresignPost(ctx, objectKey, size, checksum, time.Hour)
 	if err != nil {
-		s.logger.Error("failed to generate upload URL",
+		s.logger.Error("failed to generate upload policy",
 			zap.String("object_key", objectKey),
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to generate upload url: %w", errdefs.ErrUnavailable)
@@ -94,21 +159,154 @@ func (s *StoringService) UploadTask(ctx context.Context, filename string, upload
 	s.logger.Info("starting async analysis",
 		zap.String("task_id", id.String()),
 		zap.String("object_key", objectKey))
-	go s.startAnalysisAsync(context.Background(), id.String(), objectKey, metaData.Filename)
+	go s.startAnalysisAsync(tenant.Detach(ctx), metaData, objectKey)
 
 	s.logger.Info("upload task completed",
 		zap.String("task_id", id.String()),
 		zap.String("filename", filename))
 
 	return &domain.Task{
-		Id:         metaData.Id,
-		Filename:   metaData.Filename,
-		Url:        uploadUrl.String(),
-		UploadedBy: metaData.UploadedBy,
-		CreatedAt:  metaData.CreatedAt,
+		Id:             metaData.Id,
+		Filename:       metaData.Filename,
+		Url:            uploadUrl.String(),
+		UploadForm:     formData,
+		UploadedBy:     metaData.UploadedBy,
+		CourseId:       metaData.CourseId,
+		AssignmentId:   metaData.AssignmentId,
+		Status:         metaData.Status,
+		ExpectedSize:   metaData.ExpectedSize,
+		ExpectedSha256: metaData.ExpectedSha256,
+		ObjectKey:      objectKey,
+		SubmissionId:   metaData.SubmissionId,
+		Version:        metaData.Version,
+		CreatedAt:      metaData.CreatedAt,
 	}, nil
 }
 
+// UploadTaskContent сохраняет файл, переданный потоком через сервис, минуя presigned URL.
+// Объект записывается в хранилище частями, по пути считаются размер и SHA-256 и определяется тип содержимого.
+// Если проверка не пройдена, задача сохраняется со статусом rejected и анализ не запускается.
+func (s *StoringService) UploadTaskContent(ctx context.Context, filename string, uploadedBy uuid.UUID, courseId, assignmentId string, size int64, sha256Hex string, content io.Reader) (*domain.Task, int64, error) {
+	s.logger.Info("starting streamed upload",
+		zap.String("filename", filename),
+		zap.String("uploaded_by", uploadedBy.String()),
+		zap.String("assignment_id", assignmentId))
+
+	metaData, objectKey, size, err := s.storeTaskContent(ctx, filename, uploadedBy, courseId, assignmentId, size, sha256Hex, content)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if metaData.Status == domain.TaskVerified {
+		s.logger.Info("starting async analysis",
+			zap.String("task_id", metaData.Id.String()),
+			zap.String("object_key", objectKey))
+		go s.startAnalysisAsync(tenant.Detach(ctx), metaData, objectKey)
+	}
+
+	s.logger.Info("streamed upload completed",
+		zap.String("task_id", metaData.Id.String()),
+		zap.Int64("size", size),
+		zap.String("status", string(metaData.Status)))
+
+	return &domain.Task{
+		Id:             metaData.Id,
+		Filename:       metaData.Filename,
+		UploadedBy:     metaData.UploadedBy,
+		CourseId:       metaData.CourseId,
+		AssignmentId:   metaData.AssignmentId,
+		Status:         metaData.Status,
+		StatusReason:   metaData.StatusReason,
+		ExpectedSize:   metaData.ExpectedSize,
+		ExpectedSha256: metaData.ExpectedSha256,
+		ContentType:    metaData.ContentType,
+		ObjectKey:      objectKey,
+		SubmissionId:   metaData.SubmissionId,
+		Version:        metaData.Version,
+		CreatedAt:      metaData.CreatedAt,
+	}, size, nil
+}
+
+// storeTaskContent записывает файл в хранилище, создает задачу и сохраняет результат проверки.
+// Анализ не запускается. Возвращает задачу, ключ объекта и фактический размер файла.
+func (s *StoringService) storeTaskContent(ctx context.Context, filename string, uploadedBy uuid.UUID, courseId, assignmentId string, size int64, sha256Hex string, content io.Reader) (*domain.TaskMetadata, string, int64, error) {
+	extension, err := s.validateUpload(ctx, filename, size, sha256Hex, false)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	if err := s.checkQuota(ctx, uploadedBy, assignmentId, size); err != nil {
+		return nil, "", 0, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		s.logger.Error("failed to generate UUID", zap.Error(err))
+		return nil, "", 0, fmt.Errorf("failed to generate uuid: %w", errdefs.ErrInvalidArgument)
+	}
+
+	objectKey := s.objectKey(ctx, id, filename, courseId, assignmentId)
+	s.logger.Debug("streaming file to storage",
+		zap.String("object_key", objectKey))
+
+	// Лимит на байт больше максимума: превышение обнаруживается проверкой, а не обрезает файл молча
+	verifier := newUploadVerifier()
+	reader := io.TeeReader(io.LimitReader(content, s.cfg.MaxSize+1), verifier)
+
+	stored, err := s.blobs.Put(ctx, objectKey, reader, mime.TypeByExtension(extension))
+	if err != nil {
+		s.logger.Error("failed to stream file to storage",
+			zap.String("object_key", objectKey),
+			zap.Error(err))
+		if ctx.Err() != nil {
+			return nil, "", 0, ctx.Err()
+		}
+		return nil, "", 0, fmt.Errorf("failed to upload file: %w", errdefs.ErrUnavailable)
+	}
+
+	s.logger.Debug("file stored",
+		zap.String("object_key", objectKey),
+		zap.Int64("size", stored))
+
+	dto := &dto.CreateTaskDTO{
+		Id:             id,
+		FileName:       filename,
+		ObjectKey:      objectKey,
+		UploadedBy:     uploadedBy,
+		CourseId:       courseId,
+		AssignmentId:   assignmentId,
+		ExpectedSize:   size,
+		ExpectedSha256: strings.ToLower(sha256Hex),
+		ContentSize:    stored,
+		CreatedAt:      time.Now(),
+		Quota:          s.taskQuota(),
+	}
+
+	// Квоты проверяются еще раз вместе с созданием задачи, в том числе по фактическому размеру,
+	// если он не был заявлен; файл сверх квоты удаляется
+	metaData, err := s.repo.CreateTask(ctx, dto)
+	if err != nil {
+		s.logCreateTaskError(id, err)
+		if rmErr := s.blobs.Delete(context.WithoutCancel(ctx), objectKey); rmErr != nil {
+			s.logger.Warn("failed to remove orphaned object",
+				zap.String("object_key", objectKey),
+				zap.Error(rmErr))
+		}
+		return nil, "", 0, err
+	}
+
+	contentType, reason := s.checkUpload(ctx, verifier, extension, size, sha256Hex)
+	if reason == "" {
+		if reason, err = s.scanUpload(ctx, metaData, objectKey); err != nil {
+			return nil, "", 0, err
+		}
+	}
+	if _, err := s.saveVerification(ctx, metaData, verifier, contentType, reason); err != nil {
+		return nil, "", 0, err
+	}
+
+	return metaData, objectKey, stored, nil
+}
+
 func (s *StoringService) GetTask(ctx context.Context, fileId uuid.UUID) (*domain.Task, error) {
 	s.logger.Info("getting task", zap.String("file_id", fileId.String()))
 
@@ -125,14 +323,12 @@ func (s *StoringService) GetTask(ctx context.Context, fileId uuid.UUID) (*domain
 		return nil, err
 	}
 
-	extension := path.Ext(metaData.Filename)
-	objectKey := fmt.Sprintf("%s%s", fileId.String(), extension)
+	objectKey := metaData.ObjectKey
 
 	s.logger.Debug("generating presigned download URL",
-		zap.String("object_key", objectKey),
-		zap.String("bucket", s.bucket))
+		zap.String("object_key", objectKey))
 
-	downloadUrl, err := s.minio.PresignedGetObject(ctx, objectKey, time.Hour, nil)
+	downloadUrl, err := s.blobs.PresignGet(ctx, objectKey, time.Hour)
 	if err != nil {
 		s.logger.Error("failed to generate download URL",
 			zap.String("object_key", objectKey),
@@ -143,11 +339,22 @@ func (s *StoringService) GetTask(ctx context.Context, fileId uuid.UUID) (*domain
 	s.logger.Info("get task completed", zap.String("file_id", fileId.String()))
 
 	return &domain.Task{
-		Id:         metaData.Id,
-		Filename:   metaData.Filename,
-		Url:        downloadUrl.String(),
-		UploadedBy: metaData.UploadedBy,
-		CreatedAt:  metaData.CreatedAt,
+		Id:             metaData.Id,
+		Filename:       metaData.Filename,
+		Url:            downloadUrl.String(),
+		UploadedBy:     metaData.UploadedBy,
+		CourseId:       metaData.CourseId,
+		AssignmentId:   metaData.AssignmentId,
+		Status:         metaData.Status,
+		StatusReason:   metaData.StatusReason,
+		ExpectedSize:   metaData.ExpectedSize,
+		ExpectedSha256: metaData.ExpectedSha256,
+		ContentSha256:  metaData.ContentSha256,
+		ContentType:    metaData.ContentType,
+		ObjectKey:      objectKey,
+		SubmissionId:   metaData.SubmissionId,
+		Version:        metaData.Version,
+		CreatedAt:      metaData.CreatedAt,
 	}, nil
 }
 
@@ -167,16 +374,14 @@ func (s *StoringService) GetFileContent(ctx context.Context, fileId uuid.UUID) (
 		return nil, err
 	}
 
-	extension := path.Ext(metaData.Filename)
-	objectKey := fmt.Sprintf("%s%s", fileId.String(), extension)
+	objectKey := metaData.ObjectKey
 
-	s.logger.Debug("fetching file from MinIO",
-		zap.String("object_key", objectKey),
-		zap.String("bucket", s.bucket))
+	s.logger.Debug("fetching file from storage",
+		zap.String("object_key", objectKey))
 
-	obj, err := s.minio.GetObject(ctx, objectKey, minio.GetObjectOptions{})
+	obj, err := s.blobs.Get(ctx, objectKey)
 	if err != nil {
-		s.logger.Error("failed to get file from MinIO",
+		s.logger.Error("failed to get file from storage",
 			zap.String("object_key", objectKey),
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to get file from storage: %w", errdefs.ErrUnavailable)
@@ -198,7 +403,10 @@ func (s *StoringService) GetFileContent(ctx context.Context, fileId uuid.UUID) (
 	return content, nil
 }
 
-func (s *StoringService) startAnalysisAsync(ctx context.Context, taskId, objectKey, filename string) {
+// startAnalysisAsync ждет появления файла в хранилище, проверяет его (если задача еще не проверена)
+// и запускает анализ проверенного файла
+func (s *StoringService) startAnalysisAsync(ctx context.Context, task *domain.TaskMetadata, objectKey string) {
+	taskId := task.Id.String()
 	maxRetries := 30
 	retryInterval := 2 * time.Second
 	timeout := 5 * time.Minute
@@ -227,26 +435,28 @@ func (s *StoringService) startAnalysisAsync(ctx context.Context, taskId, objectK
 		}
 
 		if exists {
+			if task.Status == domain.TaskPending {
+				status, err := s.verifyStoredObject(ctx, task, objectKey)
+				if err != nil {
+					s.logger.Error("failed to verify uploaded file",
+						zap.String("task_id", taskId),
+						zap.String("object_key", objectKey),
+						zap.Error(err))
+					return
+				}
+				if status != domain.TaskVerified {
+					s.logger.Warn("uploaded file rejected, skipping analysis",
+						zap.String("task_id", taskId),
+						zap.String("reason", task.StatusReason))
+					return
+				}
+			}
+
 			s.logger.Info("file uploaded, starting analysis",
 				zap.String("task_id", taskId),
 				zap.String("object_key", objectKey))
 
-			status, err := s.analysisClient.AnalyseTask(ctx, taskId, objectKey)
-			if err != nil {
-				s.logger.Error("failed to start analysis",
-					zap.String("task_id", taskId),
-					zap.String("object_key", objectKey),
-					zap.Error(err))
-				return
-			}
-
-			if status {
-				s.logger.Info("analysis started successfully",
-					zap.String("task_id", taskId))
-			} else {
-				s.logger.Warn("analysis returned false status",
-					zap.String("task_id", taskId))
-			}
+			s.requestAnalysis(ctx, task, objectKey)
 			return
 		}
 
@@ -259,14 +469,95 @@ func (s *StoringService) startAnalysisAsync(ctx context.Context, taskId, objectK
 		zap.Int("max_retries", maxRetries))
 }
 
+// requestAnalysis запускает анализ проверенной задачи, передавая найденный точный дубликат.
+// Предыдущие версии той же посылки исключаются из сравнения.
+func (s *StoringService) requestAnalysis(ctx context.Context, task *domain.TaskMetadata, objectKey string) {
+	taskId := task.Id.String()
+
+	input, err := s.analysisInput(ctx, task)
+	if err != nil {
+		s.logger.Error("failed to list previous versions, analysis not started",
+			zap.String("task_id", taskId),
+			zap.Error(err))
+		return
+	}
+
+	duplicateOf, duplicateOfKey := "", ""
+	if input.DuplicateOf != nil {
+		duplicateOf = input.DuplicateOf.Id.String()
+		duplicateOfKey = input.DuplicateOf.ObjectKey
+	}
+
+	status, err := s.analysisClient.AnalyseTask(ctx, taskId, objectKey, task.AssignmentId, task.UploadedBy.String(), task.CourseId, duplicateOf, duplicateOfKey, input.ExcludeTaskIds)
+	if err != nil {
+		s.logger.Error("failed to start analysis",
+			zap.String("task_id", taskId),
+			zap.String("object_key", objectKey),
+			zap.Error(err))
+		return
+	}
+
+	if status {
+		s.logger.Info("analysis started successfully",
+			zap.String("task_id", taskId))
+	} else {
+		s.logger.Warn("analysis returned false status",
+			zap.String("task_id", taskId))
+	}
+}
+
 func (s *StoringService) checkFileExists(ctx context.Context, objectKey string) (bool, error) {
-	_, err := s.minio.StatObject(ctx, objectKey, minio.StatObjectOptions{})
+	_, err := s.blobs.Stat(ctx, objectKey)
 	if err != nil {
-		errResp := minio.ToErrorResponse(err)
-		if errResp.Code == "NoSuchKey" || errResp.Code == "NotFound" {
+		if errors.Is(err, errdefs.ErrNotFound) {
 			return false, nil
 		}
 		return false, err
 	}
 	return true, nil
 }
+
+// objectKey строит ключ объекта новой задачи по схеме STORAGE_KEY_LAYOUT в каталоге тенанта запроса.
+// Ключ сохраняется в задаче, поэтому смена схемы не затрагивает уже загруженные файлы.
+func (s *StoringService) objectKey(ctx context.Context, id uuid.UUID, filename, courseId, assignmentId string) string {
+	return tenant.Prefix(tenant.FromContext(ctx)) + escapeReservedRoot(strings.NewReplacer(
+		"{course}", keySegment(courseId),
+		"{assignment}", keySegment(assignmentId),
+		"{task_id}", id.String(),
+		"{ext}", path.Ext(filename),
+	).Replace(s.cfg.KeyLayout))
+}
+
+// reservedKeyRoots - каталоги корня хранилища, которые ключ задачи не может занять: файлы тенантов
+// и объекты, перенесенные сверкой в карантин
+var reservedKeyRoots = []string{tenant.KeyRoot, quarantinePrefix}
+
+// escapeReservedRoot добавляет '_' к первому сегменту ключа, совпадающему с зарезервированным каталогом,
+// иначе course_id "tenants" или "quarantine" поместил бы файл к другому тенанту или в карантин
+func escapeReservedRoot(key string) string {
+	first, _, _ := strings.Cut(key, "/")
+	for _, root := range reservedKeyRoots {
+		if strings.EqualFold(first+"/", root) {
+			return "_" + key
+		}
+	}
+	return key
+}
+
+// keySegment делает значение безопасным для части ключа: символы кроме латиницы, цифр, '.', '_' и '-'
+// заменяются на '_', пустое значение и значение с точкой в начале не допускаются
+func keySegment(value string) string {
+	if value == "" {
+		return "_"
+	}
+	segment := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-' {
+			return r
+		}
+		return '_'
+	}, value)
+	if strings.HasPrefix(segment, ".") {
+		segment = "_" + segment[1:]
+	}
+	return segment
+}
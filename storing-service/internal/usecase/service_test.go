@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"storing-service/internal/config"
+	"storing-service/pkg/tenant"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestObjectKeyEscapesReservedRoots(t *testing.T) {
+	id := uuid.MustParse("0190a0d4-8a2f-7c3e-9b1a-2f4c5d6e7f80")
+	tests := []struct {
+		name     string
+		layout   string
+		tenant   string
+		courseId string
+		want     string
+	}{
+		{name: "regular course", layout: "{course}/{assignment}/{task_id}{ext}", tenant: tenant.Default, courseId: "cs101",
+			want: "cs101/hw1/0190a0d4-8a2f-7c3e-9b1a-2f4c5d6e7f80.go"},
+		{name: "course named tenants", layout: "{course}/{assignment}/{task_id}{ext}", tenant: tenant.Default, courseId: "tenants",
+			want: "_tenants/hw1/0190a0d4-8a2f-7c3e-9b1a-2f4c5d6e7f80.go"},
+		{name: "course named quarantine", layout: "{course}/{assignment}/{task_id}{ext}", tenant: tenant.Default, courseId: "Quarantine",
+			want: "_Quarantine/hw1/0190a0d4-8a2f-7c3e-9b1a-2f4c5d6e7f80.go"},
+		{name: "reserved course in tenant", layout: "{course}/{assignment}/{task_id}{ext}", tenant: "uni", courseId: "tenants",
+			want: "tenants/uni/_tenants/hw1/0190a0d4-8a2f-7c3e-9b1a-2f4c5d6e7f80.go"},
+		{name: "reserved literal in layout", layout: "quarantine/{task_id}{ext}", tenant: tenant.Default, courseId: "cs101",
+			want: "_quarantine/0190a0d4-8a2f-7c3e-9b1a-2f4c5d6e7f80.go"},
+		{name: "reserved name deeper in key", layout: "{assignment}/{course}/{task_id}{ext}", tenant: tenant.Default, courseId: "tenants",
+			want: "hw1/tenants/0190a0d4-8a2f-7c3e-9b1a-2f4c5d6e7f80.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &StoringService{cfg: &config.UploadConfig{KeyLayout: tt.layout}}
+			ctx := tenant.WithTenant(context.Background(), tt.tenant)
+
+			got := svc.objectKey(ctx, id, "main.go", tt.courseId, "hw1")
+			if got != tt.want {
+				t.Errorf("objectKey() = %q, want %q", got, tt.want)
+			}
+			if !tenant.OwnsKey(tt.tenant, got) {
+				t.Errorf("key %q is outside tenant %q", got, tt.tenant)
+			}
+			if strings.HasPrefix(got, quarantinePrefix) {
+				t.Errorf("key %q is inside the quarantine", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"storing-service/internal/domain"
+	"storing-service/internal/infrastucture/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GetSubmission возвращает посылку со всеми неудаленными версиями по возрастанию номера
+func (s *StoringService) GetSubmission(ctx context.Context, submissionId uuid.UUID) (*domain.Submission, error) {
+	s.logger.Info("getting submission", zap.String("submission_id", submissionId.String()))
+
+	submission, err := s.repo.GetSubmission(ctx, &dto.GetSubmissionDTO{Id: submissionId})
+	if err != nil {
+		s.logger.Error("failed to get submission",
+			zap.String("submission_id", submissionId.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	submission.Versions, err = s.repo.ListSubmissionTasks(ctx, &dto.ListSubmissionTasksDTO{SubmissionId: submissionId})
+	if err != nil {
+		s.logger.Error("failed to list submission versions",
+			zap.String("submission_id", submissionId.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("submission retrieved",
+		zap.String("submission_id", submissionId.String()),
+		zap.Int("versions", len(submission.Versions)))
+	return submission, nil
+}
+
+// previousVersionIds возвращает идентификаторы предыдущих версий посылки задачи:
+// работа не должна сравниваться с более ранними версиями того же студента
+func (s *StoringService) previousVersionIds(ctx context.Context, task *domain.TaskMetadata) ([]string, error) {
+	if task.SubmissionId == uuid.Nil {
+		return nil, nil
+	}
+
+	versions, err := s.repo.ListSubmissionTasks(ctx, &dto.ListSubmissionTasksDTO{SubmissionId: task.SubmissionId})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, version := range versions {
+		if version.Version < task.Version {
+			ids = append(ids, version.Id.String())
+		}
+	}
+	return ids, nil
+}
+
+// GetAnalysisInput возвращает параметры анализа задачи для запуска вне загрузки (вручную или повторно),
+// собранные так же, как при загрузке
+func (s *StoringService) GetAnalysisInput(ctx context.Context, fileId uuid.UUID) (*domain.AnalysisInput, error) {
+	task, err := s.repo.GetTask(ctx, &dto.GetTaskDTO{Id: fileId})
+	if err != nil {
+		s.logger.Error("failed to get task for analysis",
+			zap.String("file_id", fileId.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	input, err := s.analysisInput(ctx, task)
+	if err != nil {
+		s.logger.Error("failed to list previous versions",
+			zap.String("file_id", fileId.String()),
+			zap.Error(err))
+		return nil, err
+	}
+	return input, nil
+}
+
+// analysisInput собирает параметры анализа задачи: предыдущие версии посылки и оригинал дубликата
+func (s *StoringService) analysisInput(ctx context.Context, task *domain.TaskMetadata) (*domain.AnalysisInput, error) {
+	excludeTaskIds, err := s.previousVersionIds(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.AnalysisInput{
+		Task:           task,
+		ExcludeTaskIds: excludeTaskIds,
+		DuplicateOf:    s.findOriginalTask(ctx, task),
+	}, nil
+}
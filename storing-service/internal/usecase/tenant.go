@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"storing-service/internal/domain"
+	"storing-service/pkg/tenant"
+
+	"go.uber.org/zap"
+)
+
+// forEachTenant выполняет fn в контексте каждого тенанта: так фоновые воркеры обходят данные всех
+// тенантов, оставаясь в пределах одного тенанта на каждом проходе
+func (s *StoringService) forEachTenant(ctx context.Context, fn func(ctx context.Context)) {
+	tenants, err := s.repo.ListTenants(ctx)
+	if err != nil {
+		s.logger.Error("failed to list tenants", zap.Error(err))
+		return
+	}
+
+	for _, id := range tenants {
+		if ctx.Err() != nil {
+			return
+		}
+		fn(tenant.WithTenant(ctx, id))
+	}
+}
+
+// listTenantBlobs возвращает объекты хранилища тенанта запроса. Файлы тенанта по умолчанию лежат
+// в корне хранилища, поэтому каталоги других тенантов из его листинга исключаются.
+func (s *StoringService) listTenantBlobs(ctx context.Context) ([]domain.BlobInfo, error) {
+	tenantId := tenant.FromContext(ctx)
+	blobs, err := s.blobs.List(ctx, tenant.Prefix(tenantId))
+	if err != nil {
+		return nil, err
+	}
+
+	owned := blobs[:0]
+	for _, blob := range blobs {
+		if tenant.OwnsKey(tenantId, blob.Key) {
+			owned = append(owned, blob)
+		}
+	}
+	return owned, nil
+}
+
+// allowedTypes возвращает допустимые типы файлов тенанта запроса: собственные, если они заданы
+// в TENANTS_FILE, иначе UPLOAD_ALLOWED_TYPES
+func (s *StoringService) allowedTypes(ctx context.Context) map[string][]string {
+	if allowed, ok := s.cfg.TenantAllowedTypes[tenant.FromContext(ctx)]; ok {
+		return allowed
+	}
+	return s.cfg.AllowedTypes
+}
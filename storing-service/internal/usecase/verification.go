@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"storing-service/internal/domain"
+	"storing-service/internal/errdefs"
+	"storing-service/internal/infrastucture/dto"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// sniffLen - сколько первых байт файла использует http.DetectContentType
+const sniffLen = 512
+
+// uploadVerifier считает размер и SHA-256 проходящих через него данных
+// и сохраняет начало файла для определения типа содержимого
+type uploadVerifier struct {
+	hash hash.Hash
+	head []byte
+	size int64
+}
+
+func newUploadVerifier() *uploadVerifier {
+	return &uploadVerifier{hash: sha256.New()}
+}
+
+func (v *uploadVerifier) Write(p []byte) (int, error) {
+	if len(v.head) < sniffLen {
+		v.head = append(v.head, p[:min(len(p), sniffLen-len(v.head))]...)
+	}
+	v.size += int64(len(p))
+	return v.hash.Write(p)
+}
+
+func (v *uploadVerifier) sha256() string {
+	return hex.EncodeToString(v.hash.Sum(nil))
+}
+
+// validateUpload проверяет заявленные клиентом параметры загрузки и возвращает расширение файла.
+// Нулевой size и пустой sha256 допустимы, только если они не обязательны.
+func (s *StoringService) validateUpload(ctx context.Context, filename string, size int64, sha256Hex string, required bool) (string, error) {
+	extension := strings.ToLower(path.Ext(filename))
+	if extension == "" {
+		s.logger.Warn("invalid file extension", zap.String("filename", filename))
+		return "", fmt.Errorf("invalid file extension: %w", errdefs.ErrInvalidArgument)
+	}
+
+	if len(s.allowedTypes(ctx)[extension]) == 0 {
+		s.logger.Warn("file type is not allowed",
+			zap.String("filename", filename),
+			zap.String("extension", extension))
+		return "", fmt.Errorf("file type %s is not allowed: %w", extension, errdefs.ErrInvalidArgument)
+	}
+
+	if size > s.cfg.MaxSize {
+		s.logger.Warn("file size exceeds quota",
+			zap.Int64("size", size),
+			zap.Int64("max_size", s.cfg.MaxSize))
+		return "", fmt.Errorf("file size exceeds %d bytes: %w", s.cfg.MaxSize, errdefs.ErrQuotaExceeded)
+	}
+	if size < 0 || (required && size == 0) {
+		s.logger.Warn("invalid file size",
+			zap.Int64("size", size),
+			zap.Int64("max_size", s.cfg.MaxSize))
+		return "", fmt.Errorf("file size must be between 1 and %d bytes: %w", s.cfg.MaxSize, errdefs.ErrInvalidArgument)
+	}
+
+	if sha256Hex == "" && !required {
+		return extension, nil
+	}
+	if sum, err := hex.DecodeString(sha256Hex); err != nil || len(sum) != sha256.Size {
+		s.logger.Warn("invalid sha256", zap.String("sha256", sha256Hex))
+		return "", fmt.Errorf("sha256 must be a hex-encoded SHA-256 digest: %w", errdefs.ErrInvalidArgument)
+	}
+
+	return extension, nil
+}
+
+// checkUpload сверяет фактический файл с заявленными параметрами и списком допустимых типов.
+// Возвращает определенный тип содержимого и причину отказа (пустую, если проверка пройдена).
+func (s *StoringService) checkUpload(ctx context.Context, v *uploadVerifier, extension string, expectedSize int64, expectedSha256 string) (string, string) {
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(v.head))
+	if err != nil {
+		contentType = "application/octet-stream"
+	}
+
+	switch {
+	case v.size > s.cfg.MaxSize:
+		return contentType, fmt.Sprintf("file exceeds %d bytes", s.cfg.MaxSize)
+	case expectedSize > 0 && v.size != expectedSize:
+		return contentType, fmt.Sprintf("size mismatch: declared %d bytes, uploaded %d bytes", expectedSize, v.size)
+	case expectedSha256 != "" && !strings.EqualFold(v.sha256(), expectedSha256):
+		return contentType, "sha256 mismatch"
+	case !slices.Contains(s.allowedTypes(ctx)[extension], contentType):
+		return contentType, fmt.Sprintf("content type %s is not allowed for %s files", contentType, extension)
+	}
+
+	return contentType, ""
+}
+
+// verifyStoredObject читает загруженный объект, проверяет его и сохраняет результат в задаче
+func (s *StoringService) verifyStoredObject(ctx context.Context, task *domain.TaskMetadata, objectKey string) (domain.TaskStatus, error) {
+	s.logger.Debug("verifying uploaded object",
+		zap.String("task_id", task.Id.String()),
+		zap.String("object_key", objectKey))
+
+	obj, err := s.blobs.Get(ctx, objectKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to get file from storage: %w", errdefs.ErrUnavailable)
+	}
+	defer obj.Close()
+
+	verifier := newUploadVerifier()
+	if _, err := io.Copy(verifier, obj); err != nil {
+		return "", fmt.Errorf("failed to read file from storage: %w", errdefs.ErrUnavailable)
+	}
+
+	contentType, reason := s.checkUpload(ctx, verifier, strings.ToLower(path.Ext(task.Filename)), task.ExpectedSize, task.ExpectedSha256)
+	if reason == "" {
+		if reason, err = s.scanUpload(ctx, task, objectKey); err != nil {
+			return "", err
+		}
+	}
+	return s.saveVerification(ctx, task, verifier, contentType, reason)
+}
+
+func (s *StoringService) saveVerification(ctx context.Context, task *domain.TaskMetadata, verifier *uploadVerifier, contentType, reason string) (domain.TaskStatus, error) {
+	contentSha256 := verifier.sha256()
+	status := domain.TaskVerified
+	if reason != "" {
+		status = domain.TaskRejected
+		s.logger.Warn("upload verification failed",
+			zap.String("task_id", task.Id.String()),
+			zap.String("reason", reason))
+	}
+
+	err := s.repo.UpdateTaskStatus(ctx, &dto.UpdateTaskStatusDTO{
+		Id:            task.Id,
+		Status:        status,
+		StatusReason:  reason,
+		ContentType:   contentType,
+		ContentSha256: contentSha256,
+		ContentSize:   verifier.size,
+	})
+	if err != nil {
+		s.logger.Error("failed to save verification result",
+			zap.String("task_id", task.Id.String()),
+			zap.Error(err))
+		return "", err
+	}
+
+	task.Status = status
+	task.StatusReason = reason
+	task.ContentType = contentType
+	task.ContentSha256 = contentSha256
+
+	s.logger.Info("upload verified",
+		zap.String("task_id", task.Id.String()),
+		zap.String("status", string(status)),
+		zap.String("content_type", contentType))
+	return status, nil
+}
@@ -0,0 +1,3 @@
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS assignment_id,
+    DROP COLUMN IF EXISTS course_id;
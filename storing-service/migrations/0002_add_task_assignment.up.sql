@@ -0,0 +1,3 @@
+ALTER TABLE tasks
+    ADD COLUMN course_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN assignment_id TEXT NOT NULL DEFAULT '';
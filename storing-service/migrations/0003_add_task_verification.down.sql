@@ -0,0 +1,6 @@
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS content_type,
+    DROP COLUMN IF EXISTS expected_sha256,
+    DROP COLUMN IF EXISTS expected_size,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,9 @@
+ALTER TABLE tasks
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'pending',
+    ADD COLUMN status_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN expected_size BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN expected_sha256 TEXT NOT NULL DEFAULT '',
+    ADD COLUMN content_type TEXT NOT NULL DEFAULT '';
+
+-- Задачи, созданные до появления проверки загрузки
+UPDATE tasks SET status = 'unverified';
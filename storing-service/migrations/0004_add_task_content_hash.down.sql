@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tasks_content_sha256_idx;
+
+ALTER TABLE tasks DROP COLUMN content_sha256;
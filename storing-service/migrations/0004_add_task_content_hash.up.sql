@@ -0,0 +1,4 @@
+-- SHA-256 фактически загруженного файла, по нему ищутся побайтовые дубликаты
+ALTER TABLE tasks ADD COLUMN content_sha256 TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX tasks_content_sha256_idx ON tasks (content_sha256, created_at) WHERE content_sha256 <> '';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS tasks_filename_trgm_idx;
+DROP INDEX IF EXISTS tasks_status_created_at_idx;
+DROP INDEX IF EXISTS tasks_course_id_created_at_idx;
+DROP INDEX IF EXISTS tasks_assignment_id_created_at_idx;
+DROP INDEX IF EXISTS tasks_uploaded_by_created_at_idx;
+DROP INDEX IF EXISTS tasks_created_at_id_idx;
@@ -0,0 +1,10 @@
+-- Keyset-пагинация списка задач идет по (created_at, id), фильтры используют ведущие колонки
+CREATE INDEX tasks_created_at_id_idx ON tasks (created_at, id);
+CREATE INDEX tasks_uploaded_by_created_at_idx ON tasks (uploaded_by, created_at, id);
+CREATE INDEX tasks_assignment_id_created_at_idx ON tasks (assignment_id, created_at, id);
+CREATE INDEX tasks_course_id_created_at_idx ON tasks (course_id, created_at, id);
+CREATE INDEX tasks_status_created_at_idx ON tasks (status, created_at, id);
+
+-- Поиск по подстроке имени файла (ILIKE '%...%')
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX tasks_filename_trgm_idx ON tasks USING gin (filename gin_trgm_ops);
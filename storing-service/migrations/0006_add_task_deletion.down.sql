@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS task_events;
+
+DROP INDEX IF EXISTS tasks_deleted_at_idx;
+
+ALTER TABLE tasks DROP COLUMN deleted_at;
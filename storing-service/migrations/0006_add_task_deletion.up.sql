@@ -0,0 +1,19 @@
+-- Мягкое удаление: задача скрыта сразу, файл и метаданные удаляются после grace-периода
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX tasks_deleted_at_idx ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- События для analysis-service записываются в одной транзакции с изменением задачи
+-- и доставляются фоновым воркером до успешного ответа
+CREATE TABLE task_events
+(
+    id UUID PRIMARY KEY NOT NULL,
+    task_id UUID NOT NULL,
+    type TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX task_events_pending_idx ON task_events (created_at) WHERE delivered_at IS NULL;
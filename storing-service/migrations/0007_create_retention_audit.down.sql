@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS tasks_course_id_created_at_active_idx;
+
+DROP TABLE IF EXISTS retention_run_items;
+DROP TABLE IF EXISTS retention_runs;
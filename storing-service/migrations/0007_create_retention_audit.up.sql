@@ -0,0 +1,26 @@
+-- Журнал запусков политики хранения, в том числе пробных (dry_run)
+CREATE TABLE retention_runs
+(
+    id UUID PRIMARY KEY NOT NULL,
+    dry_run BOOLEAN NOT NULL,
+    expired INT NOT NULL DEFAULT 0,
+    purged INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NOT NULL
+);
+
+-- Задачи, попавшие под политику хранения; имя файла и автор не сохраняются
+CREATE TABLE retention_run_items
+(
+    run_id UUID NOT NULL REFERENCES retention_runs (id) ON DELETE CASCADE,
+    task_id UUID NOT NULL,
+    course_id TEXT NOT NULL,
+    task_created_at TIMESTAMP NOT NULL,
+    retain_until TIMESTAMP NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (run_id, task_id)
+);
+
+CREATE INDEX retention_run_items_task_id_idx ON retention_run_items (task_id);
+CREATE INDEX tasks_course_id_created_at_active_idx ON tasks (course_id, created_at) WHERE deleted_at IS NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS tasks_submission_id_version_idx;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS version,
+    DROP COLUMN IF EXISTS submission_id;
+
+DROP TABLE IF EXISTS submissions;
@@ -0,0 +1,36 @@
+-- Посылка - все версии работы студента по заданию курса. last_version - номер последней выданной версии,
+-- номера не переиспользуются после удаления версий
+CREATE TABLE submissions
+(
+    id UUID PRIMARY KEY NOT NULL,
+    uploaded_by UUID NOT NULL,
+    course_id TEXT NOT NULL,
+    assignment_id TEXT NOT NULL,
+    last_version INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (uploaded_by, course_id, assignment_id)
+);
+
+-- Задачи без assignment_id в посылки не группируются
+ALTER TABLE tasks
+    ADD COLUMN submission_id UUID REFERENCES submissions (id),
+    ADD COLUMN version INT;
+
+CREATE UNIQUE INDEX tasks_submission_id_version_idx ON tasks (submission_id, version);
+
+-- Существующие задачи группируются в посылки, версии нумеруются по времени загрузки
+INSERT INTO submissions (id, uploaded_by, course_id, assignment_id, last_version, created_at)
+SELECT gen_random_uuid(), uploaded_by, course_id, assignment_id, COUNT(*), MIN(created_at)
+FROM tasks
+WHERE assignment_id <> ''
+GROUP BY uploaded_by, course_id, assignment_id;
+
+UPDATE tasks t
+SET submission_id = v.submission_id, version = v.version
+FROM (
+    SELECT x.id, s.id AS submission_id,
+           ROW_NUMBER() OVER (PARTITION BY s.id ORDER BY x.created_at, x.id) AS version
+    FROM tasks x
+    JOIN submissions s ON s.uploaded_by = x.uploaded_by AND s.course_id = x.course_id AND s.assignment_id = x.assignment_id
+) v
+WHERE t.id = v.id;
@@ -0,0 +1,5 @@
+-- Ключ объекта хранится явно: схема ключей настраивается и может меняться, существующие файлы не переносятся
+ALTER TABLE tasks ADD COLUMN object_key TEXT NOT NULL DEFAULT '';
+
+-- Задачи, созданные до появления колонки, хранятся по схеме {task_id}{ext}
+UPDATE tasks SET object_key = id::text || COALESCE(substring(filename from '\.[^./]*$'), '');
@@ -0,0 +1,4 @@
+-- Фактический размер загруженного файла, по нему считается объем для квот
+ALTER TABLE tasks ADD COLUMN content_size BIGINT NOT NULL DEFAULT 0;
+
+UPDATE tasks SET content_size = expected_size WHERE status IN ('verified', 'unverified');
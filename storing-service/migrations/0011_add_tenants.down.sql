@@ -0,0 +1,30 @@
+DROP INDEX IF EXISTS tasks_content_sha256_idx;
+DROP INDEX IF EXISTS tasks_created_at_id_idx;
+DROP INDEX IF EXISTS tasks_uploaded_by_created_at_idx;
+DROP INDEX IF EXISTS tasks_assignment_id_created_at_idx;
+DROP INDEX IF EXISTS tasks_course_id_created_at_idx;
+DROP INDEX IF EXISTS tasks_status_created_at_idx;
+DROP INDEX IF EXISTS tasks_deleted_at_idx;
+DROP INDEX IF EXISTS tasks_course_id_created_at_active_idx;
+DROP INDEX IF EXISTS task_events_pending_idx;
+
+CREATE INDEX tasks_content_sha256_idx ON tasks (content_sha256, created_at) WHERE content_sha256 <> '';
+CREATE INDEX tasks_created_at_id_idx ON tasks (created_at, id);
+CREATE INDEX tasks_uploaded_by_created_at_idx ON tasks (uploaded_by, created_at, id);
+CREATE INDEX tasks_assignment_id_created_at_idx ON tasks (assignment_id, created_at, id);
+CREATE INDEX tasks_course_id_created_at_idx ON tasks (course_id, created_at, id);
+CREATE INDEX tasks_status_created_at_idx ON tasks (status, created_at, id);
+CREATE INDEX tasks_deleted_at_idx ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX tasks_course_id_created_at_active_idx ON tasks (course_id, created_at) WHERE deleted_at IS NULL;
+CREATE INDEX task_events_pending_idx ON task_events (created_at) WHERE delivered_at IS NULL;
+
+ALTER TABLE submissions DROP CONSTRAINT IF EXISTS submissions_tenant_id_uploaded_by_course_id_assignment_id_key;
+ALTER TABLE submissions ADD CONSTRAINT submissions_uploaded_by_course_id_assignment_id_key
+    UNIQUE (uploaded_by, course_id, assignment_id);
+
+ALTER TABLE retention_runs DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE task_events DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE submissions DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS tenant_id;
+
+DROP TABLE IF EXISTS tenants;
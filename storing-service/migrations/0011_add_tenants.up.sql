@@ -0,0 +1,40 @@
+-- Тенант - учреждение (факультет) со своим корпусом работ. Реестр тенантов пополняется при первой
+-- загрузке, по нему фоновые воркеры обходят тенанты. Данные, созданные до появления тенантов, принадлежат default.
+CREATE TABLE tenants
+(
+    id TEXT PRIMARY KEY NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+INSERT INTO tenants (id) VALUES ('default');
+
+ALTER TABLE tasks ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default' REFERENCES tenants (id);
+ALTER TABLE submissions ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default' REFERENCES tenants (id);
+ALTER TABLE task_events ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default' REFERENCES tenants (id);
+ALTER TABLE retention_runs ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default' REFERENCES tenants (id);
+
+-- Посылка уникальна в пределах тенанта
+ALTER TABLE submissions DROP CONSTRAINT submissions_uploaded_by_course_id_assignment_id_key;
+ALTER TABLE submissions ADD CONSTRAINT submissions_tenant_id_uploaded_by_course_id_assignment_id_key
+    UNIQUE (tenant_id, uploaded_by, course_id, assignment_id);
+
+-- Все запросы ограничены тенантом, поэтому он ведущая колонка индексов
+DROP INDEX tasks_content_sha256_idx;
+DROP INDEX tasks_created_at_id_idx;
+DROP INDEX tasks_uploaded_by_created_at_idx;
+DROP INDEX tasks_assignment_id_created_at_idx;
+DROP INDEX tasks_course_id_created_at_idx;
+DROP INDEX tasks_status_created_at_idx;
+DROP INDEX tasks_deleted_at_idx;
+DROP INDEX tasks_course_id_created_at_active_idx;
+DROP INDEX task_events_pending_idx;
+
+CREATE INDEX tasks_content_sha256_idx ON tasks (tenant_id, content_sha256, created_at) WHERE content_sha256 <> '';
+CREATE INDEX tasks_created_at_id_idx ON tasks (tenant_id, created_at, id);
+CREATE INDEX tasks_uploaded_by_created_at_idx ON tasks (tenant_id, uploaded_by, created_at, id);
+CREATE INDEX tasks_assignment_id_created_at_idx ON tasks (tenant_id, assignment_id, created_at, id);
+CREATE INDEX tasks_course_id_created_at_idx ON tasks (tenant_id, course_id, created_at, id);
+CREATE INDEX tasks_status_created_at_idx ON tasks (tenant_id, status, created_at, id);
+CREATE INDEX tasks_deleted_at_idx ON tasks (tenant_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX tasks_course_id_created_at_active_idx ON tasks (tenant_id, course_id, created_at) WHERE deleted_at IS NULL;
+CREATE INDEX task_events_pending_idx ON task_events (tenant_id, created_at) WHERE delivered_at IS NULL;
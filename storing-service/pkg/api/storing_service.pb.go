@@ -22,9 +22,14 @@ const (
 )
 
 type UploadTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
-	UploadedBy    string                 `protobuf:"bytes,2,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Filename     string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	UploadedBy   string                 `protobuf:"bytes,2,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	CourseId     string                 `protobuf:"bytes,3,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	AssignmentId string                 `protobuf:"bytes,4,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	// Размер файла в байтах и SHA-256 (hex), проверяются политикой загрузки и после загрузки
+	Size          int64  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256        string `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,10 +78,40 @@ func (x *UploadTaskRequest) GetUploadedBy() string {
 	return ""
 }
 
+func (x *UploadTaskRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *UploadTaskRequest) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *UploadTaskRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadTaskRequest) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
 type UploadTaskResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	UploadUrl     string                 `protobuf:"bytes,2,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FileId string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// URL для POST запроса multipart/form-data, поля form_data передаются перед файлом
+	UploadUrl     string            `protobuf:"bytes,2,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`
+	FormData      map[string]string `protobuf:"bytes,3,rep,name=form_data,json=formData,proto3" json:"form_data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -125,27 +160,39 @@ func (x *UploadTaskResponse) GetUploadUrl() string {
 	return ""
 }
 
-type GetTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+func (x *UploadTaskResponse) GetFormData() map[string]string {
+	if x != nil {
+		return x.FormData
+	}
+	return nil
+}
+
+// Первое сообщение потока содержит метаданные, последующие - части файла
+type UploadTaskStreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadTaskStreamRequest_Metadata
+	//	*UploadTaskStreamRequest_Chunk
+	Payload       isUploadTaskStreamRequest_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskRequest) Reset() {
-	*x = GetTaskRequest{}
+func (x *UploadTaskStreamRequest) Reset() {
+	*x = UploadTaskStreamRequest{}
 	mi := &file_storing_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskRequest) String() string {
+func (x *UploadTaskStreamRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskRequest) ProtoMessage() {}
+func (*UploadTaskStreamRequest) ProtoMessage() {}
 
-func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadTaskStreamRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_storing_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -157,43 +204,79 @@ func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadTaskStreamRequest.ProtoReflect.Descriptor instead.
+func (*UploadTaskStreamRequest) Descriptor() ([]byte, []int) {
 	return file_storing_service_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetTaskRequest) GetFileId() string {
+func (x *UploadTaskStreamRequest) GetPayload() isUploadTaskStreamRequest_Payload {
 	if x != nil {
-		return x.FileId
+		return x.Payload
 	}
-	return ""
+	return nil
 }
 
-type GetTaskResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
-	UploadedBy    string                 `protobuf:"bytes,4,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
-	UploadedAt    string                 `protobuf:"bytes,5,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+func (x *UploadTaskStreamRequest) GetMetadata() *UploadTaskMetadata {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadTaskStreamRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *UploadTaskStreamRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadTaskStreamRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadTaskStreamRequest_Payload interface {
+	isUploadTaskStreamRequest_Payload()
+}
+
+type UploadTaskStreamRequest_Metadata struct {
+	Metadata *UploadTaskMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type UploadTaskStreamRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadTaskStreamRequest_Metadata) isUploadTaskStreamRequest_Payload() {}
+
+func (*UploadTaskStreamRequest_Chunk) isUploadTaskStreamRequest_Payload() {}
+
+type UploadTaskMetadata struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Filename     string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	UploadedBy   string                 `protobuf:"bytes,2,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	CourseId     string                 `protobuf:"bytes,3,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	AssignmentId string                 `protobuf:"bytes,4,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	// Необязательные, при указании сверяются с загруженным файлом
+	Size          int64  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256        string `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskResponse) Reset() {
-	*x = GetTaskResponse{}
+func (x *UploadTaskMetadata) Reset() {
+	*x = UploadTaskMetadata{}
 	mi := &file_storing_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskResponse) String() string {
+func (x *UploadTaskMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskResponse) ProtoMessage() {}
+func (*UploadTaskMetadata) ProtoMessage() {}
 
-func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+func (x *UploadTaskMetadata) ProtoReflect() protoreflect.Message {
 	mi := &file_storing_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -205,67 +288,77 @@ func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
-func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadTaskMetadata.ProtoReflect.Descriptor instead.
+func (*UploadTaskMetadata) Descriptor() ([]byte, []int) {
 	return file_storing_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetTaskResponse) GetFileId() string {
+func (x *UploadTaskMetadata) GetFilename() string {
 	if x != nil {
-		return x.FileId
+		return x.Filename
 	}
 	return ""
 }
 
-func (x *GetTaskResponse) GetFilename() string {
+func (x *UploadTaskMetadata) GetUploadedBy() string {
 	if x != nil {
-		return x.Filename
+		return x.UploadedBy
 	}
 	return ""
 }
 
-func (x *GetTaskResponse) GetUrl() string {
+func (x *UploadTaskMetadata) GetCourseId() string {
 	if x != nil {
-		return x.Url
+		return x.CourseId
 	}
 	return ""
 }
 
-func (x *GetTaskResponse) GetUploadedBy() string {
+func (x *UploadTaskMetadata) GetAssignmentId() string {
 	if x != nil {
-		return x.UploadedBy
+		return x.AssignmentId
 	}
 	return ""
 }
 
-func (x *GetTaskResponse) GetUploadedAt() string {
+func (x *UploadTaskMetadata) GetSize() int64 {
 	if x != nil {
-		return x.UploadedAt
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadTaskMetadata) GetSha256() string {
+	if x != nil {
+		return x.Sha256
 	}
 	return ""
 }
 
-type GetFileContentRequest struct {
+type UploadTaskStreamResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	StatusReason  string                 `protobuf:"bytes,4,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFileContentRequest) Reset() {
-	*x = GetFileContentRequest{}
+func (x *UploadTaskStreamResponse) Reset() {
+	*x = UploadTaskStreamResponse{}
 	mi := &file_storing_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFileContentRequest) String() string {
+func (x *UploadTaskStreamResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFileContentRequest) ProtoMessage() {}
+func (*UploadTaskStreamResponse) ProtoMessage() {}
 
-func (x *GetFileContentRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadTaskStreamResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_storing_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -277,39 +370,65 @@ func (x *GetFileContentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFileContentRequest.ProtoReflect.Descriptor instead.
-func (*GetFileContentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadTaskStreamResponse.ProtoReflect.Descriptor instead.
+func (*UploadTaskStreamResponse) Descriptor() ([]byte, []int) {
 	return file_storing_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetFileContentRequest) GetFileId() string {
+func (x *UploadTaskStreamResponse) GetFileId() string {
 	if x != nil {
 		return x.FileId
 	}
 	return ""
 }
 
-type GetFileContentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+func (x *UploadTaskStreamResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadTaskStreamResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UploadTaskStreamResponse) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+// Первое сообщение потока содержит метаданные, последующие - части ZIP-архива
+type ImportArchiveRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportArchiveRequest_Metadata
+	//	*ImportArchiveRequest_Chunk
+	Payload       isImportArchiveRequest_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFileContentResponse) Reset() {
-	*x = GetFileContentResponse{}
+func (x *ImportArchiveRequest) Reset() {
+	*x = ImportArchiveRequest{}
 	mi := &file_storing_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFileContentResponse) String() string {
+func (x *ImportArchiveRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFileContentResponse) ProtoMessage() {}
+func (*ImportArchiveRequest) ProtoMessage() {}
 
-func (x *GetFileContentResponse) ProtoReflect() protoreflect.Message {
+func (x *ImportArchiveRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_storing_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -321,91 +440,2931 @@ func (x *GetFileContentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFileContentResponse.ProtoReflect.Descriptor instead.
-func (*GetFileContentResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImportArchiveRequest.ProtoReflect.Descriptor instead.
+func (*ImportArchiveRequest) Descriptor() ([]byte, []int) {
 	return file_storing_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetFileContentResponse) GetContent() []byte {
+func (x *ImportArchiveRequest) GetPayload() isImportArchiveRequest_Payload {
 	if x != nil {
-		return x.Content
+		return x.Payload
 	}
 	return nil
 }
 
-var File_storing_service_proto protoreflect.FileDescriptor
+func (x *ImportArchiveRequest) GetMetadata() *ImportArchiveMetadata {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportArchiveRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
 
-const file_storing_service_proto_rawDesc = "" +
-	"\n" +
-	"\x15storing_service.proto\x12\n" +
-	"storing.v1\"P\n" +
-	"\x11UploadTaskRequest\x12\x1a\n" +
-	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x1f\n" +
-	"\vuploaded_by\x18\x02 \x01(\tR\n" +
-	"uploadedBy\"L\n" +
-	"\x12UploadTaskResponse\x12\x17\n" +
-	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1d\n" +
-	"\n" +
-	"upload_url\x18\x02 \x01(\tR\tuploadUrl\")\n" +
-	"\x0eGetTaskRequest\x12\x17\n" +
-	"\afile_id\x18\x01 \x01(\tR\x06fileId\"\x9a\x01\n" +
-	"\x0fGetTaskResponse\x12\x17\n" +
-	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1a\n" +
-	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x10\n" +
-	"\x03url\x18\x03 \x01(\tR\x03url\x12\x1f\n" +
-	"\vuploaded_by\x18\x04 \x01(\tR\n" +
-	"uploadedBy\x12\x1f\n" +
-	"\vuploaded_at\x18\x05 \x01(\tR\n" +
-	"uploadedAt\"0\n" +
-	"\x15GetFileContentRequest\x12\x17\n" +
-	"\afile_id\x18\x01 \x01(\tR\x06fileId\"2\n" +
-	"\x16GetFileContentResponse\x12\x18\n" +
-	"\acontent\x18\x01 \x01(\fR\acontent2\xfa\x01\n" +
-	"\x0eStoringService\x12K\n" +
-	"\n" +
-	"UploadTask\x12\x1d.storing.v1.UploadTaskRequest\x1a\x1e.storing.v1.UploadTaskResponse\x12B\n" +
-	"\aGetTask\x12\x1a.storing.v1.GetTaskRequest\x1a\x1b.storing.v1.GetTaskResponse\x12W\n" +
-	"\x0eGetFileContent\x12!.storing.v1.GetFileContentRequest\x1a\".storing.v1.GetFileContentResponseB\tZ\apkg/apib\x06proto3"
+func (x *ImportArchiveRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportArchiveRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
 
-var (
-	file_storing_service_proto_rawDescOnce sync.Once
-	file_storing_service_proto_rawDescData []byte
-)
+type isImportArchiveRequest_Payload interface {
+	isImportArchiveRequest_Payload()
+}
 
-func file_storing_service_proto_rawDescGZIP() []byte {
-	file_storing_service_proto_rawDescOnce.Do(func() {
-		file_storing_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_storing_service_proto_rawDesc), len(file_storing_service_proto_rawDesc)))
-	})
-	return file_storing_service_proto_rawDescData
+type ImportArchiveRequest_Metadata struct {
+	Metadata *ImportArchiveMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
 }
 
-var file_storing_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_storing_service_proto_goTypes = []any{
-	(*UploadTaskRequest)(nil),      // 0: storing.v1.UploadTaskRequest
-	(*UploadTaskResponse)(nil),     // 1: storing.v1.UploadTaskResponse
-	(*GetTaskRequest)(nil),         // 2: storing.v1.GetTaskRequest
-	(*GetTaskResponse)(nil),        // 3: storing.v1.GetTaskResponse
-	(*GetFileContentRequest)(nil),  // 4: storing.v1.GetFileContentRequest
-	(*GetFileContentResponse)(nil), // 5: storing.v1.GetFileContentResponse
+type ImportArchiveRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
 }
-var file_storing_service_proto_depIdxs = []int32{
-	0, // 0: storing.v1.StoringService.UploadTask:input_type -> storing.v1.UploadTaskRequest
-	2, // 1: storing.v1.StoringService.GetTask:input_type -> storing.v1.GetTaskRequest
-	4, // 2: storing.v1.StoringService.GetFileContent:input_type -> storing.v1.GetFileContentRequest
-	1, // 3: storing.v1.StoringService.UploadTask:output_type -> storing.v1.UploadTaskResponse
-	3, // 4: storing.v1.StoringService.GetTask:output_type -> storing.v1.GetTaskResponse
-	5, // 5: storing.v1.StoringService.GetFileContent:output_type -> storing.v1.GetFileContentResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+func (*ImportArchiveRequest_Metadata) isImportArchiveRequest_Payload() {}
+
+func (*ImportArchiveRequest_Chunk) isImportArchiveRequest_Payload() {}
+
+type ImportArchiveMetadata struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	CourseId     string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	AssignmentId string                 `protobuf:"bytes,2,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	// Регулярное выражение для пути файла в архиве с группой student (по умолчанию - папка верхнего уровня)
+	NamingRule string `protobuf:"bytes,3,opt,name=naming_rule,json=namingRule,proto3" json:"naming_rule,omitempty"`
+	// CSV с колонками path,uploaded_by, имеет приоритет над naming_rule
+	ManifestCsv   []byte `protobuf:"bytes,4,opt,name=manifest_csv,json=manifestCsv,proto3" json:"manifest_csv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func init() { file_storing_service_proto_init() }
-func file_storing_service_proto_init() {
-	if File_storing_service_proto != nil {
-		return
+func (x *ImportArchiveMetadata) Reset() {
+	*x = ImportArchiveMetadata{}
+	mi := &file_storing_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportArchiveMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportArchiveMetadata) ProtoMessage() {}
+
+func (x *ImportArchiveMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportArchiveMetadata.ProtoReflect.Descriptor instead.
+func (*ImportArchiveMetadata) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImportArchiveMetadata) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *ImportArchiveMetadata) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *ImportArchiveMetadata) GetNamingRule() string {
+	if x != nil {
+		return x.NamingRule
+	}
+	return ""
+}
+
+func (x *ImportArchiveMetadata) GetManifestCsv() []byte {
+	if x != nil {
+		return x.ManifestCsv
+	}
+	return nil
+}
+
+type ImportEntry struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Path       string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	UploadedBy string                 `protobuf:"bytes,2,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	FileId     string                 `protobuf:"bytes,3,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// imported, rejected, failed или skipped
+	Status        string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportEntry) Reset() {
+	*x = ImportEntry{}
+	mi := &file_storing_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportEntry) ProtoMessage() {}
+
+func (x *ImportEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportEntry.ProtoReflect.Descriptor instead.
+func (*ImportEntry) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ImportEntry) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ImportEntry) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *ImportEntry) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *ImportEntry) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ImportEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ImportArchiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Imported      int32                  `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	Rejected      int32                  `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Skipped       int32                  `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Entries       []*ImportEntry         `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportArchiveResponse) Reset() {
+	*x = ImportArchiveResponse{}
+	mi := &file_storing_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportArchiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportArchiveResponse) ProtoMessage() {}
+
+func (x *ImportArchiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportArchiveResponse.ProtoReflect.Descriptor instead.
+func (*ImportArchiveResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ImportArchiveResponse) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportArchiveResponse) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *ImportArchiveResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportArchiveResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ImportArchiveResponse) GetEntries() []*ImportEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_storing_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetTaskRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type GetTaskResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	FileId       string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Filename     string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Url          string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	UploadedBy   string                 `protobuf:"bytes,4,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	UploadedAt   string                 `protobuf:"bytes,5,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	CourseId     string                 `protobuf:"bytes,6,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	AssignmentId string                 `protobuf:"bytes,7,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	// pending, verified, rejected, unverified
+	Status       string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	StatusReason string `protobuf:"bytes,9,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`
+	Size         int64  `protobuf:"varint,10,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256       string `protobuf:"bytes,11,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	ContentType  string `protobuf:"bytes,12,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// SHA-256 (hex) фактически загруженного файла
+	ContentSha256 string `protobuf:"bytes,13,opt,name=content_sha256,json=contentSha256,proto3" json:"content_sha256,omitempty"`
+	// Посылка и номер версии в ней, пусты для задач без assignment_id
+	SubmissionId string `protobuf:"bytes,14,opt,name=submission_id,json=submissionId,proto3" json:"submission_id,omitempty"`
+	Version      int32  `protobuf:"varint,15,opt,name=version,proto3" json:"version,omitempty"`
+	// Ключ объекта в хранилище, по нему analysis-service читает файл задачи
+	ObjectKey     string `protobuf:"bytes,16,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskResponse) Reset() {
+	*x = GetTaskResponse{}
+	mi := &file_storing_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskResponse) ProtoMessage() {}
+
+func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTaskResponse) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetUploadedAt() string {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GetTaskResponse) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetContentSha256() string {
+	if x != nil {
+		return x.ContentSha256
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetSubmissionId() string {
+	if x != nil {
+		return x.SubmissionId
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetTaskResponse) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+type ListTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пустые поля не ограничивают выборку
+	UploadedBy   string `protobuf:"bytes,1,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	CourseId     string `protobuf:"bytes,2,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	AssignmentId string `protobuf:"bytes,3,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	Status       string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// RFC3339, created_from включительно, created_to не включительно
+	CreatedFrom string `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   string `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	// Подстрока имени файла без учета регистра
+	Filename string `protobuf:"bytes,7,opt,name=filename,proto3" json:"filename,omitempty"`
+	// asc или desc (по умолчанию) по created_at, id
+	Order string `protobuf:"bytes,8,opt,name=order,proto3" json:"order,omitempty"`
+	// 0 - 50, не больше 200
+	Limit int32 `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor из предыдущей страницы
+	Cursor string `protobuf:"bytes,10,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Выборка конкретных задач, например для дополнения списка отчетов
+	FileIds       []string `protobuf:"bytes,11,rep,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_storing_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListTasksRequest) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCreatedFrom() string {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCreatedTo() string {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetFileIds() []string {
+	if x != nil {
+		return x.FileIds
+	}
+	return nil
+}
+
+type TaskSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	UploadedBy    string                 `protobuf:"bytes,3,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	UploadedAt    string                 `protobuf:"bytes,4,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	CourseId      string                 `protobuf:"bytes,5,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	AssignmentId  string                 `protobuf:"bytes,6,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Size          int64                  `protobuf:"varint,8,opt,name=size,proto3" json:"size,omitempty"`
+	ContentType   string                 `protobuf:"bytes,9,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	SubmissionId  string                 `protobuf:"bytes,10,opt,name=submission_id,json=submissionId,proto3" json:"submission_id,omitempty"`
+	Version       int32                  `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskSummary) Reset() {
+	*x = TaskSummary{}
+	mi := &file_storing_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskSummary) ProtoMessage() {}
+
+func (x *TaskSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskSummary.ProtoReflect.Descriptor instead.
+func (*TaskSummary) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TaskSummary) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetUploadedAt() string {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *TaskSummary) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetSubmissionId() string {
+	if x != nil {
+		return x.SubmissionId
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ListTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*TaskSummary         `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// Пустой, если страница последняя
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_storing_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListTasksResponse) GetTasks() []*TaskSummary {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetSubmissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubmissionId  string                 `protobuf:"bytes,1,opt,name=submission_id,json=submissionId,proto3" json:"submission_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubmissionRequest) Reset() {
+	*x = GetSubmissionRequest{}
+	mi := &file_storing_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubmissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubmissionRequest) ProtoMessage() {}
+
+func (x *GetSubmissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubmissionRequest.ProtoReflect.Descriptor instead.
+func (*GetSubmissionRequest) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetSubmissionRequest) GetSubmissionId() string {
+	if x != nil {
+		return x.SubmissionId
+	}
+	return ""
+}
+
+type GetSubmissionResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SubmissionId string                 `protobuf:"bytes,1,opt,name=submission_id,json=submissionId,proto3" json:"submission_id,omitempty"`
+	UploadedBy   string                 `protobuf:"bytes,2,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	CourseId     string                 `protobuf:"bytes,3,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	AssignmentId string                 `protobuf:"bytes,4,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	CreatedAt    string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Неудаленные версии по возрастанию номера, последняя - текущая
+	Versions      []*TaskSummary `protobuf:"bytes,6,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubmissionResponse) Reset() {
+	*x = GetSubmissionResponse{}
+	mi := &file_storing_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubmissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubmissionResponse) ProtoMessage() {}
+
+func (x *GetSubmissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubmissionResponse.ProtoReflect.Descriptor instead.
+func (*GetSubmissionResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetSubmissionResponse) GetSubmissionId() string {
+	if x != nil {
+		return x.SubmissionId
+	}
+	return ""
+}
+
+func (x *GetSubmissionResponse) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *GetSubmissionResponse) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *GetSubmissionResponse) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *GetSubmissionResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *GetSubmissionResponse) GetVersions() []*TaskSummary {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type GetAnalysisInputRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnalysisInputRequest) Reset() {
+	*x = GetAnalysisInputRequest{}
+	mi := &file_storing_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnalysisInputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnalysisInputRequest) ProtoMessage() {}
+
+func (x *GetAnalysisInputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnalysisInputRequest.ProtoReflect.Descriptor instead.
+func (*GetAnalysisInputRequest) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetAnalysisInputRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+// Параметры AnalyseTask analysis-service, те же, что storing-service передает после загрузки
+type GetAnalysisInputResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	FileId       string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	ObjectKey    string                 `protobuf:"bytes,2,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	AssignmentId string                 `protobuf:"bytes,3,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	UploadedBy   string                 `protobuf:"bytes,4,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	CourseId     string                 `protobuf:"bytes,5,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	// Предыдущие версии посылки того же студента, не участвуют в сравнении
+	ExcludeTaskIds []string `protobuf:"bytes,6,rep,name=exclude_task_ids,json=excludeTaskIds,proto3" json:"exclude_task_ids,omitempty"`
+	// Более ранняя задача другого пользователя с побайтово совпадающим файлом, пусто - дубликата нет
+	DuplicateOf          string `protobuf:"bytes,7,opt,name=duplicate_of,json=duplicateOf,proto3" json:"duplicate_of,omitempty"`
+	DuplicateOfObjectKey string `protobuf:"bytes,8,opt,name=duplicate_of_object_key,json=duplicateOfObjectKey,proto3" json:"duplicate_of_object_key,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetAnalysisInputResponse) Reset() {
+	*x = GetAnalysisInputResponse{}
+	mi := &file_storing_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnalysisInputResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnalysisInputResponse) ProtoMessage() {}
+
+func (x *GetAnalysisInputResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnalysisInputResponse.ProtoReflect.Descriptor instead.
+func (*GetAnalysisInputResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetAnalysisInputResponse) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *GetAnalysisInputResponse) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *GetAnalysisInputResponse) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *GetAnalysisInputResponse) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *GetAnalysisInputResponse) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *GetAnalysisInputResponse) GetExcludeTaskIds() []string {
+	if x != nil {
+		return x.ExcludeTaskIds
+	}
+	return nil
+}
+
+func (x *GetAnalysisInputResponse) GetDuplicateOf() string {
+	if x != nil {
+		return x.DuplicateOf
+	}
+	return ""
+}
+
+func (x *GetAnalysisInputResponse) GetDuplicateOfObjectKey() string {
+	if x != nil {
+		return x.DuplicateOfObjectKey
+	}
+	return ""
+}
+
+type GetFileContentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileContentRequest) Reset() {
+	*x = GetFileContentRequest{}
+	mi := &file_storing_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileContentRequest) ProtoMessage() {}
+
+func (x *GetFileContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileContentRequest.ProtoReflect.Descriptor instead.
+func (*GetFileContentRequest) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetFileContentRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type GetFileContentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileContentResponse) Reset() {
+	*x = GetFileContentResponse{}
+	mi := &file_storing_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileContentResponse) ProtoMessage() {}
+
+func (x *GetFileContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileContentResponse.ProtoReflect.Descriptor instead.
+func (*GetFileContentResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetFileContentResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type ReadBlobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ObjectKey     string                 `protobuf:"bytes,1,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	Params        map[string]string      `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadBlobRequest) Reset() {
+	*x = ReadBlobRequest{}
+	mi := &file_storing_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadBlobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadBlobRequest) ProtoMessage() {}
+
+func (x *ReadBlobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadBlobRequest.ProtoReflect.Descriptor instead.
+func (*ReadBlobRequest) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReadBlobRequest) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *ReadBlobRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+// Первое сообщение содержит сведения об объекте, последующие - части файла
+type ReadBlobResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ReadBlobResponse_Info
+	//	*ReadBlobResponse_Chunk
+	Payload       isReadBlobResponse_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadBlobResponse) Reset() {
+	*x = ReadBlobResponse{}
+	mi := &file_storing_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadBlobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadBlobResponse) ProtoMessage() {}
+
+func (x *ReadBlobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadBlobResponse.ProtoReflect.Descriptor instead.
+func (*ReadBlobResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReadBlobResponse) GetPayload() isReadBlobResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ReadBlobResponse) GetInfo() *BlobInfo {
+	if x != nil {
+		if x, ok := x.Payload.(*ReadBlobResponse_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *ReadBlobResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ReadBlobResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isReadBlobResponse_Payload interface {
+	isReadBlobResponse_Payload()
+}
+
+type ReadBlobResponse_Info struct {
+	Info *BlobInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type ReadBlobResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*ReadBlobResponse_Info) isReadBlobResponse_Payload() {}
+
+func (*ReadBlobResponse_Chunk) isReadBlobResponse_Payload() {}
+
+type BlobInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Size          int64                  `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	ModifiedAt    string                 `protobuf:"bytes,3,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlobInfo) Reset() {
+	*x = BlobInfo{}
+	mi := &file_storing_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlobInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobInfo) ProtoMessage() {}
+
+func (x *BlobInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobInfo.ProtoReflect.Descriptor instead.
+func (*BlobInfo) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BlobInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BlobInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *BlobInfo) GetModifiedAt() string {
+	if x != nil {
+		return x.ModifiedAt
+	}
+	return ""
+}
+
+// Первое сообщение потока содержит метаданные, последующие - части файла
+type WriteBlobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*WriteBlobRequest_Metadata
+	//	*WriteBlobRequest_Chunk
+	Payload       isWriteBlobRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteBlobRequest) Reset() {
+	*x = WriteBlobRequest{}
+	mi := &file_storing_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteBlobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteBlobRequest) ProtoMessage() {}
+
+func (x *WriteBlobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteBlobRequest.ProtoReflect.Descriptor instead.
+func (*WriteBlobRequest) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WriteBlobRequest) GetPayload() isWriteBlobRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *WriteBlobRequest) GetMetadata() *WriteBlobMetadata {
+	if x != nil {
+		if x, ok := x.Payload.(*WriteBlobRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *WriteBlobRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*WriteBlobRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isWriteBlobRequest_Payload interface {
+	isWriteBlobRequest_Payload()
+}
+
+type WriteBlobRequest_Metadata struct {
+	Metadata *WriteBlobMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type WriteBlobRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*WriteBlobRequest_Metadata) isWriteBlobRequest_Payload() {}
+
+func (*WriteBlobRequest_Chunk) isWriteBlobRequest_Payload() {}
+
+type WriteBlobMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ObjectKey     string                 `protobuf:"bytes,1,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	Params        map[string]string      `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteBlobMetadata) Reset() {
+	*x = WriteBlobMetadata{}
+	mi := &file_storing_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteBlobMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteBlobMetadata) ProtoMessage() {}
+
+func (x *WriteBlobMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteBlobMetadata.ProtoReflect.Descriptor instead.
+func (*WriteBlobMetadata) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WriteBlobMetadata) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *WriteBlobMetadata) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type WriteBlobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Size          int64                  `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteBlobResponse) Reset() {
+	*x = WriteBlobResponse{}
+	mi := &file_storing_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteBlobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteBlobResponse) ProtoMessage() {}
+
+func (x *WriteBlobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteBlobResponse.ProtoReflect.Descriptor instead.
+func (*WriteBlobResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WriteBlobResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type DeleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_storing_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteTaskRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type DeleteTaskResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Момент окончательного удаления файла и отчетов (RFC3339)
+	PurgeAt       string `protobuf:"bytes,1,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskResponse) Reset() {
+	*x = DeleteTaskResponse{}
+	mi := &file_storing_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskResponse) ProtoMessage() {}
+
+func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteTaskResponse) GetPurgeAt() string {
+	if x != nil {
+		return x.PurgeAt
+	}
+	return ""
+}
+
+// Окончательно удаляет все задачи автора без grace-периода
+type EraseUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadedBy    string                 `protobuf:"bytes,1,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataRequest) Reset() {
+	*x = EraseUserDataRequest{}
+	mi := &file_storing_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataRequest) ProtoMessage() {}
+
+func (x *EraseUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EraseUserDataRequest) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+type EraseUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TasksErased   int32                  `protobuf:"varint,1,opt,name=tasks_erased,json=tasksErased,proto3" json:"tasks_erased,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataResponse) Reset() {
+	*x = EraseUserDataResponse{}
+	mi := &file_storing_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataResponse) ProtoMessage() {}
+
+func (x *EraseUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *EraseUserDataResponse) GetTasksErased() int32 {
+	if x != nil {
+		return x.TasksErased
+	}
+	return 0
+}
+
+// Запускает политику хранения; при dry_run задачи только перечисляются
+type RunRetentionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunRetentionRequest) Reset() {
+	*x = RunRetentionRequest{}
+	mi := &file_storing_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunRetentionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunRetentionRequest) ProtoMessage() {}
+
+func (x *RunRetentionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunRetentionRequest.ProtoReflect.Descriptor instead.
+func (*RunRetentionRequest) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RunRetentionRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type RetentionItem struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	FileId      string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	CourseId    string                 `protobuf:"bytes,2,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	UploadedAt  string                 `protobuf:"bytes,3,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	RetainUntil string                 `protobuf:"bytes,4,opt,name=retain_until,json=retainUntil,proto3" json:"retain_until,omitempty"`
+	// Пустая, если задача удалена (или была бы удалена при dry_run)
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetentionItem) Reset() {
+	*x = RetentionItem{}
+	mi := &file_storing_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetentionItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetentionItem) ProtoMessage() {}
+
+func (x *RetentionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetentionItem.ProtoReflect.Descriptor instead.
+func (*RetentionItem) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RetentionItem) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *RetentionItem) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *RetentionItem) GetUploadedAt() string {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return ""
+}
+
+func (x *RetentionItem) GetRetainUntil() string {
+	if x != nil {
+		return x.RetainUntil
+	}
+	return ""
+}
+
+func (x *RetentionItem) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RunRetentionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Expired       int32                  `protobuf:"varint,3,opt,name=expired,proto3" json:"expired,omitempty"`
+	Purged        int32                  `protobuf:"varint,4,opt,name=purged,proto3" json:"purged,omitempty"`
+	Failed        int32                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	StartedAt     string                 `protobuf:"bytes,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    string                 `protobuf:"bytes,7,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Items         []*RetentionItem       `protobuf:"bytes,8,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunRetentionResponse) Reset() {
+	*x = RunRetentionResponse{}
+	mi := &file_storing_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunRetentionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunRetentionResponse) ProtoMessage() {}
+
+func (x *RunRetentionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunRetentionResponse.ProtoReflect.Descriptor instead.
+func (*RunRetentionResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RunRetentionResponse) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *RunRetentionResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *RunRetentionResponse) GetExpired() int32 {
+	if x != nil {
+		return x.Expired
+	}
+	return 0
+}
+
+func (x *RunRetentionResponse) GetPurged() int32 {
+	if x != nil {
+		return x.Purged
+	}
+	return 0
+}
+
+func (x *RunRetentionResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *RunRetentionResponse) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *RunRetentionResponse) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *RunRetentionResponse) GetItems() []*RetentionItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Сверяет задачи с хранилищем; при dry_run расхождения только перечисляются
+type RunReconciliationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunReconciliationRequest) Reset() {
+	*x = RunReconciliationRequest{}
+	mi := &file_storing_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunReconciliationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunReconciliationRequest) ProtoMessage() {}
+
+func (x *RunReconciliationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunReconciliationRequest.ProtoReflect.Descriptor instead.
+func (*RunReconciliationRequest) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RunReconciliationRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ReconciliationItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// expired_upload, resumed_upload, missing_object или stray_object
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Пустой для объектов без задачи
+	FileId        string `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	ObjectKey     string `protobuf:"bytes,3,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconciliationItem) Reset() {
+	*x = ReconciliationItem{}
+	mi := &file_storing_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconciliationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconciliationItem) ProtoMessage() {}
+
+func (x *ReconciliationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconciliationItem.ProtoReflect.Descriptor instead.
+func (*ReconciliationItem) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReconciliationItem) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *ReconciliationItem) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RunReconciliationResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	DryRun bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// quarantine или delete
+	StrayAction    string                `protobuf:"bytes,2,opt,name=stray_action,json=strayAction,proto3" json:"stray_action,omitempty"`
+	ExpiredUploads int32                 `protobuf:"varint,3,opt,name=expired_uploads,json=expiredUploads,proto3" json:"expired_uploads,omitempty"`
+	ResumedUploads int32                 `protobuf:"varint,4,opt,name=resumed_uploads,json=resumedUploads,proto3" json:"resumed_uploads,omitempty"`
+	MissingObjects int32                 `protobuf:"varint,5,opt,name=missing_objects,json=missingObjects,proto3" json:"missing_objects,omitempty"`
+	StrayObjects   int32                 `protobuf:"varint,6,opt,name=stray_objects,json=strayObjects,proto3" json:"stray_objects,omitempty"`
+	Failed         int32                 `protobuf:"varint,7,opt,name=failed,proto3" json:"failed,omitempty"`
+	StartedAt      string                `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt     string                `protobuf:"bytes,9,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Items          []*ReconciliationItem `protobuf:"bytes,10,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RunReconciliationResponse) Reset() {
+	*x = RunReconciliationResponse{}
+	mi := &file_storing_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunReconciliationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunReconciliationResponse) ProtoMessage() {}
+
+func (x *RunReconciliationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunReconciliationResponse.ProtoReflect.Descriptor instead.
+func (*RunReconciliationResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RunReconciliationResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *RunReconciliationResponse) GetStrayAction() string {
+	if x != nil {
+		return x.StrayAction
+	}
+	return ""
+}
+
+func (x *RunReconciliationResponse) GetExpiredUploads() int32 {
+	if x != nil {
+		return x.ExpiredUploads
+	}
+	return 0
+}
+
+func (x *RunReconciliationResponse) GetResumedUploads() int32 {
+	if x != nil {
+		return x.ResumedUploads
+	}
+	return 0
+}
+
+func (x *RunReconciliationResponse) GetMissingObjects() int32 {
+	if x != nil {
+		return x.MissingObjects
+	}
+	return 0
+}
+
+func (x *RunReconciliationResponse) GetStrayObjects() int32 {
+	if x != nil {
+		return x.StrayObjects
+	}
+	return 0
+}
+
+func (x *RunReconciliationResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *RunReconciliationResponse) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *RunReconciliationResponse) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *RunReconciliationResponse) GetItems() []*ReconciliationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Перешифровывает ключи всех файлов активным мастер-ключом
+type RotateEncryptionKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateEncryptionKeysRequest) Reset() {
+	*x = RotateEncryptionKeysRequest{}
+	mi := &file_storing_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateEncryptionKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateEncryptionKeysRequest) ProtoMessage() {}
+
+func (x *RotateEncryptionKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateEncryptionKeysRequest.ProtoReflect.Descriptor instead.
+func (*RotateEncryptionKeysRequest) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{36}
+}
+
+type RotateEncryptionKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checked       int32                  `protobuf:"varint,1,opt,name=checked,proto3" json:"checked,omitempty"`
+	Rewrapped     int32                  `protobuf:"varint,2,opt,name=rewrapped,proto3" json:"rewrapped,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateEncryptionKeysResponse) Reset() {
+	*x = RotateEncryptionKeysResponse{}
+	mi := &file_storing_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateEncryptionKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateEncryptionKeysResponse) ProtoMessage() {}
+
+func (x *RotateEncryptionKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateEncryptionKeysResponse.ProtoReflect.Descriptor instead.
+func (*RotateEncryptionKeysResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RotateEncryptionKeysResponse) GetChecked() int32 {
+	if x != nil {
+		return x.Checked
+	}
+	return 0
+}
+
+func (x *RotateEncryptionKeysResponse) GetRewrapped() int32 {
+	if x != nil {
+		return x.Rewrapped
+	}
+	return 0
+}
+
+func (x *RotateEncryptionKeysResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+// Нужен хотя бы один из параметров
+type GetQuotaUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadedBy    string                 `protobuf:"bytes,1,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	AssignmentId  string                 `protobuf:"bytes,2,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuotaUsageRequest) Reset() {
+	*x = GetQuotaUsageRequest{}
+	mi := &file_storing_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaUsageRequest) ProtoMessage() {}
+
+func (x *GetQuotaUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetQuotaUsageRequest) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetQuotaUsageRequest) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *GetQuotaUsageRequest) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+type UserUsage struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	UploadedBy string                 `protobuf:"bytes,1,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	// Загрузки за окно квоты
+	Uploads       int64 `protobuf:"varint,2,opt,name=uploads,proto3" json:"uploads,omitempty"`
+	Bytes         int64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserUsage) Reset() {
+	*x = UserUsage{}
+	mi := &file_storing_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserUsage) ProtoMessage() {}
+
+func (x *UserUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserUsage.ProtoReflect.Descriptor instead.
+func (*UserUsage) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UserUsage) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *UserUsage) GetUploads() int64 {
+	if x != nil {
+		return x.Uploads
+	}
+	return 0
+}
+
+func (x *UserUsage) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+type AssignmentUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssignmentId  string                 `protobuf:"bytes,1,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	Bytes         int64                  `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentUsage) Reset() {
+	*x = AssignmentUsage{}
+	mi := &file_storing_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentUsage) ProtoMessage() {}
+
+func (x *AssignmentUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentUsage.ProtoReflect.Descriptor instead.
+func (*AssignmentUsage) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AssignmentUsage) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *AssignmentUsage) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+// Лимит 0 - без ограничения; user и assignment отсутствуют, если не запрашивались
+type GetQuotaUsageResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Window             string                 `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	MaxFileSize        int64                  `protobuf:"varint,2,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`
+	MaxUploads         int64                  `protobuf:"varint,3,opt,name=max_uploads,json=maxUploads,proto3" json:"max_uploads,omitempty"`
+	MaxUserBytes       int64                  `protobuf:"varint,4,opt,name=max_user_bytes,json=maxUserBytes,proto3" json:"max_user_bytes,omitempty"`
+	MaxAssignmentBytes int64                  `protobuf:"varint,5,opt,name=max_assignment_bytes,json=maxAssignmentBytes,proto3" json:"max_assignment_bytes,omitempty"`
+	User               *UserUsage             `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`
+	Assignment         *AssignmentUsage       `protobuf:"bytes,7,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetQuotaUsageResponse) Reset() {
+	*x = GetQuotaUsageResponse{}
+	mi := &file_storing_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaUsageResponse) ProtoMessage() {}
+
+func (x *GetQuotaUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storing_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetQuotaUsageResponse) Descriptor() ([]byte, []int) {
+	return file_storing_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetQuotaUsageResponse) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *GetQuotaUsageResponse) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+func (x *GetQuotaUsageResponse) GetMaxUploads() int64 {
+	if x != nil {
+		return x.MaxUploads
+	}
+	return 0
+}
+
+func (x *GetQuotaUsageResponse) GetMaxUserBytes() int64 {
+	if x != nil {
+		return x.MaxUserBytes
+	}
+	return 0
+}
+
+func (x *GetQuotaUsageResponse) GetMaxAssignmentBytes() int64 {
+	if x != nil {
+		return x.MaxAssignmentBytes
+	}
+	return 0
+}
+
+func (x *GetQuotaUsageResponse) GetUser() *UserUsage {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *GetQuotaUsageResponse) GetAssignment() *AssignmentUsage {
+	if x != nil {
+		return x.Assignment
+	}
+	return nil
+}
+
+var File_storing_service_proto protoreflect.FileDescriptor
+
+const file_storing_service_proto_rawDesc = "" +
+	"\n" +
+	"\x15storing_service.proto\x12\n" +
+	"storing.v1\"\xbe\x01\n" +
+	"\x11UploadTaskRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x1f\n" +
+	"\vuploaded_by\x18\x02 \x01(\tR\n" +
+	"uploadedBy\x12\x1b\n" +
+	"\tcourse_id\x18\x03 \x01(\tR\bcourseId\x12#\n" +
+	"\rassignment_id\x18\x04 \x01(\tR\fassignmentId\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\tR\x06sha256\"\xd4\x01\n" +
+	"\x12UploadTaskResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x02 \x01(\tR\tuploadUrl\x12I\n" +
+	"\tform_data\x18\x03 \x03(\v2,.storing.v1.UploadTaskResponse.FormDataEntryR\bformData\x1a;\n" +
+	"\rFormDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"z\n" +
+	"\x17UploadTaskStreamRequest\x12<\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1e.storing.v1.UploadTaskMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"\xbf\x01\n" +
+	"\x12UploadTaskMetadata\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x1f\n" +
+	"\vuploaded_by\x18\x02 \x01(\tR\n" +
+	"uploadedBy\x12\x1b\n" +
+	"\tcourse_id\x18\x03 \x01(\tR\bcourseId\x12#\n" +
+	"\rassignment_id\x18\x04 \x01(\tR\fassignmentId\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\tR\x06sha256\"\x84\x01\n" +
+	"\x18UploadTaskStreamResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12#\n" +
+	"\rstatus_reason\x18\x04 \x01(\tR\fstatusReason\"z\n" +
+	"\x14ImportArchiveRequest\x12?\n" +
+	"\bmetadata\x18\x01 \x01(\v2!.storing.v1.ImportArchiveMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"\x9d\x01\n" +
+	"\x15ImportArchiveMetadata\x12\x1b\n" +
+	"\tcourse_id\x18\x01 \x01(\tR\bcourseId\x12#\n" +
+	"\rassignment_id\x18\x02 \x01(\tR\fassignmentId\x12\x1f\n" +
+	"\vnaming_rule\x18\x03 \x01(\tR\n" +
+	"namingRule\x12!\n" +
+	"\fmanifest_csv\x18\x04 \x01(\fR\vmanifestCsv\"\x89\x01\n" +
+	"\vImportEntry\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1f\n" +
+	"\vuploaded_by\x18\x02 \x01(\tR\n" +
+	"uploadedBy\x12\x17\n" +
+	"\afile_id\x18\x03 \x01(\tR\x06fileId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xb4\x01\n" +
+	"\x15ImportArchiveResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\x05R\bimported\x12\x1a\n" +
+	"\brejected\x18\x02 \x01(\x05R\brejected\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12\x18\n" +
+	"\askipped\x18\x04 \x01(\x05R\askipped\x121\n" +
+	"\aentries\x18\x05 \x03(\v2\x17.storing.v1.ImportEntryR\aentries\")\n" +
+	"\x0eGetTaskRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"\xed\x03\n" +
+	"\x0fGetTaskResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x1f\n" +
+	"\vuploaded_by\x18\x04 \x01(\tR\n" +
+	"uploadedBy\x12\x1f\n" +
+	"\vuploaded_at\x18\x05 \x01(\tR\n" +
+	"uploadedAt\x12\x1b\n" +
+	"\tcourse_id\x18\x06 \x01(\tR\bcourseId\x12#\n" +
+	"\rassignment_id\x18\a \x01(\tR\fassignmentId\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12#\n" +
+	"\rstatus_reason\x18\t \x01(\tR\fstatusReason\x12\x12\n" +
+	"\x04size\x18\n" +
+	" \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\v \x01(\tR\x06sha256\x12!\n" +
+	"\fcontent_type\x18\f \x01(\tR\vcontentType\x12%\n" +
+	"\x0econtent_sha256\x18\r \x01(\tR\rcontentSha256\x12#\n" +
+	"\rsubmission_id\x18\x0e \x01(\tR\fsubmissionId\x12\x18\n" +
+	"\aversion\x18\x0f \x01(\x05R\aversion\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x10 \x01(\tR\tobjectKey\"\xca\x02\n" +
+	"\x10ListTasksRequest\x12\x1f\n" +
+	"\vuploaded_by\x18\x01 \x01(\tR\n" +
+	"uploadedBy\x12\x1b\n" +
+	"\tcourse_id\x18\x02 \x01(\tR\bcourseId\x12#\n" +
+	"\rassignment_id\x18\x03 \x01(\tR\fassignmentId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12!\n" +
+	"\fcreated_from\x18\x05 \x01(\tR\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\tR\tcreatedTo\x12\x1a\n" +
+	"\bfilename\x18\a \x01(\tR\bfilename\x12\x14\n" +
+	"\x05order\x18\b \x01(\tR\x05order\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\n" +
+	" \x01(\tR\x06cursor\x12\x19\n" +
+	"\bfile_ids\x18\v \x03(\tR\afileIds\"\xd4\x02\n" +
+	"\vTaskSummary\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1f\n" +
+	"\vuploaded_by\x18\x03 \x01(\tR\n" +
+	"uploadedBy\x12\x1f\n" +
+	"\vuploaded_at\x18\x04 \x01(\tR\n" +
+	"uploadedAt\x12\x1b\n" +
+	"\tcourse_id\x18\x05 \x01(\tR\bcourseId\x12#\n" +
+	"\rassignment_id\x18\x06 \x01(\tR\fassignmentId\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x12\n" +
+	"\x04size\x18\b \x01(\x03R\x04size\x12!\n" +
+	"\fcontent_type\x18\t \x01(\tR\vcontentType\x12#\n" +
+	"\rsubmission_id\x18\n" +
+	" \x01(\tR\fsubmissionId\x12\x18\n" +
+	"\aversion\x18\v \x01(\x05R\aversion\"c\n" +
+	"\x11ListTasksResponse\x12-\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x17.storing.v1.TaskSummaryR\x05tasks\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\";\n" +
+	"\x14GetSubmissionRequest\x12#\n" +
+	"\rsubmission_id\x18\x01 \x01(\tR\fsubmissionId\"\xf3\x01\n" +
+	"\x15GetSubmissionResponse\x12#\n" +
+	"\rsubmission_id\x18\x01 \x01(\tR\fsubmissionId\x12\x1f\n" +
+	"\vuploaded_by\x18\x02 \x01(\tR\n" +
+	"uploadedBy\x12\x1b\n" +
+	"\tcourse_id\x18\x03 \x01(\tR\bcourseId\x12#\n" +
+	"\rassignment_id\x18\x04 \x01(\tR\fassignmentId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x123\n" +
+	"\bversions\x18\x06 \x03(\v2\x17.storing.v1.TaskSummaryR\bversions\"2\n" +
+	"\x17GetAnalysisInputRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"\xb9\x02\n" +
+	"\x18GetAnalysisInputResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x02 \x01(\tR\tobjectKey\x12#\n" +
+	"\rassignment_id\x18\x03 \x01(\tR\fassignmentId\x12\x1f\n" +
+	"\vuploaded_by\x18\x04 \x01(\tR\n" +
+	"uploadedBy\x12\x1b\n" +
+	"\tcourse_id\x18\x05 \x01(\tR\bcourseId\x12(\n" +
+	"\x10exclude_task_ids\x18\x06 \x03(\tR\x0eexcludeTaskIds\x12!\n" +
+	"\fduplicate_of\x18\a \x01(\tR\vduplicateOf\x125\n" +
+	"\x17duplicate_of_object_key\x18\b \x01(\tR\x14duplicateOfObjectKey\"0\n" +
+	"\x15GetFileContentRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"2\n" +
+	"\x16GetFileContentResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\fR\acontent\"\xac\x01\n" +
+	"\x0fReadBlobRequest\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x01 \x01(\tR\tobjectKey\x12?\n" +
+	"\x06params\x18\x02 \x03(\v2'.storing.v1.ReadBlobRequest.ParamsEntryR\x06params\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"a\n" +
+	"\x10ReadBlobResponse\x12*\n" +
+	"\x04info\x18\x01 \x01(\v2\x14.storing.v1.BlobInfoH\x00R\x04info\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"b\n" +
+	"\bBlobInfo\x12\x12\n" +
+	"\x04size\x18\x01 \x01(\x03R\x04size\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1f\n" +
+	"\vmodified_at\x18\x03 \x01(\tR\n" +
+	"modifiedAt\"r\n" +
+	"\x10WriteBlobRequest\x12;\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1d.storing.v1.WriteBlobMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"\xb0\x01\n" +
+	"\x11WriteBlobMetadata\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x01 \x01(\tR\tobjectKey\x12A\n" +
+	"\x06params\x18\x02 \x03(\v2).storing.v1.WriteBlobMetadata.ParamsEntryR\x06params\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"'\n" +
+	"\x11WriteBlobResponse\x12\x12\n" +
+	"\x04size\x18\x01 \x01(\x03R\x04size\",\n" +
+	"\x11DeleteTaskRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"/\n" +
+	"\x12DeleteTaskResponse\x12\x19\n" +
+	"\bpurge_at\x18\x01 \x01(\tR\apurgeAt\"7\n" +
+	"\x14EraseUserDataRequest\x12\x1f\n" +
+	"\vuploaded_by\x18\x01 \x01(\tR\n" +
+	"uploadedBy\":\n" +
+	"\x15EraseUserDataResponse\x12!\n" +
+	"\ftasks_erased\x18\x01 \x01(\x05R\vtasksErased\".\n" +
+	"\x13RunRetentionRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\"\x9f\x01\n" +
+	"\rRetentionItem\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tcourse_id\x18\x02 \x01(\tR\bcourseId\x12\x1f\n" +
+	"\vuploaded_at\x18\x03 \x01(\tR\n" +
+	"uploadedAt\x12!\n" +
+	"\fretain_until\x18\x04 \x01(\tR\vretainUntil\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\x81\x02\n" +
+	"\x14RunRetentionResponse\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x18\n" +
+	"\aexpired\x18\x03 \x01(\x05R\aexpired\x12\x16\n" +
+	"\x06purged\x18\x04 \x01(\x05R\x06purged\x12\x16\n" +
+	"\x06failed\x18\x05 \x01(\x05R\x06failed\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\a \x01(\tR\n" +
+	"finishedAt\x12/\n" +
+	"\x05items\x18\b \x03(\v2\x19.storing.v1.RetentionItemR\x05items\"3\n" +
+	"\x18RunReconciliationRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\"v\n" +
+	"\x12ReconciliationItem\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x03 \x01(\tR\tobjectKey\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\x85\x03\n" +
+	"\x19RunReconciliationResponse\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12!\n" +
+	"\fstray_action\x18\x02 \x01(\tR\vstrayAction\x12'\n" +
+	"\x0fexpired_uploads\x18\x03 \x01(\x05R\x0eexpiredUploads\x12'\n" +
+	"\x0fresumed_uploads\x18\x04 \x01(\x05R\x0eresumedUploads\x12'\n" +
+	"\x0fmissing_objects\x18\x05 \x01(\x05R\x0emissingObjects\x12#\n" +
+	"\rstray_objects\x18\x06 \x01(\x05R\fstrayObjects\x12\x16\n" +
+	"\x06failed\x18\a \x01(\x05R\x06failed\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\b \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\t \x01(\tR\n" +
+	"finishedAt\x124\n" +
+	"\x05items\x18\n" +
+	" \x03(\v2\x1e.storing.v1.ReconciliationItemR\x05items\"\x1d\n" +
+	"\x1bRotateEncryptionKeysRequest\"n\n" +
+	"\x1cRotateEncryptionKeysResponse\x12\x18\n" +
+	"\achecked\x18\x01 \x01(\x05R\achecked\x12\x1c\n" +
+	"\trewrapped\x18\x02 \x01(\x05R\trewrapped\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"\\\n" +
+	"\x14GetQuotaUsageRequest\x12\x1f\n" +
+	"\vuploaded_by\x18\x01 \x01(\tR\n" +
+	"uploadedBy\x12#\n" +
+	"\rassignment_id\x18\x02 \x01(\tR\fassignmentId\"\\\n" +
+	"\tUserUsage\x12\x1f\n" +
+	"\vuploaded_by\x18\x01 \x01(\tR\n" +
+	"uploadedBy\x12\x18\n" +
+	"\auploads\x18\x02 \x01(\x03R\auploads\x12\x14\n" +
+	"\x05bytes\x18\x03 \x01(\x03R\x05bytes\"L\n" +
+	"\x0fAssignmentUsage\x12#\n" +
+	"\rassignment_id\x18\x01 \x01(\tR\fassignmentId\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x03R\x05bytes\"\xb4\x02\n" +
+	"\x15GetQuotaUsageResponse\x12\x16\n" +
+	"\x06window\x18\x01 \x01(\tR\x06window\x12\"\n" +
+	"\rmax_file_size\x18\x02 \x01(\x03R\vmaxFileSize\x12\x1f\n" +
+	"\vmax_uploads\x18\x03 \x01(\x03R\n" +
+	"maxUploads\x12$\n" +
+	"\x0emax_user_bytes\x18\x04 \x01(\x03R\fmaxUserBytes\x120\n" +
+	"\x14max_assignment_bytes\x18\x05 \x01(\x03R\x12maxAssignmentBytes\x12)\n" +
+	"\x04user\x18\x06 \x01(\v2\x15.storing.v1.UserUsageR\x04user\x12;\n" +
+	"\n" +
+	"assignment\x18\a \x01(\v2\x1b.storing.v1.AssignmentUsageR\n" +
+	"assignment2\xe0\n" +
+	"\n" +
+	"\x0eStoringService\x12K\n" +
+	"\n" +
+	"UploadTask\x12\x1d.storing.v1.UploadTaskRequest\x1a\x1e.storing.v1.UploadTaskResponse\x12_\n" +
+	"\x10UploadTaskStream\x12#.storing.v1.UploadTaskStreamRequest\x1a$.storing.v1.UploadTaskStreamResponse(\x01\x12V\n" +
+	"\rImportArchive\x12 .storing.v1.ImportArchiveRequest\x1a!.storing.v1.ImportArchiveResponse(\x01\x12B\n" +
+	"\aGetTask\x12\x1a.storing.v1.GetTaskRequest\x1a\x1b.storing.v1.GetTaskResponse\x12H\n" +
+	"\tListTasks\x12\x1c.storing.v1.ListTasksRequest\x1a\x1d.storing.v1.ListTasksResponse\x12T\n" +
+	"\rGetSubmission\x12 .storing.v1.GetSubmissionRequest\x1a!.storing.v1.GetSubmissionResponse\x12]\n" +
+	"\x10GetAnalysisInput\x12#.storing.v1.GetAnalysisInputRequest\x1a$.storing.v1.GetAnalysisInputResponse\x12W\n" +
+	"\x0eGetFileContent\x12!.storing.v1.GetFileContentRequest\x1a\".storing.v1.GetFileContentResponse\x12K\n" +
+	"\n" +
+	"DeleteTask\x12\x1d.storing.v1.DeleteTaskRequest\x1a\x1e.storing.v1.DeleteTaskResponse\x12T\n" +
+	"\rEraseUserData\x12 .storing.v1.EraseUserDataRequest\x1a!.storing.v1.EraseUserDataResponse\x12Q\n" +
+	"\fRunRetention\x12\x1f.storing.v1.RunRetentionRequest\x1a .storing.v1.RunRetentionResponse\x12`\n" +
+	"\x11RunReconciliation\x12$.storing.v1.RunReconciliationRequest\x1a%.storing.v1.RunReconciliationResponse\x12i\n" +
+	"\x14RotateEncryptionKeys\x12'.storing.v1.RotateEncryptionKeysRequest\x1a(.storing.v1.RotateEncryptionKeysResponse\x12T\n" +
+	"\rGetQuotaUsage\x12 .storing.v1.GetQuotaUsageRequest\x1a!.storing.v1.GetQuotaUsageResponse\x12G\n" +
+	"\bReadBlob\x12\x1b.storing.v1.ReadBlobRequest\x1a\x1c.storing.v1.ReadBlobResponse0\x01\x12J\n" +
+	"\tWriteBlob\x12\x1c.storing.v1.WriteBlobRequest\x1a\x1d.storing.v1.WriteBlobResponse(\x01B\tZ\apkg/apib\x06proto3"
+
+var (
+	file_storing_service_proto_rawDescOnce sync.Once
+	file_storing_service_proto_rawDescData []byte
+)
+
+func file_storing_service_proto_rawDescGZIP() []byte {
+	file_storing_service_proto_rawDescOnce.Do(func() {
+		file_storing_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_storing_service_proto_rawDesc), len(file_storing_service_proto_rawDesc)))
+	})
+	return file_storing_service_proto_rawDescData
+}
+
+var file_storing_service_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
+var file_storing_service_proto_goTypes = []any{
+	(*UploadTaskRequest)(nil),            // 0: storing.v1.UploadTaskRequest
+	(*UploadTaskResponse)(nil),           // 1: storing.v1.UploadTaskResponse
+	(*UploadTaskStreamRequest)(nil),      // 2: storing.v1.UploadTaskStreamRequest
+	(*UploadTaskMetadata)(nil),           // 3: storing.v1.UploadTaskMetadata
+	(*UploadTaskStreamResponse)(nil),     // 4: storing.v1.UploadTaskStreamResponse
+	(*ImportArchiveRequest)(nil),         // 5: storing.v1.ImportArchiveRequest
+	(*ImportArchiveMetadata)(nil),        // 6: storing.v1.ImportArchiveMetadata
+	(*ImportEntry)(nil),                  // 7: storing.v1.ImportEntry
+	(*ImportArchiveResponse)(nil),        // 8: storing.v1.ImportArchiveResponse
+	(*GetTaskRequest)(nil),               // 9: storing.v1.GetTaskRequest
+	(*GetTaskResponse)(nil),              // 10: storing.v1.GetTaskResponse
+	(*ListTasksRequest)(nil),             // 11: storing.v1.ListTasksRequest
+	(*TaskSummary)(nil),                  // 12: storing.v1.TaskSummary
+	(*ListTasksResponse)(nil),            // 13: storing.v1.ListTasksResponse
+	(*GetSubmissionRequest)(nil),         // 14: storing.v1.GetSubmissionRequest
+	(*GetSubmissionResponse)(nil),        // 15: storing.v1.GetSubmissionResponse
+	(*GetAnalysisInputRequest)(nil),      // 16: storing.v1.GetAnalysisInputRequest
+	(*GetAnalysisInputResponse)(nil),     // 17: storing.v1.GetAnalysisInputResponse
+	(*GetFileContentRequest)(nil),        // 18: storing.v1.GetFileContentRequest
+	(*GetFileContentResponse)(nil),       // 19: storing.v1.GetFileContentResponse
+	(*ReadBlobRequest)(nil),              // 20: storing.v1.ReadBlobRequest
+	(*ReadBlobResponse)(nil),             // 21: storing.v1.ReadBlobResponse
+	(*BlobInfo)(nil),                     // 22: storing.v1.BlobInfo
+	(*WriteBlobRequest)(nil),             // 23: storing.v1.WriteBlobRequest
+	(*WriteBlobMetadata)(nil),            // 24: storing.v1.WriteBlobMetadata
+	(*WriteBlobResponse)(nil),            // 25: storing.v1.WriteBlobResponse
+	(*DeleteTaskRequest)(nil),            // 26: storing.v1.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),           // 27: storing.v1.DeleteTaskResponse
+	(*EraseUserDataRequest)(nil),         // 28: storing.v1.EraseUserDataRequest
+	(*EraseUserDataResponse)(nil),        // 29: storing.v1.EraseUserDataResponse
+	(*RunRetentionRequest)(nil),          // 30: storing.v1.RunRetentionRequest
+	(*RetentionItem)(nil),                // 31: storing.v1.RetentionItem
+	(*RunRetentionResponse)(nil),         // 32: storing.v1.RunRetentionResponse
+	(*RunReconciliationRequest)(nil),     // 33: storing.v1.RunReconciliationRequest
+	(*ReconciliationItem)(nil),           // 34: storing.v1.ReconciliationItem
+	(*RunReconciliationResponse)(nil),    // 35: storing.v1.RunReconciliationResponse
+	(*RotateEncryptionKeysRequest)(nil),  // 36: storing.v1.RotateEncryptionKeysRequest
+	(*RotateEncryptionKeysResponse)(nil), // 37: storing.v1.RotateEncryptionKeysResponse
+	(*GetQuotaUsageRequest)(nil),         // 38: storing.v1.GetQuotaUsageRequest
+	(*UserUsage)(nil),                    // 39: storing.v1.UserUsage
+	(*AssignmentUsage)(nil),              // 40: storing.v1.AssignmentUsage
+	(*GetQuotaUsageResponse)(nil),        // 41: storing.v1.GetQuotaUsageResponse
+	nil,                                  // 42: storing.v1.UploadTaskResponse.FormDataEntry
+	nil,                                  // 43: storing.v1.ReadBlobRequest.ParamsEntry
+	nil,                                  // 44: storing.v1.WriteBlobMetadata.ParamsEntry
+}
+var file_storing_service_proto_depIdxs = []int32{
+	42, // 0: storing.v1.UploadTaskResponse.form_data:type_name -> storing.v1.UploadTaskResponse.FormDataEntry
+	3,  // 1: storing.v1.UploadTaskStreamRequest.metadata:type_name -> storing.v1.UploadTaskMetadata
+	6,  // 2: storing.v1.ImportArchiveRequest.metadata:type_name -> storing.v1.ImportArchiveMetadata
+	7,  // 3: storing.v1.ImportArchiveResponse.entries:type_name -> storing.v1.ImportEntry
+	12, // 4: storing.v1.ListTasksResponse.tasks:type_name -> storing.v1.TaskSummary
+	12, // 5: storing.v1.GetSubmissionResponse.versions:type_name -> storing.v1.TaskSummary
+	43, // 6: storing.v1.ReadBlobRequest.params:type_name -> storing.v1.ReadBlobRequest.ParamsEntry
+	22, // 7: storing.v1.ReadBlobResponse.info:type_name -> storing.v1.BlobInfo
+	24, // 8: storing.v1.WriteBlobRequest.metadata:type_name -> storing.v1.WriteBlobMetadata
+	44, // 9: storing.v1.WriteBlobMetadata.params:type_name -> storing.v1.WriteBlobMetadata.ParamsEntry
+	31, // 10: storing.v1.RunRetentionResponse.items:type_name -> storing.v1.RetentionItem
+	34, // 11: storing.v1.RunReconciliationResponse.items:type_name -> storing.v1.ReconciliationItem
+	39, // 12: storing.v1.GetQuotaUsageResponse.user:type_name -> storing.v1.UserUsage
+	40, // 13: storing.v1.GetQuotaUsageResponse.assignment:type_name -> storing.v1.AssignmentUsage
+	0,  // 14: storing.v1.StoringService.UploadTask:input_type -> storing.v1.UploadTaskRequest
+	2,  // 15: storing.v1.StoringService.UploadTaskStream:input_type -> storing.v1.UploadTaskStreamRequest
+	5,  // 16: storing.v1.StoringService.ImportArchive:input_type -> storing.v1.ImportArchiveRequest
+	9,  // 17: storing.v1.StoringService.GetTask:input_type -> storing.v1.GetTaskRequest
+	11, // 18: storing.v1.StoringService.ListTasks:input_type -> storing.v1.ListTasksRequest
+	14, // 19: storing.v1.StoringService.GetSubmission:input_type -> storing.v1.GetSubmissionRequest
+	16, // 20: storing.v1.StoringService.GetAnalysisInput:input_type -> storing.v1.GetAnalysisInputRequest
+	18, // 21: storing.v1.StoringService.GetFileContent:input_type -> storing.v1.GetFileContentRequest
+	26, // 22: storing.v1.StoringService.DeleteTask:input_type -> storing.v1.DeleteTaskRequest
+	28, // 23: storing.v1.StoringService.EraseUserData:input_type -> storing.v1.EraseUserDataRequest
+	30, // 24: storing.v1.StoringService.RunRetention:input_type -> storing.v1.RunRetentionRequest
+	33, // 25: storing.v1.StoringService.RunReconciliation:input_type -> storing.v1.RunReconciliationRequest
+	36, // 26: storing.v1.StoringService.RotateEncryptionKeys:input_type -> storing.v1.RotateEncryptionKeysRequest
+	38, // 27: storing.v1.StoringService.GetQuotaUsage:input_type -> storing.v1.GetQuotaUsageRequest
+	20, // 28: storing.v1.StoringService.ReadBlob:input_type -> storing.v1.ReadBlobRequest
+	23, // 29: storing.v1.StoringService.WriteBlob:input_type -> storing.v1.WriteBlobRequest
+	1,  // 30: storing.v1.StoringService.UploadTask:output_type -> storing.v1.UploadTaskResponse
+	4,  // 31: storing.v1.StoringService.UploadTaskStream:output_type -> storing.v1.UploadTaskStreamResponse
+	8,  // 32: storing.v1.StoringService.ImportArchive:output_type -> storing.v1.ImportArchiveResponse
+	10, // 33: storing.v1.StoringService.GetTask:output_type -> storing.v1.GetTaskResponse
+	13, // 34: storing.v1.StoringService.ListTasks:output_type -> storing.v1.ListTasksResponse
+	15, // 35: storing.v1.StoringService.GetSubmission:output_type -> storing.v1.GetSubmissionResponse
+	17, // 36: storing.v1.StoringService.GetAnalysisInput:output_type -> storing.v1.GetAnalysisInputResponse
+	19, // 37: storing.v1.StoringService.GetFileContent:output_type -> storing.v1.GetFileContentResponse
+	27, // 38: storing.v1.StoringService.DeleteTask:output_type -> storing.v1.DeleteTaskResponse
+	29, // 39: storing.v1.StoringService.EraseUserData:output_type -> storing.v1.EraseUserDataResponse
+	32, // 40: storing.v1.StoringService.RunRetention:output_type -> storing.v1.RunRetentionResponse
+	35, // 41: storing.v1.StoringService.RunReconciliation:output_type -> storing.v1.RunReconciliationResponse
+	37, // 42: storing.v1.StoringService.RotateEncryptionKeys:output_type -> storing.v1.RotateEncryptionKeysResponse
+	41, // 43: storing.v1.StoringService.GetQuotaUsage:output_type -> storing.v1.GetQuotaUsageResponse
+	21, // 44: storing.v1.StoringService.ReadBlob:output_type -> storing.v1.ReadBlobResponse
+	25, // 45: storing.v1.StoringService.WriteBlob:output_type -> storing.v1.WriteBlobResponse
+	30, // [30:46] is the sub-list for method output_type
+	14, // [14:30] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_storing_service_proto_init() }
+func file_storing_service_proto_init() {
+	if File_storing_service_proto != nil {
+		return
+	}
+	file_storing_service_proto_msgTypes[2].OneofWrappers = []any{
+		(*UploadTaskStreamRequest_Metadata)(nil),
+		(*UploadTaskStreamRequest_Chunk)(nil),
+	}
+	file_storing_service_proto_msgTypes[5].OneofWrappers = []any{
+		(*ImportArchiveRequest_Metadata)(nil),
+		(*ImportArchiveRequest_Chunk)(nil),
+	}
+	file_storing_service_proto_msgTypes[21].OneofWrappers = []any{
+		(*ReadBlobResponse_Info)(nil),
+		(*ReadBlobResponse_Chunk)(nil),
+	}
+	file_storing_service_proto_msgTypes[23].OneofWrappers = []any{
+		(*WriteBlobRequest_Metadata)(nil),
+		(*WriteBlobRequest_Chunk)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -413,7 +3372,7 @@ func file_storing_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_storing_service_proto_rawDesc), len(file_storing_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
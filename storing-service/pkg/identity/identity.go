@@ -0,0 +1,240 @@
+// Package identity подписывает и проверяет клиента gRPC-вызовов; общий для storing-service и analysis-service
+package identity
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"storing-service/pkg/tenant"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Ключи metadata с клиентом запроса. Клиента подписывает api-gateway (пользователи и сервисы)
+// или другой сервис системы (свои вызовы) общим секретом IDENTITY_SECRET.
+// Курсы преподавателя передаются повторением ключа x-auth-course.
+const (
+	SubjectMetadataKey   = "x-auth-subject"
+	KindMetadataKey      = "x-auth-kind"
+	RoleMetadataKey      = "x-auth-role"
+	CourseMetadataKey    = "x-auth-course"
+	SignatureMetadataKey = "x-auth-signature"
+)
+
+// Виды клиентов: пользователь по JWT, сервис по API-ключу и внутренний вызов другого сервиса системы
+const (
+	KindUser    = "user"
+	KindService = "service"
+	KindSystem  = "system"
+)
+
+const (
+	RoleStudent = "student"
+	RoleTeacher = "teacher"
+	RoleAdmin   = "admin"
+)
+
+// signatureTTL - срок действия подписи; подпись проверяется при начале вызова, поэтому длинные
+// потоки срок не ограничивает
+const signatureTTL = 5 * time.Minute
+
+// Identity - клиент запроса из подписанной metadata
+type Identity struct {
+	Subject string
+	Kind    string
+	Role    string
+	Tenant  string
+	Courses []string
+}
+
+// Sign возвращает подпись клиента для вызова method вида "<expires>.<hex hmac>"
+func Sign(secret []byte, method string, id Identity, expires time.Time) string {
+	unix := expires.Unix()
+	return strconv.FormatInt(unix, 10) + "." + hex.EncodeToString(mac(secret, method, id, unix))
+}
+
+// mac считает HMAC-SHA256 от JSON-массива полей: так значения с разделителями не склеиваются
+func mac(secret []byte, method string, id Identity, expires int64) []byte {
+	fields := append([]string{"v1", method, strconv.FormatInt(expires, 10), id.Subject, id.Kind, id.Role, id.Tenant}, id.Courses...)
+	payload, _ := json.Marshal(fields)
+
+	h := hmac.New(sha256.New, secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// Verify проверяет подпись клиента вызова method
+func Verify(secret []byte, method string, id Identity, signature string, now time.Time) error {
+	expiresValue, sum, ok := strings.Cut(signature, ".")
+	if !ok {
+		return fmt.Errorf("malformed identity signature")
+	}
+	expires, err := strconv.ParseInt(expiresValue, 10, 64)
+	if err != nil {
+		return fmt.Errorf("malformed identity signature")
+	}
+	if now.Unix() > expires {
+		return fmt.Errorf("identity signature expired")
+	}
+	if expires > now.Add(2*signatureTTL).Unix() {
+		return fmt.Errorf("identity signature expires too late")
+	}
+
+	got, err := hex.DecodeString(sum)
+	if err != nil || !hmac.Equal(got, mac(secret, method, id, expires)) {
+		return fmt.Errorf("invalid identity signature")
+	}
+	return validate(id)
+}
+
+// validate отклоняет подписанные, но неизвестные виды и роли клиентов
+func validate(id Identity) error {
+	if id.Subject == "" {
+		return fmt.Errorf("identity has no subject")
+	}
+	switch id.Kind {
+	case KindUser, KindService, KindSystem:
+	default:
+		return fmt.Errorf("unknown identity kind %q", id.Kind)
+	}
+	switch id.Role {
+	case RoleStudent, RoleTeacher, RoleAdmin:
+	default:
+		return fmt.Errorf("unknown identity role %q", id.Role)
+	}
+	// Тенант по умолчанию не подставляется: клиент без тенанта не получает доступ ни к чьим данным
+	if !tenant.Valid(id.Tenant) {
+		return fmt.Errorf("identity has no valid tenant")
+	}
+	return nil
+}
+
+type identityKey struct{}
+
+// WithIdentity сохраняет проверенного клиента в контексте вызова
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext возвращает проверенного клиента вызова; false - клиента нет (публичный метод или
+// внутренний вызов без сети)
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// FromMetadata читает клиента из metadata вызова
+func FromMetadata(md metadata.MD) Identity {
+	return Identity{
+		Subject: firstValue(md, SubjectMetadataKey),
+		Kind:    firstValue(md, KindMetadataKey),
+		Role:    firstValue(md, RoleMetadataKey),
+		Tenant:  firstValue(md, tenant.MetadataKey),
+		Courses: md.Get(CourseMetadataKey),
+	}
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Verifier проверяет подпись клиента входящих вызовов. Методы publicMethods вызываются без клиента:
+// они проверяют собственную подпись запроса (подписанные ссылки на файлы).
+type Verifier struct {
+	secret        []byte
+	publicMethods map[string]bool
+}
+
+func NewVerifier(secret []byte, publicMethods ...string) *Verifier {
+	v := &Verifier{
+		secret:        secret,
+		publicMethods: make(map[string]bool, len(publicMethods)),
+	}
+	for _, method := range publicMethods {
+		v.publicMethods[method] = true
+	}
+	return v
+}
+
+// UnaryServerInterceptor отклоняет вызовы без действительной подписи клиента с codes.Unauthenticated
+func (v *Verifier) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := v.verify(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor отклоняет потоки без действительной подписи клиента с codes.Unauthenticated
+func (v *Verifier) StreamServerInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := v.verify(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &identityStream{ServerStream: stream, ctx: ctx})
+}
+
+// verify проверяет подпись и сохраняет клиента и его тенант в контексте; обработчики и репозитории
+// доверяют только им
+func (v *Verifier) verify(ctx context.Context, method string) (context.Context, error) {
+	if v.publicMethods[method] {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	signature := firstValue(md, SignatureMetadataKey)
+	if signature == "" {
+		return nil, status.Error(codes.Unauthenticated, "identity signature is required")
+	}
+	id := FromMetadata(md)
+	if err := Verify(v.secret, method, id, signature, time.Now()); err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return tenant.WithTenant(WithIdentity(ctx, id), id.Tenant), nil
+}
+
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
+
+// SystemClientInterceptor подписывает исходящие вызовы как внутренний клиент subject с правами
+// администратора в тенанте контекста. Вызов из контекста без тенанта не отправляется.
+func SystemClientInterceptor(secret []byte, subject string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		tenantId, ok := tenant.Lookup(ctx)
+		if !ok {
+			return status.Errorf(codes.Internal, "internal call %s has no tenant", method)
+		}
+		id := Identity{
+			Subject: subject,
+			Kind:    KindSystem,
+			Role:    RoleAdmin,
+			Tenant:  tenantId,
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx,
+			SubjectMetadataKey, id.Subject,
+			KindMetadataKey, id.Kind,
+			RoleMetadataKey, id.Role,
+			tenant.MetadataKey, id.Tenant,
+			SignatureMetadataKey, Sign(secret, method, id, time.Now().Add(signatureTTL)))
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
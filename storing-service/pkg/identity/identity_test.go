@@ -0,0 +1,231 @@
+package identity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"storing-service/pkg/tenant"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	testSecret = []byte("0123456789abcdef0123456789abcdef")
+	testMethod = "/storing.v1.StoringService/GetTask"
+	testId     = Identity{
+		Subject: "2c9b7f6e-8d3a-4c1e-9f0a-5b6d7e8f9a0b",
+		Kind:    KindUser,
+		Role:    RoleTeacher,
+		Tenant:  "uni",
+		Courses: []string{"cs101", "cs102"},
+	}
+)
+
+// Та же подпись проверяется в тестах api-gateway: формат подписи у них должен совпадать
+func TestSignMatchesGatewayVector(t *testing.T) {
+	const want = "1767225600.8dc7f2e222174cdd1c3a9618558f1dbfd9da68400328ab006358dddfb586ad24"
+	if got := Sign(testSecret, testMethod, testId, time.Unix(1767225600, 0)); got != want {
+		t.Fatalf("Sign = %s, want %s", got, want)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Now()
+	signature := Sign(testSecret, testMethod, testId, now.Add(time.Minute))
+
+	if err := Verify(testSecret, testMethod, testId, signature, now); err != nil {
+		t.Fatalf("valid signature rejected: %v", err)
+	}
+
+	admin := testId
+	admin.Role = RoleAdmin
+	otherTenant := testId
+	otherTenant.Tenant = "other"
+	moreCourses := testId
+	moreCourses.Courses = append([]string{"cs999"}, testId.Courses...)
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		method    string
+		id        Identity
+		signature string
+	}{
+		{"forged role", testSecret, testMethod, admin, signature},
+		{"forged tenant", testSecret, testMethod, otherTenant, signature},
+		{"forged courses", testSecret, testMethod, moreCourses, signature},
+		{"other method", testSecret, "/storing.v1.StoringService/DeleteTask", testId, signature},
+		{"other secret", []byte("fedcba9876543210fedcba9876543210"), testMethod, testId, signature},
+		{"expired", testSecret, testMethod, testId, Sign(testSecret, testMethod, testId, now.Add(-time.Second))},
+		{"expires too late", testSecret, testMethod, testId, Sign(testSecret, testMethod, testId, now.Add(time.Hour))},
+		{"malformed", testSecret, testMethod, testId, "signature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(tt.secret, tt.method, tt.id, tt.signature, now); err == nil {
+				t.Fatal("signature accepted")
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsUnknownRole(t *testing.T) {
+	id := testId
+	id.Role = "superuser"
+	now := time.Now()
+	if err := Verify(testSecret, testMethod, id, Sign(testSecret, testMethod, id, now.Add(time.Minute)), now); err == nil {
+		t.Fatal("unknown role accepted")
+	}
+}
+
+func TestVerifyRequiresTenant(t *testing.T) {
+	now := time.Now()
+	for _, tenantId := range []string{"", "Other Tenant", "../uni"} {
+		id := testId
+		id.Tenant = tenantId
+		if err := Verify(testSecret, testMethod, id, Sign(testSecret, testMethod, id, now.Add(time.Minute)), now); err == nil {
+			t.Errorf("identity with tenant %q accepted", tenantId)
+		}
+	}
+}
+
+func signedContext(id Identity, method string) context.Context {
+	md := metadata.Pairs(
+		SubjectMetadataKey, id.Subject,
+		KindMetadataKey, id.Kind,
+		RoleMetadataKey, id.Role,
+		tenant.MetadataKey, id.Tenant,
+		SignatureMetadataKey, Sign(testSecret, method, id, time.Now().Add(time.Minute)))
+	for _, course := range id.Courses {
+		md.Append(CourseMetadataKey, course)
+	}
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func callUnary(v *Verifier, ctx context.Context, method string) (Identity, bool, error) {
+	var (
+		got    Identity
+		found  bool
+		called bool
+	)
+	_, err := v.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req any) (any, error) {
+			called = true
+			got, found = FromContext(ctx)
+			return nil, nil
+		})
+	if err == nil && !called {
+		panic("handler was not called")
+	}
+	return got, found, err
+}
+
+func TestVerifierRejectsCallsWithoutMetadata(t *testing.T) {
+	v := NewVerifier(testSecret)
+
+	_, _, err := callUnary(v, context.Background(), testMethod)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("err = %v, want Unauthenticated", err)
+	}
+
+	// Клиент без подписи, как раньше передавал api-gateway
+	md := metadata.Pairs(SubjectMetadataKey, testId.Subject, KindMetadataKey, KindUser, RoleMetadataKey, RoleAdmin)
+	_, _, err = callUnary(v, metadata.NewIncomingContext(context.Background(), md), testMethod)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("unsigned identity: err = %v, want Unauthenticated", err)
+	}
+}
+
+func TestVerifierStoresSignedIdentity(t *testing.T) {
+	v := NewVerifier(testSecret)
+
+	got, found, err := callUnary(v, signedContext(testId, testMethod), testMethod)
+	if err != nil {
+		t.Fatalf("signed call rejected: %v", err)
+	}
+	if !found || got.Subject != testId.Subject || got.Role != testId.Role || len(got.Courses) != 2 {
+		t.Fatalf("identity = %+v (found %v), want %+v", got, found, testId)
+	}
+
+	// Подпись одного метода не подходит для другого
+	_, _, err = callUnary(v, signedContext(testId, testMethod), "/storing.v1.StoringService/DeleteTask")
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("replayed signature: err = %v, want Unauthenticated", err)
+	}
+}
+
+func TestVerifierStoresSignedTenant(t *testing.T) {
+	v := NewVerifier(testSecret)
+
+	var got string
+	var found bool
+	_, err := v.UnaryServerInterceptor(signedContext(testId, testMethod), nil, &grpc.UnaryServerInfo{FullMethod: testMethod},
+		func(ctx context.Context, req any) (any, error) {
+			got, found = tenant.Lookup(ctx)
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatalf("signed call rejected: %v", err)
+	}
+	if !found || got != testId.Tenant {
+		t.Fatalf("tenant = %q (found %v), want %q", got, found, testId.Tenant)
+	}
+
+	// Тенант без подписи не принимается: подпись считается и по тенанту
+	md := metadata.Pairs(
+		SubjectMetadataKey, testId.Subject,
+		KindMetadataKey, testId.Kind,
+		RoleMetadataKey, testId.Role,
+		tenant.MetadataKey, "other",
+		SignatureMetadataKey, Sign(testSecret, testMethod, testId, time.Now().Add(time.Minute)))
+	_, _, err = callUnary(v, metadata.NewIncomingContext(context.Background(), md), testMethod)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("forged tenant: err = %v, want Unauthenticated", err)
+	}
+}
+
+func TestVerifierSkipsPublicMethods(t *testing.T) {
+	const public = "/storing.v1.StoringService/ReadBlob"
+	v := NewVerifier(testSecret, public)
+
+	_, found, err := callUnary(v, context.Background(), public)
+	if err != nil {
+		t.Fatalf("public method rejected: %v", err)
+	}
+	if found {
+		t.Fatal("public method got an identity")
+	}
+}
+
+func TestSystemClientInterceptorRequiresTenant(t *testing.T) {
+	interceptor := SystemClientInterceptor(testSecret, "storing-service")
+	invoke := func(ctx context.Context) (metadata.MD, error) {
+		var md metadata.MD
+		err := interceptor(ctx, testMethod, nil, nil, nil,
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ = metadata.FromOutgoingContext(ctx)
+				return nil
+			})
+		return md, err
+	}
+
+	if _, err := invoke(context.Background()); err == nil {
+		t.Fatal("internal call without tenant was sent")
+	}
+
+	md, err := invoke(tenant.WithTenant(context.Background(), "uni"))
+	if err != nil {
+		t.Fatalf("internal call rejected: %v", err)
+	}
+	id := FromMetadata(md)
+	if err := Verify(testSecret, testMethod, id, md.Get(SignatureMetadataKey)[0], time.Now()); err != nil {
+		t.Fatalf("internal call signature: %v", err)
+	}
+	if id.Tenant != "uni" || id.Kind != KindSystem {
+		t.Fatalf("identity = %+v, want system client of tenant uni", id)
+	}
+}
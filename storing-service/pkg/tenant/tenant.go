@@ -0,0 +1,75 @@
+// Package tenant - тенант запроса в контексте и ключах объектов; общий для storing-service и analysis-service
+package tenant
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+const (
+	// Default - тенант данных, созданных до появления тенантов. Клиент попадает в него только явно:
+	// вызов без тенанта в подписанной metadata отклоняется (см. identity)
+	Default = "default"
+	// MetadataKey - ключ gRPC metadata с тенантом клиента; тенант входит в подпись клиента (см. identity)
+	MetadataKey = "x-auth-tenant"
+	// KeyRoot - каталог хранилища с файлами тенантов, кроме тенанта по умолчанию
+	KeyRoot = "tenants/"
+)
+
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// Valid проверяет идентификатор тенанта: он входит в ключи объектов, поэтому допускаются только
+// строчная латиница, цифры, '_' и '-'
+func Valid(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+type tenantKey struct{}
+
+// WithTenant сохраняет тенант в контексте; по нему репозитории ограничивают все запросы
+func WithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// Lookup возвращает тенант, сохраненный в контексте; false - тенант не задан
+func Lookup(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantKey{}).(string)
+	return id, ok && id != ""
+}
+
+// FromContext возвращает тенант запроса. Вызовы клиентов всегда несут тенант из подписанной metadata,
+// фоновые задачи - тенант, который они обрабатывают; Default возвращается только вызовам без клиента
+// (подписанные ссылки на файлы), которые к данным тенантов не обращаются.
+func FromContext(ctx context.Context) string {
+	if id, ok := Lookup(ctx); ok {
+		return id
+	}
+	return Default
+}
+
+// Detach возвращает фоновый контекст с тенантом ctx: задачи, запущенные из запроса,
+// не отменяются вместе с ним, но остаются в его тенанте
+func Detach(ctx context.Context) context.Context {
+	if id, ok := Lookup(ctx); ok {
+		return WithTenant(context.Background(), id)
+	}
+	return context.Background()
+}
+
+// Prefix возвращает префикс ключей объектов тенанта. Файлы тенанта по умолчанию лежат в корне
+// хранилища, как до появления тенантов, остальные - в tenants/{id}/.
+func Prefix(id string) string {
+	if id == Default {
+		return ""
+	}
+	return KeyRoot + id + "/"
+}
+
+// OwnsKey сообщает, что объект принадлежит тенанту
+func OwnsKey(id, key string) bool {
+	if id == Default {
+		return !strings.HasPrefix(key, KeyRoot)
+	}
+	return strings.HasPrefix(key, Prefix(id))
+}